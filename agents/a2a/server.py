@@ -62,6 +62,7 @@ class A2AServer:
     def _register_routes(self) -> None:
         app = self.app
 
+        @app.get("/.well-known/agent.json", response_model=AgentCard)
         @app.get("/a2a/agent-card", response_model=AgentCard)
         def get_agent_card() -> AgentCard:
             return AgentCard(name=self.agent_name, skills=self.skills)
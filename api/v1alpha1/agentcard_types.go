@@ -54,6 +54,12 @@ type AgentCardSpec struct {
 	// +optional
 	HealthCheck *AgentHealthCheck `json:"healthCheck,omitempty"`
 
+	// cardPath is the URL path where the agent publishes its own A2A agent card, which
+	// the controller compares with spec.skills to detect drift
+	// +kubebuilder:default="/.well-known/agent.json"
+	// +optional
+	CardPath *string `json:"cardPath,omitempty"`
+
 	// maxConcurrentTasks limits how many tasks this agent handles simultaneously
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:default=5
@@ -74,6 +80,10 @@ type AgentSkill struct {
 	// +optional
 	Description *string `json:"description,omitempty"`
 
+	// version is the version of this skill the agent is expected to serve (e.g. "1.2.0")
+	// +optional
+	Version *string `json:"version,omitempty"`
+
 	// inputSchema is a JSON Schema defining the expected input (as raw JSON)
 	// +optional
 	InputSchema *string `json:"inputSchema,omitempty"`
@@ -100,12 +110,29 @@ type AgentEndpoint struct {
 	// +kubebuilder:default="/a2a"
 	// +optional
 	BasePath *string `json:"basePath,omitempty"`
+
+	// tls makes the controller and peer agents reach this agent over HTTPS
+	// +optional
+	TLS *AgentTLS `json:"tls,omitempty"`
+}
+
+// AgentTLS configures HTTPS for an agent endpoint
+type AgentTLS struct {
+	// caSecret is the name of a Secret whose "ca.crt" key holds the PEM CA bundle that
+	// signed the agent's certificate; the system roots are used when unset
+	// +optional
+	CASecret *string `json:"caSecret,omitempty"`
+
+	// serverName is the name verified against the agent's certificate (default: host)
+	// +optional
+	ServerName *string `json:"serverName,omitempty"`
 }
 
 // AgentAuth configures authentication for inter-agent communication
 type AgentAuth struct {
 	// type specifies the authentication mechanism
-	// +kubebuilder:validation:Enum=serviceAccount;bearer;none
+	// "mtls" presents the client certificate from clientCertSecret and implies HTTPS
+	// +kubebuilder:validation:Enum=serviceAccount;bearer;mtls;none
 	// +kubebuilder:default=serviceAccount
 	// +optional
 	Type *string `json:"type,omitempty"`
@@ -114,6 +141,12 @@ type AgentAuth struct {
 	// Only used when type is "bearer"
 	// +optional
 	TokenSecret *SecretKeyRef `json:"tokenSecret,omitempty"`
+
+	// clientCertSecret is the name of a kubernetes.io/tls Secret ("tls.crt", "tls.key")
+	// holding the client certificate presented to the agent
+	// Only used when type is "mtls"
+	// +optional
+	ClientCertSecret *string `json:"clientCertSecret,omitempty"`
 }
 
 // AgentHealthCheck defines how the controller probes agent liveness
@@ -134,6 +167,12 @@ type AgentHealthCheck struct {
 	// +kubebuilder:default=5
 	// +optional
 	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// failureThreshold is how many consecutive failed checks turn an Available agent Degraded
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
 }
 
 // AgentCardStatus defines the observed state of an AgentCard
@@ -147,10 +186,21 @@ type AgentCardStatus struct {
 	// +optional
 	LastHeartbeat *metav1.Time `json:"lastHeartbeat,omitempty"`
 
+	// consecutiveFailures counts health checks failed since the last successful one
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
 	// activeTaskCount is the number of tasks currently being processed
 	// +optional
 	ActiveTaskCount int32 `json:"activeTaskCount,omitempty"`
 
+	// reservations lists the task slots held on this agent; activeTaskCount is their number
+	// +listType=map
+	// +listMapKey=holder
+	// +listMapKey=skill
+	// +optional
+	Reservations []TaskReservation `json:"reservations,omitempty"`
+
 	// totalTasksCompleted is the lifetime count of completed tasks
 	// +optional
 	TotalTasksCompleted int64 `json:"totalTasksCompleted,omitempty"`
@@ -166,6 +216,18 @@ type AgentCardStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// TaskReservation is a task slot reserved on an agent by the scheduler
+type TaskReservation struct {
+	// holder is the name of the AgentWorkload in this namespace holding the slot
+	Holder string `json:"holder"`
+
+	// skill is the skill the slot was reserved for
+	Skill string `json:"skill"`
+
+	// reservedAt is when the slot was reserved
+	ReservedAt metav1.Time `json:"reservedAt"`
+}
+
 // SkillStatus reports the availability of a single skill
 type SkillStatus struct {
 	// name matches the skill's name in spec.skills[]
@@ -174,6 +236,14 @@ type SkillStatus struct {
 	// available indicates if this skill is currently operational
 	Available bool `json:"available"`
 
+	// version is the skill version advertised by the agent's published card
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// message explains why the skill is unavailable or differs from spec.skills[]
+	// +optional
+	Message string `json:"message,omitempty"`
+
 	// lastUsed is when this skill was last invoked
 	// +optional
 	LastUsed *metav1.Time `json:"lastUsed,omitempty"`
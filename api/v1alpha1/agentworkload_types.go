@@ -170,24 +170,81 @@ type AgentPersona struct {
 
 // AgentRef references an AgentCard and assigns it a role in the workload
 type AgentRef struct {
-	// name is the name of the AgentCard CR in the same namespace
+	// name is the name of the AgentCard CR in the same namespace.
+	// Leave it empty and set skill to let the operator pick the AgentCard.
 	// +kubebuilder:validation:MinLength=1
-	Name string `json:"name"`
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// skill is the skill this agent is delegated. Without a name, the sub-task runs on the
+	// Available AgentCard serving the skill with the most spare capacity; only specialists
+	// in "delegation" mode may omit the name.
+	// +optional
+	Skill *string `json:"skill,omitempty"`
 
 	// role describes this agent's function in the workload (e.g. "lead", "analyst", "collector")
 	// +optional
 	Role *string `json:"role,omitempty"`
 }
 
-// OrchestrationSpec defines orchestration settings for Argo Workflows
+// OrchestrationSpec defines how the workload's agent runtime is orchestrated
 type OrchestrationSpec struct {
-	// type is the orchestration engine (e.g. "argo")
+	// type is the orchestration engine
+	// "argo" = run the referenced Argo WorkflowTemplate (requires Argo Workflows)
+	// "job" = run the agent runtime image as Kubernetes Jobs, with no Argo dependency
+	// +kubebuilder:validation:Enum=argo;job
 	// +optional
 	Type *string `json:"type,omitempty"`
 
+	// job configures the "job" orchestration engine
+	// +optional
+	Job *JobOrchestrationSpec `json:"job,omitempty"`
+
 	// workflowTemplateRef references the Argo WorkflowTemplate to use
 	// +optional
 	WorkflowTemplateRef *WorkflowTemplateRef `json:"workflowTemplateRef,omitempty"`
+
+	// workflowRetention controls what happens to the Argo Workflow when the AgentWorkload is deleted
+	// "delete" = delete the Workflow and garbage-collect its artifacts
+	// "retain" = keep the Workflow and its artifacts as history for auditing
+	// +kubebuilder:validation:Enum=delete;retain
+	// +kubebuilder:default=delete
+	// +optional
+	WorkflowRetention *string `json:"workflowRetention,omitempty"`
+}
+
+// JobOrchestrationSpec configures the built-in Kubernetes Job orchestration engine
+type JobOrchestrationSpec struct {
+	// image is the agent runtime image (default: the image used by the Argo template)
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// steps run one after another, each as its own Job; a step starts once the previous
+	// one succeeded. Without steps, a single Job runs the image entrypoint.
+	// +optional
+	Steps []JobStep `json:"steps,omitempty"`
+
+	// backoffLimit is the number of retries of each step's Job before it is marked failed
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+}
+
+// JobStep is one step of a Job orchestration sequence
+type JobStep struct {
+	// name identifies the step (e.g. "scraper"); it must be a DNS label
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// command overrides the image entrypoint (e.g. ["python", "-m", "agents.scraper"])
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// args are passed to the command
+	// +optional
+	Args []string `json:"args,omitempty"`
 }
 
 // WorkflowTemplateRef references a WorkflowTemplate
@@ -232,6 +289,14 @@ type TimeoutSpec struct {
 	// suspendGate is the timeout for suspend gate approval in seconds
 	// +optional
 	SuspendGate *int32 `json:"suspendGate,omitempty"`
+
+	// suspendGatePolicy decides what happens when an approval gate waits longer than suspendGate
+	// "reject" = automatically reject the pending actions (a suspended Argo Workflow is stopped and the workload fails)
+	// "escalate" = keep waiting and raise the ApprovalEscalated condition
+	// +kubebuilder:validation:Enum=reject;escalate
+	// +kubebuilder:default=reject
+	// +optional
+	SuspendGatePolicy *string `json:"suspendGatePolicy,omitempty"`
 }
 
 // LLMProvider defines an LLM provider configuration
@@ -244,13 +309,15 @@ type LLMProvider struct {
 
 	// type specifies the provider type
 	// "openai-compatible" = OpenAI API or compatible (e.g. vLLM, LocalAI)
+	// "anthropic" = Anthropic Messages API
 	// "workers-ai" = Cloudflare Workers AI
-	// "custom" = custom provider type
-	// +kubebuilder:validation:Enum=openai-compatible;workers-ai;custom
+	// "custom" = external provider plugin described in customConfig
+	// +kubebuilder:validation:Enum=openai-compatible;anthropic;workers-ai;custom
 	Type string `json:"type"`
 
 	// endpoint is the API base URL for the provider (e.g. "https://api.openai.com/v1")
-	// Required for openai-compatible providers
+	// Required for openai-compatible providers; anthropic providers default to
+	// "https://api.anthropic.com/v1"
 	// +optional
 	Endpoint *string `json:"endpoint,omitempty"`
 
@@ -261,6 +328,9 @@ type LLMProvider struct {
 	APIKeySecret *SecretKeyRef `json:"apiKeySecret,omitempty"`
 
 	// customConfig allows arbitrary provider-specific configuration
+	// (anthropic: "anthropicVersion" sets the anthropic-version header;
+	// workers-ai: "accountId" and optional AI Gateway "gateway";
+	// custom: plugin "protocol" ("http" or "grpc"), grpc "address" and "tls")
 	// +optional
 	CustomConfig map[string]string `json:"customConfig,omitempty"`
 }
@@ -295,6 +365,18 @@ type Action struct {
 
 	// approved indicates if this action was approved
 	Approved *bool `json:"approved,omitempty"`
+
+	// proposal is the raw MCP proposal (JSON) replayed to execute_action once the action is approved
+	// +optional
+	Proposal string `json:"proposal,omitempty"`
+
+	// reviewedBy identifies who approved or rejected this action
+	// +optional
+	ReviewedBy string `json:"reviewedBy,omitempty"`
+
+	// reviewedAt is when the approval or rejection was recorded
+	// +optional
+	ReviewedAt *metav1.Time `json:"reviewedAt,omitempty"`
 }
 
 // ArgoWorkflowRef references an Argo Workflow CR
@@ -313,9 +395,35 @@ type ArgoWorkflowRef struct {
 	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
 }
 
+// WorkflowNodeStatus reports the progress of a single Argo Workflow or Job step
+type WorkflowNodeStatus struct {
+	// name is the step's display name (e.g. "scraper", "approval-gate")
+	Name string `json:"name"`
+
+	// type is the Argo node type (Pod, Suspend, Skipped, ...) or "Job"
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// phase is the step phase: Pending, Running, Succeeded, Failed, Error, Skipped, Omitted
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// startedAt is when the step started
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// finishedAt is when the step finished
+	// +optional
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+
+	// message explains the step's phase, e.g. why it failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // AgentWorkloadStatus defines the observed state of AgentWorkload.
 type AgentWorkloadStatus struct {
-	// phase is the current lifecycle phase: Pending, Running, Completed, Failed
+	// phase is the current lifecycle phase: Pending, Running, PendingApproval, Completed, Failed
 	// +optional
 	Phase string `json:"phase,omitempty"`
 
@@ -323,6 +431,11 @@ type AgentWorkloadStatus struct {
 	// +optional
 	ReadyAgents int32 `json:"readyAgents,omitempty"`
 
+	// startTime is when the operator first started executing this workload.
+	// Used as the reference point for spec.timeouts.execution.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
 	// lastReconcileTime is the last time the resource was reconciled
 	// +optional
 	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
@@ -335,6 +448,10 @@ type AgentWorkloadStatus struct {
 	// +optional
 	ExecutedActions []Action `json:"executedActions,omitempty"`
 
+	// rejectedActions is a list of proposed actions that a reviewer rejected
+	// +optional
+	RejectedActions []Action `json:"rejectedActions,omitempty"`
+
 	// argoWorkflow references the associated Argo Workflow CR
 	// Set when the operator creates a workflow for Argo orchestration
 	// +optional
@@ -346,8 +463,20 @@ type AgentWorkloadStatus struct {
 	// +optional
 	ArgoPhase string `json:"argoPhase,omitempty"`
 
-	// workflowArtifacts maps workflow step names to their artifact locations
-	// Example: {"scraper": "s3://bucket/job_id/raw_html.json"}
+	// argoMessage is the Argo Workflow status message, e.g. the reason it failed
+	// +optional
+	ArgoMessage string `json:"argoMessage,omitempty"`
+
+	// argoProgress is the number of completed workflow steps out of the total, e.g. "3/5"
+	// +optional
+	ArgoProgress string `json:"argoProgress,omitempty"`
+
+	// workflowNodes reports the status of each step of the Argo Workflow or Job sequence
+	// +optional
+	WorkflowNodes []WorkflowNodeStatus `json:"workflowNodes,omitempty"`
+
+	// workflowArtifacts maps workflow step output artifacts ("<step>/<artifact>") to their S3 locations
+	// Example: {"scraper/raw-html": "s3://bucket/job_id/raw_html.json"}
 	// +optional
 	WorkflowArtifacts map[string]string `json:"workflowArtifacts,omitempty"`
 
@@ -368,13 +497,49 @@ type AgentWorkloadStatus struct {
 	// agentStatuses reports per-agent status when collaborationMode is "team" or "delegation"
 	// +optional
 	AgentStatuses []AgentInstanceStatus `json:"agentStatuses,omitempty"`
+
+	// tokensUsed counts the LLM tokens the workload consumed through model routing
+	// +optional
+	TokensUsed *TokenUsage `json:"tokensUsed,omitempty"`
+}
+
+// TokenUsage counts the LLM tokens consumed by a workload across its model calls in the
+// calendar month of the latest call, in the time zone of the tenant's quota windows; the
+// first call of a new month starts the count over
+type TokenUsage struct {
+	// inputTokens is the number of prompt tokens
+	InputTokens int64 `json:"inputTokens"`
+
+	// outputTokens is the number of completion tokens
+	OutputTokens int64 `json:"outputTokens"`
+
+	// provider is the LLM provider that served the latest call
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// model is the model that served the latest call
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// recordedAt is when the latest call consumed its tokens
+	// +optional
+	RecordedAt *metav1.Time `json:"recordedAt,omitempty"`
 }
 
 // AgentInstanceStatus reports the status of a single agent in a collaborative workload
 type AgentInstanceStatus struct {
-	// name matches the AgentCard name from spec.agentRefs[]
+	// name matches the AgentCard name from spec.agentRefs[], or the AgentCard the sub-task
+	// of an agentRef naming only a skill was scheduled on; it is empty until then
 	Name string `json:"name"`
 
+	// skill is the skill from spec.agentRefs[]
+	// +optional
+	Skill string `json:"skill,omitempty"`
+
+	// role is the agent's role from spec.agentRefs[]
+	// +optional
+	Role string `json:"role,omitempty"`
+
 	// phase is this agent's individual lifecycle state
 	// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
 	Phase string `json:"phase"`
@@ -383,6 +548,10 @@ type AgentInstanceStatus struct {
 	// +optional
 	TasksCompleted int32 `json:"tasksCompleted,omitempty"`
 
+	// taskId is the A2A task delegated to this agent in "delegation" mode
+	// +optional
+	TaskID string `json:"taskId,omitempty"`
+
 	// lastActivity is when this agent last processed a task
 	// +optional
 	LastActivity *metav1.Time `json:"lastActivity,omitempty"`
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -27,19 +29,74 @@ import (
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Annotations used to review actions held in PendingApproval. The controller reads them;
+// ReviewedByAnnotation is written by the mutating webhook, never by the reviewer.
+const (
+	ApproveActionsAnnotation = "agentworkload.clawdlinux.io/approve-actions"
+	RejectActionsAnnotation  = "agentworkload.clawdlinux.io/reject-actions"
+	ReviewedByAnnotation     = "agentworkload.clawdlinux.io/reviewed-by"
 )
 
 var agentworkloadlog = logf.Log.WithName("agentworkload-resource")
 
 func (r *AgentWorkload) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, r).
+		WithDefaulter(&agentWorkloadDefaulter{}).
 		Complete()
 }
 
+// agentWorkloadDefaulter applies spec defaults and records who wrote approval decisions
+type agentWorkloadDefaulter struct{}
+
+// Default implements admission.Defaulter using the admission request's userInfo
+func (d *agentWorkloadDefaulter) Default(ctx context.Context, r *AgentWorkload) error {
+	r.Default()
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	var old *AgentWorkload
+	if len(req.OldObject.Raw) > 0 {
+		old = &AgentWorkload{}
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return fmt.Errorf("failed to decode old object: %w", err)
+		}
+	}
+	r.setReviewer(old, req.UserInfo.Username)
+	return nil
+}
+
+// setReviewer sets ReviewedByAnnotation to username when the request writes an approval
+// decision. A reviewer supplied in the request itself is discarded: an unchanged decision
+// keeps the recorded reviewer and no decision means no reviewer.
+func (r *AgentWorkload) setReviewer(old *AgentWorkload, username string) {
+	approve := r.Annotations[ApproveActionsAnnotation]
+	reject := r.Annotations[RejectActionsAnnotation]
+	if approve == "" && reject == "" {
+		delete(r.Annotations, ReviewedByAnnotation)
+		return
+	}
+
+	if old != nil && approve == old.Annotations[ApproveActionsAnnotation] && reject == old.Annotations[RejectActionsAnnotation] {
+		if reviewer, ok := old.Annotations[ReviewedByAnnotation]; ok {
+			r.Annotations[ReviewedByAnnotation] = reviewer
+		} else {
+			delete(r.Annotations, ReviewedByAnnotation)
+		}
+		return
+	}
+	r.Annotations[ReviewedByAnnotation] = username
+}
+
 // Default implements DefaultingWebhook so a webhook will be registered for the type
 func (r *AgentWorkload) Default() {
 	agentworkloadlog.Info("default", "name", r.Name)
@@ -178,6 +235,31 @@ func (r *AgentWorkload) validate() error {
 		}
 	}
 
+	// 7. Validate orchestration.workflowTemplateRef (a reference must name a template)
+	if r.Spec.Orchestration != nil && r.Spec.Orchestration.WorkflowTemplateRef != nil {
+		ref := r.Spec.Orchestration.WorkflowTemplateRef
+		if ref.Name == nil || *ref.Name == "" {
+			allErrs = append(allErrs, "orchestration.workflowTemplateRef.name must not be empty")
+		}
+	}
+
+	// 8. Validate resources quantities
+	if r.Spec.Resources != nil {
+		allErrs = append(allErrs, validateResourceRequirements("resources.requests", r.Spec.Resources.Requests)...)
+		allErrs = append(allErrs, validateResourceRequirements("resources.limits", r.Spec.Resources.Limits)...)
+	}
+
+	// 9. Validate orchestration engine and Job steps
+	if r.Spec.Orchestration != nil {
+		allErrs = append(allErrs, validateOrchestration(r.Spec.Orchestration)...)
+	}
+
+	// 10. Validate LLM providers
+	allErrs = append(allErrs, validateProviders(r.Spec.Providers)...)
+
+	// 11. Validate agentRefs
+	allErrs = append(allErrs, validateAgentRefs(r.Spec.CollaborationMode, r.Spec.AgentRefs)...)
+
 	// Combine errors
 	if len(allErrs) > 0 {
 		errMsg := strings.Join(allErrs, "; ")
@@ -193,6 +275,25 @@ func (r *AgentWorkload) validate() error {
 	return nil
 }
 
+// validateResourceRequirements checks that cpu and memory parse as Kubernetes quantities
+func validateResourceRequirements(path string, req *ResourceRequirements) []string {
+	if req == nil {
+		return nil
+	}
+	var errs []string
+	if req.CPU != nil {
+		if _, err := resource.ParseQuantity(*req.CPU); err != nil {
+			errs = append(errs, fmt.Sprintf("%s.cpu is not a valid quantity: %q", path, *req.CPU))
+		}
+	}
+	if req.Memory != nil {
+		if _, err := resource.ParseQuantity(*req.Memory); err != nil {
+			errs = append(errs, fmt.Sprintf("%s.memory is not a valid quantity: %q", path, *req.Memory))
+		}
+	}
+	return errs
+}
+
 // validateMCPEndpoint validates the MCP server endpoint
 func validateMCPEndpoint(endpoint string) error {
 	if endpoint == "" {
@@ -321,3 +422,134 @@ func ResolveEndpointIP(endpoint string) (string, error) {
 
 	return ips[0].String(), nil
 }
+
+// validateOrchestration checks spec.orchestration.type and the Job engine's steps
+func validateOrchestration(spec *OrchestrationSpec) []string {
+	var errs []string
+	if spec.Type != nil {
+		validTypes := []string{"argo", "job"}
+		if !isStringInSlice(*spec.Type, validTypes) {
+			errs = append(errs, fmt.Sprintf("orchestration.type must be one of %v, got %q", validTypes, *spec.Type))
+		}
+	}
+	if spec.Job == nil {
+		return errs
+	}
+	if spec.Type == nil || *spec.Type != "job" {
+		errs = append(errs, "orchestration.job requires orchestration.type \"job\"")
+	}
+	seen := map[string]bool{}
+	for i, step := range spec.Job.Steps {
+		if step.Name == "" {
+			errs = append(errs, fmt.Sprintf("orchestration.job.steps[%d].name must not be empty", i))
+			continue
+		}
+		if seen[step.Name] {
+			errs = append(errs, fmt.Sprintf("orchestration.job.steps[%d].name %q is duplicated", i, step.Name))
+		}
+		seen[step.Name] = true
+	}
+	return errs
+}
+
+// validateAgentRefs checks that every agentRef names an AgentCard or a skill, and that only
+// "delegation" specialists leave the AgentCard to the scheduler: the lead (the agentRef with
+// role "lead", else the first) and "team" agents must be named. A workload holds one task
+// slot per skill on an agent, so two agentRefs without a name can't share a skill.
+func validateAgentRefs(mode *string, refs []AgentRef) []string {
+	var errs []string
+	lead := 0
+	for i, ref := range refs {
+		if ref.Role != nil && *ref.Role == "lead" {
+			lead = i
+			break
+		}
+	}
+	scheduled := map[string]bool{}
+	for i, ref := range refs {
+		if ref.Name != "" {
+			continue
+		}
+		switch {
+		case ref.Skill != nil && scheduled[*ref.Skill]:
+			errs = append(errs, fmt.Sprintf("agentRefs[%d] repeats skill %q of another agentRef without a name", i, *ref.Skill))
+		case ref.Skill == nil || *ref.Skill == "":
+			errs = append(errs, fmt.Sprintf("agentRefs[%d] must set name or skill", i))
+		case mode == nil || *mode != "delegation":
+			errs = append(errs, fmt.Sprintf("agentRefs[%d].name is required unless collaborationMode is \"delegation\"", i))
+		case i == lead:
+			errs = append(errs, fmt.Sprintf("agentRefs[%d].name is required for the delegation lead", i))
+		}
+		if ref.Skill != nil {
+			scheduled[*ref.Skill] = true
+		}
+	}
+	return errs
+}
+
+// validProviderTypes mirrors the LLMProvider type enum
+var validProviderTypes = []string{"openai-compatible", "anthropic", "workers-ai", "custom"}
+
+// validateProviders checks each provider's type and the settings its type requires
+func validateProviders(providers []LLMProvider) []string {
+	var errs []string
+	seen := map[string]bool{}
+	for i, provider := range providers {
+		path := fmt.Sprintf("providers[%d]", i)
+		if seen[provider.Name] {
+			errs = append(errs, fmt.Sprintf("%s.name %q is duplicated", path, provider.Name))
+		}
+		seen[provider.Name] = true
+
+		if !isStringInSlice(provider.Type, validProviderTypes) {
+			errs = append(errs, fmt.Sprintf("%s.type must be one of %v, got %q", path, validProviderTypes, provider.Type))
+			continue
+		}
+		if provider.Endpoint != nil {
+			parsedURL, err := url.Parse(*provider.Endpoint)
+			if err != nil || (parsedURL.Scheme != "https" && parsedURL.Scheme != "http") || parsedURL.Host == "" {
+				errs = append(errs, fmt.Sprintf("%s.endpoint must be an http(s) URL, got %q", path, *provider.Endpoint))
+			}
+		}
+
+		switch provider.Type {
+		case "openai-compatible":
+			if provider.Endpoint == nil || *provider.Endpoint == "" {
+				errs = append(errs, fmt.Sprintf("%s.endpoint is required for openai-compatible providers", path))
+			}
+		case "anthropic":
+			// The Messages API rejects unauthenticated calls
+			if provider.APIKeySecret == nil {
+				errs = append(errs, fmt.Sprintf("%s.apiKeySecret is required for anthropic providers", path))
+			}
+		case "workers-ai":
+			if provider.APIKeySecret == nil {
+				errs = append(errs, fmt.Sprintf("%s.apiKeySecret is required for workers-ai providers", path))
+			}
+			if provider.Endpoint == nil && provider.CustomConfig["accountId"] == "" {
+				errs = append(errs, fmt.Sprintf("%s.customConfig.accountId is required for workers-ai providers without an endpoint", path))
+			}
+		case "custom":
+			errs = append(errs, validatePluginConfig(path, provider)...)
+		}
+	}
+	return errs
+}
+
+// validatePluginConfig checks that a custom provider describes a reachable plugin
+func validatePluginConfig(path string, provider LLMProvider) []string {
+	var errs []string
+	switch protocol := provider.CustomConfig["protocol"]; protocol {
+	case "", "http":
+		if provider.Endpoint == nil || *provider.Endpoint == "" {
+			errs = append(errs, fmt.Sprintf("%s.endpoint is required for http plugins", path))
+		}
+	case "grpc":
+		if provider.CustomConfig["address"] == "" {
+			errs = append(errs, fmt.Sprintf("%s.customConfig.address is required for grpc plugins", path))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("%s.customConfig.protocol must be \"http\" or \"grpc\", got %q", path, protocol))
+	}
+	return errs
+}
@@ -17,7 +17,15 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func TestWebhook_RejectInvalidWorkloadType(t *testing.T) {
@@ -154,6 +162,163 @@ func TestWebhook_RejectObjectiveTooLong(t *testing.T) {
 	}
 }
 
+func TestWebhook_RejectEmptyWorkflowTemplateRefName(t *testing.T) {
+	workload := &AgentWorkload{
+		Spec: AgentWorkloadSpec{
+			MCPServerEndpoint: stringPtr("https://localhost:8000"),
+			Objective:         stringPtr("test objective"),
+			Agents:            []string{"agent1"},
+			Orchestration: &OrchestrationSpec{
+				Type:                stringPtr("argo"),
+				WorkflowTemplateRef: &WorkflowTemplateRef{Namespace: stringPtr("team-a")},
+			},
+		},
+	}
+
+	err := workload.ValidateCreate()
+	if err == nil {
+		t.Error("Expected validation error for workflowTemplateRef without name, got nil")
+	} else {
+		t.Logf("✅ Correctly rejected: %v", err)
+	}
+}
+
+func TestWebhook_RejectInvalidResourceQuantity(t *testing.T) {
+	workload := &AgentWorkload{
+		Spec: AgentWorkloadSpec{
+			MCPServerEndpoint: stringPtr("https://localhost:8000"),
+			Objective:         stringPtr("test objective"),
+			Agents:            []string{"agent1"},
+			Resources: &ResourceSpec{
+				Requests: &ResourceRequirements{CPU: stringPtr("500m")},
+				Limits:   &ResourceRequirements{Memory: stringPtr("lots")},
+			},
+		},
+	}
+
+	err := workload.ValidateCreate()
+	if err == nil {
+		t.Error("Expected validation error for invalid memory limit, got nil")
+	} else {
+		t.Logf("✅ Correctly rejected: %v", err)
+	}
+}
+
+func TestWebhook_ValidateJobOrchestration(t *testing.T) {
+	newWorkload := func(orchestration *OrchestrationSpec) *AgentWorkload {
+		return &AgentWorkload{
+			Spec: AgentWorkloadSpec{
+				MCPServerEndpoint: stringPtr("https://localhost:8000"),
+				Objective:         stringPtr("test objective"),
+				Agents:            []string{"agent1"},
+				Orchestration:     orchestration,
+			},
+		}
+	}
+
+	valid := newWorkload(&OrchestrationSpec{
+		Type: stringPtr("job"),
+		Job:  &JobOrchestrationSpec{Steps: []JobStep{{Name: "scraper"}, {Name: "synthesis"}}},
+	})
+	if err := valid.ValidateCreate(); err != nil {
+		t.Errorf("Expected job orchestration to be accepted, got %v", err)
+	}
+
+	invalid := map[string]*OrchestrationSpec{
+		"unknown type":     {Type: stringPtr("tekton")},
+		"job without type": {Job: &JobOrchestrationSpec{}},
+		"duplicate steps": {
+			Type: stringPtr("job"),
+			Job:  &JobOrchestrationSpec{Steps: []JobStep{{Name: "scraper"}, {Name: "scraper"}}},
+		},
+	}
+	for name, orchestration := range invalid {
+		if err := newWorkload(orchestration).ValidateCreate(); err == nil {
+			t.Errorf("%s: expected validation error, got nil", name)
+		}
+	}
+}
+
+func TestWebhook_ValidateProviders(t *testing.T) {
+	newWorkload := func(providers ...LLMProvider) *AgentWorkload {
+		return &AgentWorkload{
+			Spec: AgentWorkloadSpec{
+				MCPServerEndpoint: stringPtr("https://localhost:8000"),
+				Objective:         stringPtr("test objective"),
+				Agents:            []string{"agent1"},
+				Providers:         providers,
+			},
+		}
+	}
+	apiKey := &SecretKeyRef{Name: "anthropic-credentials"}
+
+	valid := newWorkload(
+		LLMProvider{Name: "openai", Type: "openai-compatible", Endpoint: stringPtr("https://api.openai.com/v1")},
+		LLMProvider{Name: "claude", Type: "anthropic", APIKeySecret: apiKey},
+		LLMProvider{Name: "cloudflare", Type: "workers-ai", APIKeySecret: apiKey, CustomConfig: map[string]string{"accountId": "acct"}},
+		LLMProvider{Name: "in-house", Type: "custom", CustomConfig: map[string]string{"protocol": "grpc", "address": "plugin:9000"}},
+	)
+	if err := valid.ValidateCreate(); err != nil {
+		t.Errorf("Expected providers of every type to be accepted, got %v", err)
+	}
+
+	invalid := map[string]LLMProvider{
+		"unknown type":                 {Name: "gemini", Type: "vertex"},
+		"openai without endpoint":      {Name: "openai", Type: "openai-compatible"},
+		"anthropic without api key":    {Name: "claude", Type: "anthropic"},
+		"anthropic with bad endpoint":  {Name: "claude", Type: "anthropic", APIKeySecret: apiKey, Endpoint: stringPtr("api.anthropic.com")},
+		"workers-ai without account":   {Name: "cloudflare", Type: "workers-ai", APIKeySecret: apiKey},
+		"http plugin without endpoint": {Name: "in-house", Type: "custom"},
+		"grpc plugin without address":  {Name: "in-house", Type: "custom", CustomConfig: map[string]string{"protocol": "grpc"}},
+		"unknown plugin protocol":      {Name: "in-house", Type: "custom", CustomConfig: map[string]string{"protocol": "thrift"}},
+	}
+	for name, provider := range invalid {
+		if err := newWorkload(provider).ValidateCreate(); err == nil {
+			t.Errorf("%s: expected validation error, got nil", name)
+		}
+	}
+
+	duplicate := newWorkload(
+		LLMProvider{Name: "claude", Type: "anthropic", APIKeySecret: apiKey},
+		LLMProvider{Name: "claude", Type: "anthropic", APIKeySecret: apiKey},
+	)
+	if err := duplicate.ValidateCreate(); err == nil {
+		t.Error("duplicate provider names: expected validation error, got nil")
+	}
+}
+
+func TestWebhook_ValidateAgentRefs(t *testing.T) {
+	newWorkload := func(mode string, refs ...AgentRef) *AgentWorkload {
+		return &AgentWorkload{
+			Spec: AgentWorkloadSpec{
+				MCPServerEndpoint: stringPtr("https://localhost:8000"),
+				Objective:         stringPtr("test objective"),
+				Agents:            []string{"agent1"},
+				CollaborationMode: stringPtr(mode),
+				AgentRefs:         refs,
+			},
+		}
+	}
+	lead := AgentRef{Name: "planner", Role: stringPtr("lead")}
+	scraper := AgentRef{Skill: stringPtr("scrape")}
+
+	if err := newWorkload("delegation", scraper, lead).ValidateCreate(); err != nil {
+		t.Errorf("Expected a skill-only specialist to be accepted, got %v", err)
+	}
+
+	invalid := map[string]*AgentWorkload{
+		"neither name nor skill": newWorkload("delegation", lead, AgentRef{}),
+		"skill-only in team":     newWorkload("team", lead, scraper),
+		"skill-only lead":        newWorkload("delegation", scraper, AgentRef{Name: "analyst"}),
+		"repeated skill-only":    newWorkload("delegation", lead, scraper, scraper),
+	}
+	for name, workload := range invalid {
+		if err := workload.ValidateCreate(); err == nil {
+			t.Errorf("%s: expected validation error, got nil", name)
+		}
+	}
+}
+
 func TestWebhook_AcceptAllWorkloadTypes(t *testing.T) {
 	workloadTypes := []string{"generic", "ceph", "minio", "postgres", "aws", "kubernetes"}
 
@@ -273,3 +438,51 @@ func TestValidateMCPEndpoint(t *testing.T) {
 		}
 	}
 }
+
+// TestDefaulter_RecordsReviewerFromUserInfo tests that the reviewer comes from the
+// admission request and cannot be self-asserted
+func TestDefaulter_RecordsReviewerFromUserInfo(t *testing.T) {
+	admit := func(old, workload *AgentWorkload, username string) *AgentWorkload {
+		t.Helper()
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: username},
+		}}
+		if old != nil {
+			raw, _ := json.Marshal(old)
+			req.OldObject = runtime.RawExtension{Raw: raw}
+		}
+		ctx := admission.NewContextWithRequest(context.Background(), req)
+		if err := (&agentWorkloadDefaulter{}).Default(ctx, workload); err != nil {
+			t.Fatalf("Default failed: %v", err)
+		}
+		return workload
+	}
+	annotated := func(annotations map[string]string) *AgentWorkload {
+		return &AgentWorkload{ObjectMeta: metav1.ObjectMeta{Name: "w", Annotations: annotations}}
+	}
+
+	pending := annotated(nil)
+	approved := admit(pending, annotated(map[string]string{
+		ApproveActionsAnnotation: "scale",
+		ReviewedByAnnotation:     "someone-else",
+	}), "alice")
+	if got := approved.Annotations[ReviewedByAnnotation]; got != "alice" {
+		t.Fatalf("expected reviewer alice from userInfo, got %q", got)
+	}
+
+	relabelled := annotated(map[string]string{
+		ApproveActionsAnnotation: "scale",
+		ReviewedByAnnotation:     "mallory",
+	})
+	if got := admit(approved, relabelled, "mallory").Annotations[ReviewedByAnnotation]; got != "alice" {
+		t.Errorf("expected unchanged decision to keep reviewer alice, got %q", got)
+	}
+
+	forged := admit(pending, annotated(map[string]string{ReviewedByAnnotation: "mallory"}), "mallory")
+	if _, ok := forged.Annotations[ReviewedByAnnotation]; ok {
+		t.Errorf("expected reviewer without a decision to be dropped, got %v", forged.Annotations)
+	}
+	if forged.Spec.OPAPolicy == nil || *forged.Spec.OPAPolicy != "strict" {
+		t.Errorf("expected spec defaults to be applied, got %v", forged.Spec.OPAPolicy)
+	}
+}
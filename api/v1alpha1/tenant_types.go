@@ -39,8 +39,34 @@ type TenantSpec struct {
 	// SLATarget is the target SLA percentage (e.g., 99.5)
 	SLATarget float64 `json:"slaTarget,omitempty"`
 
-	// NetworkPolicy enables network isolation for this tenant
+	// NetworkPolicy enables network isolation for this tenant: traffic in and out of the
+	// tenant namespace is denied except DNS, the shared-services namespace and ProviderEgress
 	NetworkPolicy bool `json:"networkPolicy,omitempty"`
+
+	// ProviderEgress lists the network destinations of the tenant's providers that the
+	// network policy allows. When empty, HTTPS to public (non-private) addresses is allowed.
+	// +optional
+	ProviderEgress []TenantEgress `json:"providerEgress,omitempty"`
+
+	// RetentionPolicy decides what happens to the tenant namespace when the Tenant is deleted.
+	// "Delete" removes the namespace and everything provisioned in it (default).
+	// "Retain" keeps the namespace and its data but revokes access: the copied provider
+	// secrets, agent ServiceAccount, Role and RoleBinding are removed.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	RetentionPolicy string `json:"retentionPolicy,omitempty"`
+}
+
+// TenantEgress is a destination tenant workloads may reach when network isolation is on
+type TenantEgress struct {
+	// CIDR of the destination, e.g. "203.0.113.0/24"
+	// +kubebuilder:validation:MinLength=1
+	CIDR string `json:"cidr"`
+
+	// Ports are the TCP ports allowed on the destination (default 443)
+	// +optional
+	Ports []int32 `json:"ports,omitempty"`
 }
 
 // TenantQuotas defines resource limits per tenant
@@ -59,6 +85,11 @@ type TenantQuotas struct {
 
 	// MemoryLimit is the memory resource limit for this tenant
 	MemoryLimit string `json:"memoryLimit,omitempty"`
+
+	// Timezone is the IANA time zone (such as Europe/Berlin) whose midnight and first of
+	// the month start the tenant's daily and monthly quota windows. Defaults to the
+	// operator's --quota-timezone.
+	Timezone string `json:"timezone,omitempty"`
 }
 
 // TenantStatus defines the observed state of Tenant
@@ -85,12 +116,26 @@ type TenantStatus struct {
 	// NetworkPolicyActive indicates if network policies are active
 	NetworkPolicyActive bool `json:"networkPolicyActive,omitempty"`
 
-	// WorkloadCount is the number of active workloads for this tenant
+	// WorkloadCount is the number of active (not Completed or Failed) workloads for this tenant
 	WorkloadCount int `json:"workloadCount,omitempty"`
 
+	// WorkloadsByPhase counts the tenant's AgentWorkloads per phase
+	// +optional
+	WorkloadsByPhase map[string]int `json:"workloadsByPhase,omitempty"`
+
 	// TokensUsedThisMonth tracks monthly token usage
 	TokensUsedThisMonth int64 `json:"tokensUsedThisMonth,omitempty"`
 
+	// TokensMonthStart is the start of the monthly window TokensUsedThisMonth counts.
+	// Usage only grows within a window and starts over in the next one.
+	// +optional
+	TokensMonthStart *metav1.Time `json:"tokensMonthStart,omitempty"`
+
+	// CostTodayUSD is the cost of the tenant's workloads since midnight UTC, as reported by
+	// the cost reporter
+	// +optional
+	CostTodayUSD float64 `json:"costTodayUSD,omitempty"`
+
 	// LastReconciliation is the timestamp of last successful reconciliation
 	LastReconciliation *metav1.Time `json:"lastReconciliation,omitempty"`
 }
@@ -101,6 +146,7 @@ type TenantStatus struct {
 // +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.namespace`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Workloads",type=integer,JSONPath=`.status.workloadCount`
+// +kubebuilder:printcolumn:name="Tokens",type=integer,JSONPath=`.status.tokensUsedThisMonth`,priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Tenant represents a multi-tenant customer with isolated resources
@@ -167,6 +213,33 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 		copy(*out, *in)
 	}
 	in.Quotas.DeepCopyInto(&out.Quotas)
+	if in.ProviderEgress != nil {
+		in, out := &in.ProviderEgress, &out.ProviderEgress
+		*out = make([]TenantEgress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy for TenantEgress
+func (in *TenantEgress) DeepCopy() *TenantEgress {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantEgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto for TenantEgress
+func (in *TenantEgress) DeepCopyInto(out *TenantEgress) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy for TenantQuotas
@@ -204,6 +277,17 @@ func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.WorkloadsByPhase != nil {
+		in, out := &in.WorkloadsByPhase, &out.WorkloadsByPhase
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TokensMonthStart != nil {
+		in, out := &in.TokensMonthStart, &out.TokensMonthStart
+		*out = (*in).DeepCopy()
+	}
 	if in.LastReconciliation != nil {
 		in, out := &in.LastReconciliation, &out.LastReconciliation
 		*out = (*in).DeepCopy()
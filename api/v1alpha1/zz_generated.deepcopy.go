@@ -21,6 +21,10 @@ func (in *Action) DeepCopyInto(out *Action) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ReviewedAt != nil {
+		in, out := &in.ReviewedAt, &out.ReviewedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Action.
@@ -46,6 +50,11 @@ func (in *AgentAuth) DeepCopyInto(out *AgentAuth) {
 		*out = new(SecretKeyRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClientCertSecret != nil {
+		in, out := &in.ClientCertSecret, &out.ClientCertSecret
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentAuth.
@@ -148,6 +157,11 @@ func (in *AgentCardSpec) DeepCopyInto(out *AgentCardSpec) {
 		*out = new(AgentHealthCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CardPath != nil {
+		in, out := &in.CardPath, &out.CardPath
+		*out = new(string)
+		**out = **in
+	}
 	if in.MaxConcurrentTasks != nil {
 		in, out := &in.MaxConcurrentTasks, &out.MaxConcurrentTasks
 		*out = new(int32)
@@ -172,6 +186,13 @@ func (in *AgentCardStatus) DeepCopyInto(out *AgentCardStatus) {
 		in, out := &in.LastHeartbeat, &out.LastHeartbeat
 		*out = (*in).DeepCopy()
 	}
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = make([]TaskReservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Skills != nil {
 		in, out := &in.Skills, &out.Skills
 		*out = make([]SkillStatus, len(*in))
@@ -211,6 +232,11 @@ func (in *AgentEndpoint) DeepCopyInto(out *AgentEndpoint) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(AgentTLS)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEndpoint.
@@ -241,6 +267,11 @@ func (in *AgentHealthCheck) DeepCopyInto(out *AgentHealthCheck) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHealthCheck.
@@ -300,6 +331,11 @@ func (in *AgentPersona) DeepCopy() *AgentPersona {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentRef) DeepCopyInto(out *AgentRef) {
 	*out = *in
+	if in.Skill != nil {
+		in, out := &in.Skill, &out.Skill
+		*out = new(string)
+		**out = **in
+	}
 	if in.Role != nil {
 		in, out := &in.Role, &out.Role
 		*out = new(string)
@@ -325,6 +361,11 @@ func (in *AgentSkill) DeepCopyInto(out *AgentSkill) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
 	if in.InputSchema != nil {
 		in, out := &in.InputSchema, &out.InputSchema
 		*out = new(string)
@@ -347,6 +388,31 @@ func (in *AgentSkill) DeepCopy() *AgentSkill {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentTLS) DeepCopyInto(out *AgentTLS) {
+	*out = *in
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServerName != nil {
+		in, out := &in.ServerName, &out.ServerName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTLS.
+func (in *AgentTLS) DeepCopy() *AgentTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentWorkload) DeepCopyInto(out *AgentWorkload) {
 	*out = *in
@@ -535,6 +601,10 @@ func (in *AgentWorkloadSpec) DeepCopy() *AgentWorkloadSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentWorkloadStatus) DeepCopyInto(out *AgentWorkloadStatus) {
 	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
 	if in.LastReconcileTime != nil {
 		in, out := &in.LastReconcileTime, &out.LastReconcileTime
 		*out = (*in).DeepCopy()
@@ -553,11 +623,25 @@ func (in *AgentWorkloadStatus) DeepCopyInto(out *AgentWorkloadStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RejectedActions != nil {
+		in, out := &in.RejectedActions, &out.RejectedActions
+		*out = make([]Action, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ArgoWorkflow != nil {
 		in, out := &in.ArgoWorkflow, &out.ArgoWorkflow
 		*out = new(ArgoWorkflowRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WorkflowNodes != nil {
+		in, out := &in.WorkflowNodes, &out.WorkflowNodes
+		*out = make([]WorkflowNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.WorkflowArtifacts != nil {
 		in, out := &in.WorkflowArtifacts, &out.WorkflowArtifacts
 		*out = make(map[string]string, len(*in))
@@ -579,6 +663,11 @@ func (in *AgentWorkloadStatus) DeepCopyInto(out *AgentWorkloadStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TokensUsed != nil {
+		in, out := &in.TokensUsed, &out.TokensUsed
+		*out = new(TokenUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentWorkloadStatus.
@@ -610,6 +699,63 @@ func (in *ArgoWorkflowRef) DeepCopy() *ArgoWorkflowRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobOrchestrationSpec) DeepCopyInto(out *JobOrchestrationSpec) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]JobStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobOrchestrationSpec.
+func (in *JobOrchestrationSpec) DeepCopy() *JobOrchestrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobOrchestrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobStep) DeepCopyInto(out *JobStep) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStep.
+func (in *JobStep) DeepCopy() *JobStep {
+	if in == nil {
+		return nil
+	}
+	out := new(JobStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LLMProvider) DeepCopyInto(out *LLMProvider) {
 	*out = *in
@@ -650,11 +796,21 @@ func (in *OrchestrationSpec) DeepCopyInto(out *OrchestrationSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(JobOrchestrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.WorkflowTemplateRef != nil {
 		in, out := &in.WorkflowTemplateRef, &out.WorkflowTemplateRef
 		*out = new(WorkflowTemplateRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WorkflowRetention != nil {
+		in, out := &in.WorkflowRetention, &out.WorkflowRetention
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestrationSpec.
@@ -756,6 +912,22 @@ func (in *SkillStatus) DeepCopy() *SkillStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskReservation) DeepCopyInto(out *TaskReservation) {
+	*out = *in
+	in.ReservedAt.DeepCopyInto(&out.ReservedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskReservation.
+func (in *TaskReservation) DeepCopy() *TaskReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TimeoutSpec) DeepCopyInto(out *TimeoutSpec) {
 	*out = *in
@@ -769,6 +941,11 @@ func (in *TimeoutSpec) DeepCopyInto(out *TimeoutSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.SuspendGatePolicy != nil {
+		in, out := &in.SuspendGatePolicy, &out.SuspendGatePolicy
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeoutSpec.
@@ -781,6 +958,48 @@ func (in *TimeoutSpec) DeepCopy() *TimeoutSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenUsage) DeepCopyInto(out *TokenUsage) {
+	*out = *in
+	if in.RecordedAt != nil {
+		in, out := &in.RecordedAt, &out.RecordedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenUsage.
+func (in *TokenUsage) DeepCopy() *TokenUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowNodeStatus) DeepCopyInto(out *WorkflowNodeStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowNodeStatus.
+func (in *WorkflowNodeStatus) DeepCopy() *WorkflowNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowTemplateRef) DeepCopyInto(out *WorkflowTemplateRef) {
 	*out = *in
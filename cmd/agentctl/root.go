@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/discovery"
@@ -38,10 +39,13 @@ import (
 var (
 	agentWorkloadGVR = schema.GroupVersionResource{Group: "agentic.clawdlinux.org", Version: "v1alpha1", Resource: "agentworkloads"}
 	workflowGVR      = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "workflows"}
+	tenantGVR        = schema.GroupVersionResource{Group: "agentic.clawdlinux.org", Version: "v1alpha1", Resource: "tenants"}
 )
 
 const (
 	costAnnotationKey          = "agentworkload.clawdlinux.io/cost-usd-today"
+	approveActionsAnnotation   = "agentworkload.clawdlinux.io/approve-actions"
+	rejectActionsAnnotation    = "agentworkload.clawdlinux.io/reject-actions"
 	defaultLiteLLMURL          = "http://litellm.agent-system.svc:4000"
 	defaultArgoNamespace       = "argo-workflows"
 	defaultOperatorNamespace   = "agentic-system"
@@ -70,6 +74,20 @@ type workloadRow struct {
 	Age       string  `json:"age" yaml:"age"`
 }
 
+type tenantRow struct {
+	Name             string         `json:"name" yaml:"name"`
+	Namespace        string         `json:"namespace" yaml:"namespace"`
+	TenantNamespace  string         `json:"tenantNamespace" yaml:"tenantNamespace"`
+	Phase            string         `json:"phase" yaml:"phase"`
+	Workloads        int64          `json:"workloads" yaml:"workloads"`
+	WorkloadsByPhase map[string]int `json:"workloadsByPhase,omitempty" yaml:"workloadsByPhase,omitempty"`
+	TokensThisMonth  int64          `json:"tokensThisMonth" yaml:"tokensThisMonth"`
+	MaxMonthlyTokens int64          `json:"maxMonthlyTokens,omitempty" yaml:"maxMonthlyTokens,omitempty"`
+	CostToday        float64        `json:"costToday" yaml:"costToday"`
+	Quota            string         `json:"quota" yaml:"quota"`
+	QuotaMessage     string         `json:"quotaMessage,omitempty" yaml:"quotaMessage,omitempty"`
+}
+
 type costRow struct {
 	Workload    string  `json:"workload" yaml:"workload"`
 	Namespace   string  `json:"namespace" yaml:"namespace"`
@@ -84,6 +102,7 @@ type workflowStep struct {
 	Phase     string
 	StartedAt string
 	EndedAt   string
+	Message   string
 }
 
 func newRootCommand() *cobra.Command {
@@ -127,6 +146,8 @@ func newRootCommand() *cobra.Command {
 	cmd.AddCommand(newLogsCommand(opts))
 	cmd.AddCommand(newCostCommand(opts))
 	cmd.AddCommand(newApplyCommand(opts))
+	cmd.AddCommand(newReviewCommand(opts, "approve", approveActionsAnnotation))
+	cmd.AddCommand(newReviewCommand(opts, "reject", rejectActionsAnnotation))
 	cmd.AddCommand(newVersionCommand(opts))
 
 	return cmd
@@ -138,6 +159,7 @@ func newGetCommand(opts *cliOptions) *cobra.Command {
 		Short: "List resources",
 	}
 	cmd.AddCommand(newGetWorkloadsCommand(opts))
+	cmd.AddCommand(newGetTenantsCommand(opts))
 	return cmd
 }
 
@@ -205,6 +227,103 @@ func newGetWorkloadsCommand(opts *cliOptions) *cobra.Command {
 	return cmd
 }
 
+func newGetTenantsCommand(opts *cliOptions) *cobra.Command {
+	var allNamespaces bool
+
+	cmd := &cobra.Command{
+		Use:   "tenants",
+		Short: "List Tenant resources",
+		Long:  "List Tenant resources with phase, workloads, monthly tokens, daily cost, and quota state.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns := opts.Namespace
+			if allNamespaces {
+				ns = ""
+			}
+
+			list, err := opts.dynamic.Resource(tenantGVR).Namespace(ns).List(cmd.Context(), metav1.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("list tenants: %w", err)
+			}
+
+			rows := make([]tenantRow, 0, len(list.Items))
+			for i := range list.Items {
+				rows = append(rows, tenantRowFromObject(&list.Items[i]))
+			}
+			sort.Slice(rows, func(i, j int) bool {
+				if rows[i].Namespace == rows[j].Namespace {
+					return rows[i].Name < rows[j].Name
+				}
+				return rows[i].Namespace < rows[j].Namespace
+			})
+
+			switch opts.Output {
+			case "json", "yaml":
+				return printStructured(cmd.OutOrStdout(), rows, opts.Output)
+			default:
+				tbl := tablewriter.NewWriter(cmd.OutOrStdout())
+				tbl.SetHeader([]string{"NAME", "TENANT-NAMESPACE", "PHASE", "WORKLOADS", "TOKENS-MONTH", "COST-TODAY", "QUOTA"})
+				for _, row := range rows {
+					tokens := strconv.FormatInt(row.TokensThisMonth, 10)
+					if row.MaxMonthlyTokens > 0 {
+						tokens += "/" + strconv.FormatInt(row.MaxMonthlyTokens, 10)
+					}
+					tbl.Append([]string{
+						row.Name,
+						safeText(row.TenantNamespace, "-"),
+						safeText(row.Phase, "unknown"),
+						strconv.FormatInt(row.Workloads, 10),
+						tokens,
+						fmt.Sprintf("$%.4f", row.CostToday),
+						row.Quota,
+					})
+				}
+				tbl.Render()
+				return nil
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&allNamespaces, "all-namespaces", false, "List tenants from all namespaces")
+	return cmd
+}
+
+// tenantRowFromObject summarises a Tenant's usage; quota is "Exceeded", "Near" or "OK"
+// following its QuotaExceeded and NearQuota conditions
+func tenantRowFromObject(obj *unstructured.Unstructured) tenantRow {
+	row := tenantRow{
+		Name:            obj.GetName(),
+		Namespace:       obj.GetNamespace(),
+		TenantNamespace: nestedString(obj.Object, "spec", "namespace"),
+		Phase:           nestedString(obj.Object, "status", "phase"),
+		Quota:           "OK",
+	}
+	row.Workloads, _, _ = unstructured.NestedInt64(obj.Object, "status", "workloadCount")
+	row.TokensThisMonth, _, _ = unstructured.NestedInt64(obj.Object, "status", "tokensUsedThisMonth")
+	row.MaxMonthlyTokens, _, _ = unstructured.NestedInt64(obj.Object, "spec", "quotas", "maxMonthlyTokens")
+	if cost, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "status", "costTodayUSD"); found {
+		row.CostToday = float64FromAny(cost)
+	}
+	if phases, found, _ := unstructured.NestedMap(obj.Object, "status", "workloadsByPhase"); found {
+		row.WorkloadsByPhase = map[string]int{}
+		for phase, count := range phases {
+			row.WorkloadsByPhase[phase] = int(int64FromAny(count))
+		}
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, quota := range []struct{ condition, state string }{{"QuotaExceeded", "Exceeded"}, {"NearQuota", "Near"}} {
+		for _, item := range conditions {
+			condition, ok := item.(map[string]interface{})
+			if !ok || condition["type"] != quota.condition || condition["status"] != "True" {
+				continue
+			}
+			row.Quota = quota.state
+			row.QuotaMessage, _ = condition["message"].(string)
+			return row
+		}
+	}
+	return row
+}
+
 func newDescribeCommand(opts *cliOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "describe",
@@ -239,20 +358,41 @@ func newDescribeWorkloadCommand(opts *cliOptions) *cobra.Command {
 			}
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Spec:\n%s\n", string(specBytes))
 
-			steps, err := opts.fetchRecentWorkflowSteps(cmd.Context(), obj.GetName())
+			if argoPhase := nestedString(obj.Object, "status", "argoPhase"); argoPhase != "" {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Workflow: %s (%s steps)\n", argoPhase, safeText(nestedString(obj.Object, "status", "argoProgress"), "?"))
+				if message := nestedString(obj.Object, "status", "argoMessage"); message != "" {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Workflow message: %s\n", message)
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout())
+			}
+
+			// Prefer the per-step status recorded by the operator; fall back to reading the Workflow
+			steps := workflowStepsFromStatus(obj)
+			if len(steps) == 0 {
+				wfNamespace := safeText(nestedString(obj.Object, "status", "argoWorkflow", "namespace"), defaultArgoNamespace)
+				steps, err = opts.fetchRecentWorkflowSteps(cmd.Context(), obj.GetName(), wfNamespace)
+			}
 			if err != nil {
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Recent workflow steps: unavailable (%v)\n\n", err)
 			} else {
 				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Recent workflow steps:")
 				tbl := tablewriter.NewWriter(cmd.OutOrStdout())
-				tbl.SetHeader([]string{"STEP", "PHASE", "STARTED", "ENDED"})
+				tbl.SetHeader([]string{"STEP", "PHASE", "STARTED", "ENDED", "MESSAGE"})
 				for _, step := range steps {
-					tbl.Append([]string{step.Name, step.Phase, step.StartedAt, step.EndedAt})
+					tbl.Append([]string{step.Name, step.Phase, step.StartedAt, step.EndedAt, step.Message})
 				}
 				tbl.Render()
 				_, _ = fmt.Fprintln(cmd.OutOrStdout())
 			}
 
+			if artifacts := workflowArtifactsFromStatus(obj); len(artifacts) > 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Workflow artifacts:")
+				for _, artifact := range artifacts {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s: %s\n", artifact[0], artifact[1])
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout())
+			}
+
 			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "MinIO audit trail (last 20 lines):")
 			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "TODO: MinIO audit log read not implemented yet; placeholder output.")
 			return nil
@@ -415,6 +555,47 @@ func newApplyCommand(opts *cliOptions) *cobra.Command {
 	return cmd
 }
 
+// newReviewCommand builds the approve/reject commands. Both annotate the workload so
+// the operator applies the decision to actions held in PendingApproval; the admission
+// webhook records the caller as the reviewer.
+func newReviewCommand(opts *cliOptions, verb string, annotationKey string) *cobra.Command {
+	var actions []string
+
+	cmd := &cobra.Command{
+		Use:   verb + " <workload>",
+		Short: fmt.Sprintf("%s actions awaiting approval on a workload", strings.ToUpper(verb[:1])+verb[1:]),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			ns := opts.Namespace
+			if len(actions) == 0 {
+				return errors.New("--action is required (use \"*\" for all pending actions)")
+			}
+
+			patch := map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						annotationKey: strings.Join(actions, ","),
+					},
+				},
+			}
+			payload, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("marshal review patch: %w", err)
+			}
+			if _, err := opts.dynamic.Resource(agentWorkloadGVR).Namespace(ns).Patch(cmd.Context(), name, types.MergePatchType, payload, metav1.PatchOptions{}); err != nil {
+				return fmt.Errorf("%s actions on agentworkload %s/%s: %w", verb, ns, name, err)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s requested for %s on AgentWorkload %s/%s\n", verb, strings.Join(actions, ","), ns, name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&actions, "action", nil, "Name of the proposed action (repeatable, \"*\" for all)")
+	return cmd
+}
+
 func newVersionCommand(opts *cliOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "version",
@@ -590,8 +771,8 @@ func printStructured(w io.Writer, data interface{}, output string) error {
 	}
 }
 
-func (o *cliOptions) fetchRecentWorkflowSteps(ctx context.Context, workloadName string) ([]workflowStep, error) {
-	wf, err := o.dynamic.Resource(workflowGVR).Namespace(defaultArgoNamespace).Get(ctx, workloadName, metav1.GetOptions{})
+func (o *cliOptions) fetchRecentWorkflowSteps(ctx context.Context, workloadName string, namespace string) ([]workflowStep, error) {
+	wf, err := o.dynamic.Resource(workflowGVR).Namespace(namespace).Get(ctx, workloadName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -614,6 +795,7 @@ func (o *cliOptions) fetchRecentWorkflowSteps(ctx context.Context, workloadName
 			Phase:     safeText(valueString(node, "phase"), "unknown"),
 			StartedAt: safeText(valueString(node, "startedAt"), "-"),
 			EndedAt:   safeText(valueString(node, "finishedAt"), "-"),
+			Message:   valueString(node, "message"),
 		})
 	}
 	sort.Slice(steps, func(i, j int) bool {
@@ -625,6 +807,45 @@ func (o *cliOptions) fetchRecentWorkflowSteps(ctx context.Context, workloadName
 	return steps, nil
 }
 
+// workflowStepsFromStatus returns the per-step Argo status recorded in status.workflowNodes
+func workflowStepsFromStatus(obj *unstructured.Unstructured) []workflowStep {
+	nodes, found, _ := unstructured.NestedSlice(obj.Object, "status", "workflowNodes")
+	if !found {
+		return nil
+	}
+	steps := make([]workflowStep, 0, len(nodes))
+	for _, raw := range nodes {
+		node, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		steps = append(steps, workflowStep{
+			Name:      valueString(node, "name"),
+			Phase:     safeText(valueString(node, "phase"), "unknown"),
+			StartedAt: safeText(valueString(node, "startedAt"), "-"),
+			EndedAt:   safeText(valueString(node, "finishedAt"), "-"),
+			Message:   valueString(node, "message"),
+		})
+	}
+	return steps
+}
+
+// workflowArtifactsFromStatus returns status.workflowArtifacts as sorted (artifact, location) pairs
+func workflowArtifactsFromStatus(obj *unstructured.Unstructured) [][2]string {
+	artifacts, found, _ := unstructured.NestedStringMap(obj.Object, "status", "workflowArtifacts")
+	if !found {
+		return nil
+	}
+	pairs := make([][2]string, 0, len(artifacts))
+	for name, location := range artifacts {
+		pairs = append(pairs, [2]string{name, location})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i][0] < pairs[j][0]
+	})
+	return pairs
+}
+
 func valueString(m map[string]interface{}, key string) string {
 	if v, ok := m[key].(string); ok {
 		return v
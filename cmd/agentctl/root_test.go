@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestNewRootCommand_ContainsRequiredSubcommands(t *testing.T) {
 	cmd := newRootCommand()
 
-	required := []string{"get", "describe", "logs", "cost", "apply", "version"}
+	required := []string{"get", "describe", "logs", "cost", "apply", "approve", "reject", "version"}
 	for _, name := range required {
 		if child, _, err := cmd.Find([]string{name}); err != nil || child == nil || child.Name() != name {
 			t.Fatalf("expected subcommand %q to exist", name)
@@ -165,3 +167,70 @@ func TestExtractRecords(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowStatusFromWorkload(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"workflowNodes": []interface{}{
+				map[string]interface{}{"name": "scraper", "phase": "Succeeded", "startedAt": "2026-02-24T00:00:00Z"},
+				map[string]interface{}{"name": "analyzer", "phase": "Failed", "message": "Error (exit code 1)"},
+			},
+			"workflowArtifacts": map[string]interface{}{
+				"scraper/raw-html":  "s3://artifacts/job/raw_html.json",
+				"analyzer/main-log": "s3://artifacts/job/main.log",
+			},
+		},
+	}}
+
+	steps := workflowStepsFromStatus(obj)
+	if len(steps) != 2 {
+		t.Fatalf("steps = %d, want 2", len(steps))
+	}
+	if steps[1].Name != "analyzer" || steps[1].Message != "Error (exit code 1)" || steps[1].EndedAt != "-" {
+		t.Fatalf("unexpected analyzer step: %+v", steps[1])
+	}
+
+	artifacts := workflowArtifactsFromStatus(obj)
+	if len(artifacts) != 2 || artifacts[0][0] != "analyzer/main-log" {
+		t.Fatalf("expected artifacts sorted by name, got %v", artifacts)
+	}
+
+	if steps := workflowStepsFromStatus(&unstructured.Unstructured{Object: map[string]interface{}{}}); len(steps) != 0 {
+		t.Fatalf("expected no steps without status, got %d", len(steps))
+	}
+}
+
+func TestTenantRowFromObject(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "acme", "namespace": "agentic-system"},
+		"spec": map[string]interface{}{
+			"namespace": "tenant-acme",
+			"quotas":    map[string]interface{}{"maxMonthlyTokens": int64(1000)},
+		},
+		"status": map[string]interface{}{
+			"phase":               "Active",
+			"workloadCount":       int64(3),
+			"workloadsByPhase":    map[string]interface{}{"Running": int64(2), "Pending": int64(1)},
+			"tokensUsedThisMonth": int64(900),
+			"costTodayUSD":        1.5,
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "QuotaExceeded", "status": "False"},
+				map[string]interface{}{"type": "NearQuota", "status": "True", "message": "monthly tokens 900/1000"},
+			},
+		},
+	}}
+
+	row := tenantRowFromObject(obj)
+	if row.TenantNamespace != "tenant-acme" || row.Phase != "Active" || row.Workloads != 3 {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+	if row.TokensThisMonth != 900 || row.MaxMonthlyTokens != 1000 || row.CostToday != 1.5 {
+		t.Fatalf("unexpected usage: %+v", row)
+	}
+	if row.WorkloadsByPhase["Running"] != 2 {
+		t.Fatalf("unexpected workloads by phase: %v", row.WorkloadsByPhase)
+	}
+	if row.Quota != "Near" || row.QuotaMessage != "monthly tokens 900/1000" {
+		t.Fatalf("expected Near quota, got %q (%q)", row.Quota, row.QuotaMessage)
+	}
+}
@@ -19,18 +19,23 @@ package main
 // OSS-PRIVATE-ALLOW: Mentions of SLA in comments are transitional and non-enforcing in OSS.
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -65,6 +70,9 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var tenantStateConfigMap string
+	var tenantStateInterval time.Duration
+	var quotaTimezone string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -83,6 +91,13 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&tenantStateConfigMap, "tenant-state-configmap", "agentic-tenant-state",
+		"The ConfigMap, in the operator namespace, that checkpoints tenant quota and SLA counters.")
+	flag.DurationVar(&tenantStateInterval, "tenant-state-checkpoint-interval", 30*time.Second,
+		"How often the leader checkpoints tenant quota and SLA counters. 0 keeps them in memory only.")
+	flag.StringVar(&quotaTimezone, "quota-timezone", "UTC",
+		"The IANA time zone whose midnight and first of the month start tenant quota windows, "+
+			"for tenants without quotas.timezone.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -91,6 +106,12 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	quotaLocation, err := time.LoadLocation(quotaTimezone)
+	if err != nil {
+		setupLog.Error(err, "invalid --quota-timezone", "timezone", quotaTimezone)
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -182,11 +203,45 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Phase 7: Initialize multi-tenancy components
-	tenantResolver := multitenancy.NewResolver()
-	tenants := []*multitenancy.TenantContext{} // Will be populated from cluster config
-	quotaMgr := multitenancy.NewQuotaManager(tenants)
-	slaMonitor := multitenancy.NewSLAMonitor(tenants)
+	// Phase 7: Initialize multi-tenancy components, fed from Tenant resources by an informer
+	tenantResolver := multitenancy.NewResolver(multitenancy.WithNamespaceLabels(
+		func(ctx context.Context, namespace string) (map[string]string, error) {
+			ns := &corev1.Namespace{}
+			if err := mgr.GetClient().Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+				return nil, err
+			}
+			return ns.Labels, nil
+		}))
+	quotaMgr := multitenancy.NewQuotaManager(nil, multitenancy.WithQuotaLocation(quotaLocation))
+	slaMonitor := multitenancy.NewSLAMonitor(nil)
+	tenantInformer, err := mgr.GetCache().GetInformer(context.Background(), &agenticv1alpha1.Tenant{})
+	if err != nil {
+		setupLog.Error(err, "Failed to get Tenant informer")
+		os.Exit(1)
+	}
+	if err := multitenancy.NewTenantSync(tenantResolver, quotaMgr, slaMonitor).Register(tenantInformer); err != nil {
+		setupLog.Error(err, "Failed to watch Tenants")
+		os.Exit(1)
+	}
+
+	// Quota and SLA counters survive restarts and leader changes through a checkpoint the
+	// leader writes to a ConfigMap; it is read uncached so a new leader sees the latest one
+	if tenantStateInterval > 0 {
+		stateClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+		if err != nil {
+			setupLog.Error(err, "Failed to create tenant state client")
+			os.Exit(1)
+		}
+		operatorNamespace := os.Getenv("POD_NAMESPACE")
+		if operatorNamespace == "" {
+			operatorNamespace = "agentic-system"
+		}
+		stateStore := multitenancy.NewConfigMapStateStore(stateClient, operatorNamespace, tenantStateConfigMap)
+		if err := mgr.Add(multitenancy.NewCheckpointer(stateStore, quotaMgr, slaMonitor, tenantStateInterval)); err != nil {
+			setupLog.Error(err, "Failed to add tenant state checkpointer")
+			os.Exit(1)
+		}
+	}
 
 	workloadReconciler := controller.NewAgentWorkloadReconciler(mgr.GetClient(), mgr.GetScheme())
 	workloadReconciler.Evaluator = evaluation.NewEvaluator() // Phase 4: Agent Evaluation Pipeline
@@ -207,6 +262,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := (&controller.TenantReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		CostReporter:  workloadReconciler.CostReporter,
+		QuotaLocation: quotaLocation,
+		QuotaMgr:      quotaMgr,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "Tenant")
+		os.Exit(1)
+	}
+
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		if err := (&agenticv1alpha1.AgentWorkload{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "Failed to setup webhook", "webhook", "AgentWorkload")
@@ -0,0 +1,132 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/a2a"
+	"github.com/shreyansh/agentic-operator/pkg/llm"
+)
+
+// Keys read from the Secrets an AgentCard references
+const (
+	caBundleKey   = "ca.crt"
+	clientCertKey = corev1.TLSCertKey
+	clientKeyKey  = corev1.TLSPrivateKeyKey
+)
+
+// agentCredentials hold what is needed to call an agent's endpoints: the TLS settings
+// from endpoint.tls and auth.type "mtls", and the bearer token from auth.tokenSecret
+type agentCredentials struct {
+	tlsConfig *tls.Config
+	token     string
+}
+
+// loadAgentCredentials resolves an AgentCard's TLS and auth settings from the Secrets in
+// its namespace
+func loadAgentCredentials(ctx context.Context, c client.Client, card *agenticv1alpha1.AgentCard) (*agentCredentials, error) {
+	creds := &agentCredentials{}
+
+	if a2a.UsesTLS(card) {
+		creds.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if endpointTLS := card.Spec.Endpoint.TLS; endpointTLS != nil {
+			if endpointTLS.ServerName != nil {
+				creds.tlsConfig.ServerName = *endpointTLS.ServerName
+			}
+			if endpointTLS.CASecret != nil {
+				bundle, err := secretValue(ctx, c, card.Namespace, *endpointTLS.CASecret, caBundleKey)
+				if err != nil {
+					return nil, err
+				}
+				pool := x509.NewCertPool()
+				if !pool.AppendCertsFromPEM(bundle) {
+					return nil, fmt.Errorf("secret %s/%s: %s contains no PEM certificates", card.Namespace, *endpointTLS.CASecret, caBundleKey)
+				}
+				creds.tlsConfig.RootCAs = pool
+			}
+		}
+	}
+
+	auth := card.Spec.Auth
+	if auth == nil || auth.Type == nil {
+		return creds, nil
+	}
+	switch *auth.Type {
+	case "bearer":
+		if auth.TokenSecret == nil {
+			return nil, fmt.Errorf("auth.type bearer requires auth.tokenSecret")
+		}
+		token, err := llm.ResolveAPIKey(ctx, c, card.Namespace, auth.TokenSecret)
+		if err != nil {
+			return nil, err
+		}
+		creds.token = strings.TrimSpace(token)
+	case "mtls":
+		if auth.ClientCertSecret == nil {
+			return nil, fmt.Errorf("auth.type mtls requires auth.clientCertSecret")
+		}
+		certPEM, err := secretValue(ctx, c, card.Namespace, *auth.ClientCertSecret, clientCertKey)
+		if err != nil {
+			return nil, err
+		}
+		keyPEM, err := secretValue(ctx, c, card.Namespace, *auth.ClientCertSecret, clientKeyKey)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("secret %s/%s: invalid client certificate: %w", card.Namespace, *auth.ClientCertSecret, err)
+		}
+		creds.tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return creds, nil
+}
+
+// httpClient returns a client for the agent's endpoints with the given timeout
+func (c *agentCredentials) httpClient(timeout time.Duration) *http.Client {
+	if c.tlsConfig == nil {
+		return &http.Client{Timeout: timeout}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = c.tlsConfig
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// secretValue reads one key of a Secret
+func secretValue(ctx context.Context, c client.Client, namespace, name, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to retrieve secret %s/%s: %w", namespace, name, err)
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in secret %s/%s", key, namespace, name)
+	}
+	return value, nil
+}
@@ -20,8 +20,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,6 +33,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/a2a"
 )
 
 // AgentCardReconciler reconciles an AgentCard object
@@ -42,12 +46,20 @@ type AgentCardReconciler struct {
 // +kubebuilder:rbac:groups=agentic.clawdlinux.org,resources=agentcards,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=agentic.clawdlinux.org,resources=agentcards/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=agentic.clawdlinux.org,resources=agentcards/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Defaults for spec.healthCheck fields left unset
+const (
+	defaultHealthCheckInterval  = 30 * time.Second
+	defaultHealthCheckTimeout   = 5 * time.Second
+	defaultHealthCheckThreshold = 3
+)
 
 // Reconcile reconciles the AgentCard by:
 // 1. Fetching the AgentCard CR
-// 2. Probing the agent health endpoint
-// 3. Updating status (phase, heartbeat, skill availability)
-// 4. Requeuing for periodic health checks
+// 2. Probing the agent health endpoint (HTTPS and bearer/mTLS auth when configured)
+// 3. Updating status (phase, heartbeat, skill availability from the agent's published card)
+// 4. Requeuing for periodic health checks every healthCheck.intervalSeconds
 func (r *AgentCardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
@@ -60,23 +72,20 @@ func (r *AgentCardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	log.Info("Reconciling AgentCard", "name", card.Name, "namespace", card.Namespace)
 
 	// Step 2: Probe agent health endpoint
-	healthy := r.probeHealth(ctx, &card)
+	httpClient, token, probeErr := r.agentClient(ctx, &card)
+	reason := "CredentialsUnavailable"
+	if probeErr == nil {
+		probeErr = r.probeHealth(ctx, &card, httpClient, token)
+		reason = "HealthCheckFailed"
+	}
 
-	// Step 3: Update status based on health probe
+	// Step 3: Update status based on health probe and the agent's published card
 	now := metav1.Now()
-	if healthy {
+	previousSkills := card.Status.Skills
+	if probeErr == nil {
 		card.Status.Phase = "Available"
 		card.Status.LastHeartbeat = &now
-
-		// Mark all skills as available
-		skillStatuses := make([]agenticv1alpha1.SkillStatus, len(card.Spec.Skills))
-		for i, skill := range card.Spec.Skills {
-			skillStatuses[i] = agenticv1alpha1.SkillStatus{
-				Name:      skill.Name,
-				Available: true,
-			}
-		}
-		card.Status.Skills = skillStatuses
+		card.Status.ConsecutiveFailures = 0
 
 		r.setCondition(&card, metav1.Condition{
 			Type:               "Ready",
@@ -85,22 +94,42 @@ func (r *AgentCardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			Message:            "Agent health check passed",
 			LastTransitionTime: now,
 		})
+		r.discoverSkills(ctx, &card, httpClient, token, now)
 	} else {
-		// If previously available, mark as degraded; if never available, mark as unavailable
-		if card.Status.Phase == "Available" {
-			card.Status.Phase = "Degraded"
-		} else if card.Status.Phase == "" {
+		card.Status.ConsecutiveFailures++
+		threshold := healthCheckFailureThreshold(&card)
+		log.V(1).Info("Health check failed", "error", probeErr,
+			"consecutiveFailures", card.Status.ConsecutiveFailures, "failureThreshold", threshold)
+
+		// An Available agent only turns Degraded after failureThreshold consecutive failures,
+		// so a single blip doesn't flap it; an agent never seen healthy is Unavailable
+		if card.Status.ConsecutiveFailures >= threshold {
+			if card.Status.Phase == "Available" {
+				card.Status.Phase = "Degraded"
+			}
+			card.Status.Skills = unavailableSkills(&card, "agent health check failed")
+			r.setCondition(&card, metav1.Condition{
+				Type:               "Ready",
+				Status:             metav1.ConditionFalse,
+				Reason:             reason,
+				Message:            fmt.Sprintf("%d consecutive health checks failed: %v", card.Status.ConsecutiveFailures, probeErr),
+				LastTransitionTime: now,
+			})
+		}
+		if card.Status.Phase == "" || card.Status.Phase == "Pending" {
 			card.Status.Phase = "Unavailable"
+			card.Status.Skills = unavailableSkills(&card, "agent health check failed")
+			r.setCondition(&card, metav1.Condition{
+				Type:               "Ready",
+				Status:             metav1.ConditionFalse,
+				Reason:             reason,
+				Message:            probeErr.Error(),
+				LastTransitionTime: now,
+			})
 		}
-
-		r.setCondition(&card, metav1.Condition{
-			Type:               "Ready",
-			Status:             metav1.ConditionFalse,
-			Reason:             "HealthCheckFailed",
-			Message:            "Agent health check failed or endpoint unreachable",
-			LastTransitionTime: now,
-		})
 	}
+	keepSkillUsage(card.Status.Skills, previousSkills)
+	r.releaseStaleReservations(ctx, &card)
 
 	// Step 4: Persist status update
 	if err := r.Status().Update(ctx, &card); err != nil {
@@ -108,72 +137,162 @@ func (r *AgentCardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	// Requeue for periodic health check (30 seconds)
-	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	// Requeue for periodic health check
+	return ctrl.Result{RequeueAfter: healthCheckInterval(&card)}, nil
 }
 
-// probeHealth checks the agent's health endpoint
-func (r *AgentCardReconciler) probeHealth(ctx context.Context, card *agenticv1alpha1.AgentCard) bool {
-	log := logf.FromContext(ctx)
+// agentClient returns the HTTP client and bearer token used to reach the agent. The
+// client honours healthCheck.timeoutSeconds and endpoint.tls; HTTPClient overrides it.
+func (r *AgentCardReconciler) agentClient(ctx context.Context, card *agenticv1alpha1.AgentCard) (*http.Client, string, error) {
+	creds, err := loadAgentCredentials(ctx, r.Client, card)
+	if err != nil {
+		return nil, "", err
+	}
+	if r.HTTPClient != nil {
+		return r.HTTPClient, creds.token, nil
+	}
+	timeout := defaultHealthCheckTimeout
+	if card.Spec.HealthCheck != nil && card.Spec.HealthCheck.TimeoutSeconds != nil {
+		timeout = time.Duration(*card.Spec.HealthCheck.TimeoutSeconds) * time.Second
+	}
+	return creds.httpClient(timeout), creds.token, nil
+}
 
+// probeHealth checks the agent's health endpoint
+func (r *AgentCardReconciler) probeHealth(ctx context.Context, card *agenticv1alpha1.AgentCard, httpClient *http.Client, token string) error {
 	healthPath := "/healthz"
 	if card.Spec.HealthCheck != nil && card.Spec.HealthCheck.Path != nil {
 		healthPath = *card.Spec.HealthCheck.Path
 	}
+	url := a2a.BaseURL(card) + healthPath
 
-	port := int32(8080)
-	if card.Spec.Endpoint.Port != nil {
-		port = *card.Spec.Endpoint.Port
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create health check request: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
-	basePath := ""
-	if card.Spec.Endpoint.BasePath != nil {
-		basePath = *card.Spec.Endpoint.BasePath
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("health check %s failed: %w", url, err)
 	}
+	defer resp.Body.Close()
 
-	url := fmt.Sprintf("http://%s:%d%s%s", card.Spec.Endpoint.Host, port, basePath, healthPath)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return fmt.Errorf("health check %s returned status %d", url, resp.StatusCode)
+}
 
-	httpClient := r.HTTPClient
-	if httpClient == nil {
-		timeout := 5 * time.Second
-		if card.Spec.HealthCheck != nil && card.Spec.HealthCheck.TimeoutSeconds != nil {
-			timeout = time.Duration(*card.Spec.HealthCheck.TimeoutSeconds) * time.Second
-		}
-		httpClient = &http.Client{Timeout: timeout}
+// healthCheckInterval returns healthCheck.intervalSeconds, defaulting to 30s
+func healthCheckInterval(card *agenticv1alpha1.AgentCard) time.Duration {
+	if card.Spec.HealthCheck != nil && card.Spec.HealthCheck.IntervalSeconds != nil && *card.Spec.HealthCheck.IntervalSeconds > 0 {
+		return time.Duration(*card.Spec.HealthCheck.IntervalSeconds) * time.Second
 	}
+	return defaultHealthCheckInterval
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		log.V(1).Info("Failed to create health check request", "url", url, "error", err)
-		return false
+// healthCheckFailureThreshold returns healthCheck.failureThreshold, defaulting to 3
+func healthCheckFailureThreshold(card *agenticv1alpha1.AgentCard) int32 {
+	if card.Spec.HealthCheck != nil && card.Spec.HealthCheck.FailureThreshold != nil && *card.Spec.HealthCheck.FailureThreshold > 0 {
+		return *card.Spec.HealthCheck.FailureThreshold
 	}
+	return defaultHealthCheckThreshold
+}
 
-	resp, err := httpClient.Do(req)
+// discoverSkills fetches the card the agent publishes about itself and records which
+// declared skills it actually serves. An agent that publishes no card keeps every declared
+// skill available, as its health check passed, with SkillDrift reported as Unknown.
+func (r *AgentCardReconciler) discoverSkills(ctx context.Context, card *agenticv1alpha1.AgentCard, httpClient *http.Client, token string, now metav1.Time) {
+	log := logf.FromContext(ctx)
+
+	published, err := a2a.NewClient(httpClient).GetCard(ctx, a2a.CardURLs(card), token)
 	if err != nil {
-		log.V(1).Info("Health check failed", "url", url, "error", err)
-		return false
+		log.V(1).Info("Agent card not available, skills not verified", "error", err)
+		statuses := make([]agenticv1alpha1.SkillStatus, len(card.Spec.Skills))
+		for i, skill := range card.Spec.Skills {
+			statuses[i] = agenticv1alpha1.SkillStatus{Name: skill.Name, Available: true}
+		}
+		card.Status.Skills = statuses
+		r.setCondition(card, metav1.Condition{
+			Type:               ConditionSkillDrift,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "AgentCardUnavailable",
+			Message:            "Agent does not publish an agent card: " + err.Error(),
+			LastTransitionTime: now,
+		})
+		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		return true
+	statuses, drift := compareSkills(card, published)
+	card.Status.Skills = statuses
+	if len(drift) > 0 {
+		log.Info("Deployed agent disagrees with AgentCard spec", "drift", drift)
+		r.setCondition(card, metav1.Condition{
+			Type:               ConditionSkillDrift,
+			Status:             metav1.ConditionTrue,
+			Reason:             "SkillsDiffer",
+			Message:            strings.Join(drift, "; "),
+			LastTransitionTime: now,
+		})
+		return
 	}
+	r.setCondition(card, metav1.Condition{
+		Type:               ConditionSkillDrift,
+		Status:             metav1.ConditionFalse,
+		Reason:             "SkillsMatch",
+		Message:            fmt.Sprintf("Agent serves all %d declared skills as specified", len(statuses)),
+		LastTransitionTime: now,
+	})
+}
+
+// releaseStaleReservations drops task slots still held by workloads that were deleted or
+// whose sub-task on this agent finished without releasing them
+func (r *AgentCardReconciler) releaseStaleReservations(ctx context.Context, card *agenticv1alpha1.AgentCard) {
+	log := logf.FromContext(ctx)
+	a2a.RemoveReservations(card, func(reservation agenticv1alpha1.TaskReservation) bool {
+		var workload agenticv1alpha1.AgentWorkload
+		err := r.Get(ctx, types.NamespacedName{Name: reservation.Holder, Namespace: card.Namespace}, &workload)
+		if err != nil {
+			return apierrors.IsNotFound(err)
+		}
+		if delegatedTaskFinished(&workload, card.Name) {
+			log.Info("Releasing task slot held by finished sub-task", "workload", workload.Name, "phase", workload.Status.Phase)
+			return true
+		}
+		return false
+	})
+}
 
-	log.V(1).Info("Health check returned non-OK status", "url", url, "status", resp.StatusCode)
-	return false
+// delegatedTaskFinished reports whether the workload's sub-task on the named agent has
+// finished. A slot without a dispatched sub-task is only finished with the workload.
+func delegatedTaskFinished(workload *agenticv1alpha1.AgentWorkload, agentName string) bool {
+	for _, status := range workload.Status.AgentStatuses {
+		if status.Name == agentName && status.TaskID != "" {
+			return status.Phase == "Completed" || status.Phase == "Failed"
+		}
+	}
+	return workload.Status.Phase == "Completed" || workload.Status.Phase == "Failed"
 }
 
-// setCondition updates or appends a condition in the AgentCard status
-func (r *AgentCardReconciler) setCondition(card *agenticv1alpha1.AgentCard, condition metav1.Condition) {
-	for i, existing := range card.Status.Conditions {
-		if existing.Type == condition.Type {
-			if existing.Status != condition.Status {
-				card.Status.Conditions[i] = condition
+// keepSkillUsage carries lastUsed over from the previous skill statuses
+func keepSkillUsage(skills, previous []agenticv1alpha1.SkillStatus) {
+	for i := range skills {
+		for _, old := range previous {
+			if old.Name == skills[i].Name {
+				skills[i].LastUsed = old.LastUsed
+				break
 			}
-			return
 		}
 	}
-	card.Status.Conditions = append(card.Status.Conditions, condition)
+}
+
+// setCondition updates or appends a condition in the AgentCard status. The transition
+// time only changes when the condition status does.
+func (r *AgentCardReconciler) setCondition(card *agenticv1alpha1.AgentCard, condition metav1.Condition) {
+	meta.SetStatusCondition(&card.Status.Conditions, condition)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -0,0 +1,326 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// newAgentServer serves /healthz and, when card is not empty, the agent card
+func newAgentServer(t *testing.T, card string) (string, int32) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/a2a/healthz":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/.well-known/agent.json" && card != "":
+			_, _ = w.Write([]byte(card))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	host, portString, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	port, _ := strconv.Atoi(portString)
+	return host, int32(port)
+}
+
+func newAgentCardTestReconciler(t *testing.T, objs ...client.Object) *AgentCardReconciler {
+	t.Helper()
+	scheme := newControllerTestScheme(t)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agenticv1alpha1.AgentCard{}).
+		WithObjects(objs...).
+		Build()
+	return &AgentCardReconciler{Client: c, Scheme: scheme}
+}
+
+func reconcileCardAndGet(t *testing.T, r *AgentCardReconciler, key types.NamespacedName) (*agenticv1alpha1.AgentCard, ctrl.Result) {
+	t.Helper()
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	updated := &agenticv1alpha1.AgentCard{}
+	if err := r.Get(context.Background(), key, updated); err != nil {
+		t.Fatalf("failed to get AgentCard: %v", err)
+	}
+	return updated, result
+}
+
+func reconcileAgentCard(t *testing.T, card *agenticv1alpha1.AgentCard) *agenticv1alpha1.AgentCard {
+	t.Helper()
+	updated, _ := reconcileCardAndGet(t, newAgentCardTestReconciler(t, card), client.ObjectKeyFromObject(card))
+	return updated
+}
+
+func newDiscoveryAgentCard(host string, port int32) *agenticv1alpha1.AgentCard {
+	basePath := "/a2a"
+	version := "1.0.0"
+	schema := `{"type": "object", "required": ["url"]}`
+	return &agenticv1alpha1.AgentCard{
+		ObjectMeta: metav1.ObjectMeta{Name: "scraper", Namespace: "default"},
+		Spec: agenticv1alpha1.AgentCardSpec{
+			DisplayName: "Scraper",
+			Skills: []agenticv1alpha1.AgentSkill{
+				{Name: "scrape", Version: &version, InputSchema: &schema},
+				{Name: "summarize"},
+			},
+			Endpoint: agenticv1alpha1.AgentEndpoint{Host: host, Port: &port, BasePath: &basePath},
+		},
+	}
+}
+
+func TestAgentCardReconcile_SkillsMatchPublishedCard(t *testing.T) {
+	host, port := newAgentServer(t, `{"name": "scraper", "skills": [
+		{"name": "scrape", "version": "1.0.0", "inputSchema": {"required": ["url"], "type": "object"}},
+		{"name": "summarize"}]}`)
+
+	updated := reconcileAgentCard(t, newDiscoveryAgentCard(host, port))
+	if updated.Status.Phase != "Available" {
+		t.Fatalf("expected Available, got %q", updated.Status.Phase)
+	}
+	if len(updated.Status.Skills) != 2 || !updated.Status.Skills[0].Available || updated.Status.Skills[0].Version != "1.0.0" {
+		t.Fatalf("unexpected skill statuses: %+v", updated.Status.Skills)
+	}
+	if !meta.IsStatusConditionFalse(updated.Status.Conditions, ConditionSkillDrift) {
+		t.Fatalf("expected SkillDrift=False, got %+v", meta.FindStatusCondition(updated.Status.Conditions, ConditionSkillDrift))
+	}
+}
+
+func TestAgentCardReconcile_SkillDrift(t *testing.T) {
+	host, port := newAgentServer(t, `{"name": "scraper", "skills": [
+		{"name": "scrape", "version": "2.0.0", "inputSchema": {"type": "object"}},
+		{"id": "translate"}]}`)
+
+	updated := reconcileAgentCard(t, newDiscoveryAgentCard(host, port))
+	skills := updated.Status.Skills
+	if len(skills) != 2 || !skills[0].Available || skills[1].Available {
+		t.Fatalf("expected scrape available and summarize unavailable, got %+v", skills)
+	}
+	if !strings.Contains(skills[0].Message, "inputSchema differs") || skills[1].Message != "not advertised by the agent" {
+		t.Fatalf("unexpected skill messages: %+v", skills)
+	}
+
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionSkillDrift)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("expected SkillDrift=True, got %+v", cond)
+	}
+	for _, want := range []string{`skill "scrape": version "2.0.0"`, `skill "summarize" is not advertised`, `skill "translate" is advertised by the agent but not declared`} {
+		if !strings.Contains(cond.Message, want) {
+			t.Fatalf("expected drift message to contain %q, got %q", want, cond.Message)
+		}
+	}
+}
+
+func TestAgentCardReconcile_NoPublishedCard(t *testing.T) {
+	host, port := newAgentServer(t, "")
+
+	updated := reconcileAgentCard(t, newDiscoveryAgentCard(host, port))
+	if len(updated.Status.Skills) != 2 || !updated.Status.Skills[1].Available {
+		t.Fatalf("expected declared skills to stay available, got %+v", updated.Status.Skills)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionSkillDrift)
+	if cond == nil || cond.Status != metav1.ConditionUnknown || cond.Reason != "AgentCardUnavailable" {
+		t.Fatalf("expected SkillDrift=Unknown, got %+v", cond)
+	}
+}
+
+func TestAgentCardReconcile_UnhealthyAgentSkillsUnavailable(t *testing.T) {
+	updated := reconcileAgentCard(t, newDiscoveryAgentCard("127.0.0.1", 1))
+	if updated.Status.Phase != "Unavailable" {
+		t.Fatalf("expected Unavailable, got %q", updated.Status.Phase)
+	}
+	for _, skill := range updated.Status.Skills {
+		if skill.Available {
+			t.Fatalf("expected no skill available on an unreachable agent, got %+v", updated.Status.Skills)
+		}
+	}
+}
+
+// newTLSAgentServer serves /a2a/healthz over HTTPS, requiring the bearer token when set
+// and a client certificate when requireClientCert is true
+func newTLSAgentServer(t *testing.T, token string, requireClientCert bool) (*httptest.Server, string, int32) {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/a2a/healthz" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	if requireClientCert {
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	host, portString, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "https://"))
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	port, _ := strconv.Atoi(portString)
+	return server, host, int32(port)
+}
+
+func pemBlock(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func TestAgentCardReconcile_HTTPSWithCABundleAndBearerToken(t *testing.T) {
+	server, host, port := newTLSAgentServer(t, "s3cret", false)
+	card := newDiscoveryAgentCard(host, port)
+	caSecret := "agent-ca"
+	authType := "bearer"
+	card.Spec.Endpoint.TLS = &agenticv1alpha1.AgentTLS{CASecret: &caSecret}
+	card.Spec.Auth = &agenticv1alpha1.AgentAuth{Type: &authType, TokenSecret: &agenticv1alpha1.SecretKeyRef{Name: "agent-token"}}
+
+	r := newAgentCardTestReconciler(t, card,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "agent-ca", Namespace: "default"},
+			Data: map[string][]byte{"ca.crt": pemBlock("CERTIFICATE", server.Certificate().Raw)}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "agent-token", Namespace: "default"},
+			Data: map[string][]byte{"api-key": []byte("s3cret\n")}})
+
+	updated, _ := reconcileCardAndGet(t, r, client.ObjectKeyFromObject(card))
+	if updated.Status.Phase != "Available" {
+		t.Fatalf("expected Available over HTTPS with bearer auth, got %q (%+v)", updated.Status.Phase, updated.Status.Conditions)
+	}
+}
+
+func TestAgentCardReconcile_MutualTLS(t *testing.T) {
+	server, host, port := newTLSAgentServer(t, "", true)
+	card := newDiscoveryAgentCard(host, port)
+	caSecret := "agent-ca"
+	certSecret := "agent-client-cert"
+	authType := "mtls"
+	card.Spec.Endpoint.TLS = &agenticv1alpha1.AgentTLS{CASecret: &caSecret}
+	card.Spec.Auth = &agenticv1alpha1.AgentAuth{Type: &authType, ClientCertSecret: &certSecret}
+
+	// The test server's own key pair doubles as the client certificate
+	serverCert := server.TLS.Certificates[0]
+	keyDER, err := x509.MarshalPKCS8PrivateKey(serverCert.PrivateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	caSecretObj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: caSecret, Namespace: "default"},
+		Data: map[string][]byte{"ca.crt": pemBlock("CERTIFICATE", server.Certificate().Raw)}}
+
+	// Without the client certificate Secret the card cannot be probed
+	r := newAgentCardTestReconciler(t, card, caSecretObj)
+	updated, _ := reconcileCardAndGet(t, r, client.ObjectKeyFromObject(card))
+	if updated.Status.Phase != "Unavailable" {
+		t.Fatalf("expected Unavailable without client certificate, got %q", updated.Status.Phase)
+	}
+	if cond := meta.FindStatusCondition(updated.Status.Conditions, "Ready"); cond == nil || cond.Reason != "CredentialsUnavailable" {
+		t.Fatalf("expected CredentialsUnavailable, got %+v", cond)
+	}
+
+	r = newAgentCardTestReconciler(t, card, caSecretObj, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: certSecret, Namespace: "default"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pemBlock("CERTIFICATE", serverCert.Certificate[0]),
+			corev1.TLSPrivateKeyKey: pemBlock("PRIVATE KEY", keyDER),
+		},
+	})
+	updated, _ = reconcileCardAndGet(t, r, client.ObjectKeyFromObject(card))
+	if updated.Status.Phase != "Available" {
+		t.Fatalf("expected Available with mTLS, got %q (%+v)", updated.Status.Phase, updated.Status.Conditions)
+	}
+}
+
+func TestAgentCardReconcile_FailureThresholdAndInterval(t *testing.T) {
+	host, port := newAgentServer(t, "")
+	card := newDiscoveryAgentCard(host, port)
+	interval := int32(45)
+	threshold := int32(2)
+	card.Spec.HealthCheck = &agenticv1alpha1.AgentHealthCheck{IntervalSeconds: &interval, FailureThreshold: &threshold}
+	r := newAgentCardTestReconciler(t, card)
+	key := client.ObjectKeyFromObject(card)
+
+	updated, result := reconcileCardAndGet(t, r, key)
+	if updated.Status.Phase != "Available" || result.RequeueAfter != 45*time.Second {
+		t.Fatalf("expected Available requeued after 45s, got %q after %s", updated.Status.Phase, result.RequeueAfter)
+	}
+
+	// Point the card at a closed port: one failure is tolerated, the second degrades it
+	closedPort := int32(1)
+	updated.Spec.Endpoint.Host = "127.0.0.1"
+	updated.Spec.Endpoint.Port = &closedPort
+	if err := r.Update(context.Background(), updated); err != nil {
+		t.Fatalf("failed to update card: %v", err)
+	}
+
+	updated, _ = reconcileCardAndGet(t, r, key)
+	if updated.Status.Phase != "Available" || updated.Status.ConsecutiveFailures != 1 {
+		t.Fatalf("expected Available after one failure, got %q with %d failures", updated.Status.Phase, updated.Status.ConsecutiveFailures)
+	}
+	if !updated.Status.Skills[0].Available {
+		t.Fatal("expected skills to stay available below the failure threshold")
+	}
+
+	updated, _ = reconcileCardAndGet(t, r, key)
+	if updated.Status.Phase != "Degraded" || updated.Status.ConsecutiveFailures != 2 {
+		t.Fatalf("expected Degraded after two failures, got %q with %d failures", updated.Status.Phase, updated.Status.ConsecutiveFailures)
+	}
+	if cond := meta.FindStatusCondition(updated.Status.Conditions, "Ready"); cond == nil || cond.Status != metav1.ConditionFalse {
+		t.Fatalf("expected Ready=False, got %+v", cond)
+	}
+}
+
+func TestAgentCardReconcile_ReleasesStaleReservations(t *testing.T) {
+	host, port := newAgentServer(t, "")
+	card := newDiscoveryAgentCard(host, port)
+	card.Status.ActiveTaskCount = 5
+	card.Status.Reservations = []agenticv1alpha1.TaskReservation{
+		{Holder: "running"}, {Holder: "finished"}, {Holder: "deleted"}, {Holder: "failed-lead"}, {Holder: "task-done"},
+	}
+	running := &agenticv1alpha1.AgentWorkload{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default"},
+		Status: agenticv1alpha1.AgentWorkloadStatus{Phase: "Pending"}}
+	finished := &agenticv1alpha1.AgentWorkload{ObjectMeta: metav1.ObjectMeta{Name: "finished", Namespace: "default"},
+		Status: agenticv1alpha1.AgentWorkloadStatus{Phase: "Completed"}}
+	// A failed workload whose sub-task on this agent still runs keeps its slot
+	failedLead := &agenticv1alpha1.AgentWorkload{ObjectMeta: metav1.ObjectMeta{Name: "failed-lead", Namespace: "default"},
+		Status: agenticv1alpha1.AgentWorkloadStatus{Phase: "Failed", AgentStatuses: []agenticv1alpha1.AgentInstanceStatus{
+			{Name: card.Name, TaskID: "task-1", Phase: "Running"},
+		}}}
+	taskDone := &agenticv1alpha1.AgentWorkload{ObjectMeta: metav1.ObjectMeta{Name: "task-done", Namespace: "default"},
+		Status: agenticv1alpha1.AgentWorkloadStatus{Phase: "Pending", AgentStatuses: []agenticv1alpha1.AgentInstanceStatus{
+			{Name: card.Name, TaskID: "task-2", Phase: "Completed"},
+		}}}
+
+	r := newAgentCardTestReconciler(t, card, running, finished, failedLead, taskDone)
+	updated, _ := reconcileCardAndGet(t, r, client.ObjectKeyFromObject(card))
+	holders := []string{}
+	for _, reservation := range updated.Status.Reservations {
+		holders = append(holders, reservation.Holder)
+	}
+	if updated.Status.ActiveTaskCount != 2 || strings.Join(holders, ",") != "running,failed-lead" {
+		t.Fatalf("expected only slots of running sub-tasks kept, got %+v", updated.Status.Reservations)
+	}
+}
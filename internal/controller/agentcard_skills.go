@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/a2a"
+)
+
+// ConditionSkillDrift reports whether the agent's published card disagrees with spec
+const ConditionSkillDrift = "SkillDrift"
+
+// compareSkills derives per-skill availability from the card an agent published and
+// describes every difference between it and the AgentCard spec. A skill is available
+// when the agent advertises it; version and schema mismatches are reported as drift.
+func compareSkills(card *agenticv1alpha1.AgentCard, published *a2a.PublishedCard) ([]agenticv1alpha1.SkillStatus, []string) {
+	advertised := make(map[string]a2a.PublishedSkill, len(published.Skills))
+	for _, skill := range published.Skills {
+		advertised[skill.SkillName()] = skill
+	}
+
+	var drift []string
+	if card.Spec.Version != nil && published.Version != "" && *card.Spec.Version != published.Version {
+		drift = append(drift, fmt.Sprintf("agent version is %s, spec declares %s", published.Version, *card.Spec.Version))
+	}
+
+	statuses := make([]agenticv1alpha1.SkillStatus, 0, len(card.Spec.Skills))
+	declared := make(map[string]bool, len(card.Spec.Skills))
+	for _, skill := range card.Spec.Skills {
+		declared[skill.Name] = true
+		status := agenticv1alpha1.SkillStatus{Name: skill.Name}
+
+		served, ok := advertised[skill.Name]
+		if !ok {
+			status.Message = "not advertised by the agent"
+			drift = append(drift, fmt.Sprintf("skill %q is not advertised by the agent", skill.Name))
+			statuses = append(statuses, status)
+			continue
+		}
+		status.Available = true
+		status.Version = served.Version
+
+		var differences []string
+		if skill.Version != nil && *skill.Version != served.Version {
+			differences = append(differences, fmt.Sprintf("version %q, spec declares %q", served.Version, *skill.Version))
+		}
+		if skill.InputSchema != nil && !sameJSON(*skill.InputSchema, served.InputSchema) {
+			differences = append(differences, "inputSchema differs")
+		}
+		if skill.OutputSchema != nil && !sameJSON(*skill.OutputSchema, served.OutputSchema) {
+			differences = append(differences, "outputSchema differs")
+		}
+		if len(differences) > 0 {
+			status.Message = "agent serves " + strings.Join(differences, ", ")
+			drift = append(drift, fmt.Sprintf("skill %q: %s", skill.Name, strings.Join(differences, ", ")))
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, skill := range published.Skills {
+		if !declared[skill.SkillName()] {
+			drift = append(drift, fmt.Sprintf("skill %q is advertised by the agent but not declared", skill.SkillName()))
+		}
+	}
+	return statuses, drift
+}
+
+// unavailableSkills marks every declared skill unavailable with the given reason
+func unavailableSkills(card *agenticv1alpha1.AgentCard, message string) []agenticv1alpha1.SkillStatus {
+	statuses := make([]agenticv1alpha1.SkillStatus, len(card.Spec.Skills))
+	for i, skill := range card.Spec.Skills {
+		statuses[i] = agenticv1alpha1.SkillStatus{Name: skill.Name, Message: message}
+	}
+	return statuses
+}
+
+// sameJSON compares a JSON Schema from spec with the one an agent published, ignoring
+// formatting and key order
+func sameJSON(declared string, served json.RawMessage) bool {
+	if len(served) == 0 {
+		return false
+	}
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(declared), &want); err != nil {
+		return strings.TrimSpace(declared) == strings.TrimSpace(string(served))
+	}
+	if err := json.Unmarshal(served, &got); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(want, got)
+}
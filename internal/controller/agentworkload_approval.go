@@ -0,0 +1,399 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/argo"
+	"github.com/shreyansh/agentic-operator/pkg/mcp"
+)
+
+// Annotations used by humans (or agentctl) to review actions held in PendingApproval.
+// Values of the approve/reject annotations are comma-separated action names; "*" matches
+// every pending action. The reviewed-by annotation is set by the mutating webhook to the
+// user who wrote the decision and cannot be supplied by the reviewer.
+const (
+	ApproveActionsAnnotation = agenticv1alpha1.ApproveActionsAnnotation
+	RejectActionsAnnotation  = agenticv1alpha1.RejectActionsAnnotation
+	ReviewedByAnnotation     = agenticv1alpha1.ReviewedByAnnotation
+
+	// ConditionAwaitingApproval reports whether proposed actions are waiting for review
+	ConditionAwaitingApproval = "AwaitingApproval"
+)
+
+// approvalDecisions holds the review decisions parsed from workload annotations
+type approvalDecisions struct {
+	approve    map[string]bool
+	reject     map[string]bool
+	reviewedBy string
+}
+
+// parseApprovalDecisions reads the approve/reject annotations from a workload
+func parseApprovalDecisions(workload *agenticv1alpha1.AgentWorkload) approvalDecisions {
+	decisions := approvalDecisions{
+		approve:    parseActionList(workload.Annotations[ApproveActionsAnnotation]),
+		reject:     parseActionList(workload.Annotations[RejectActionsAnnotation]),
+		reviewedBy: strings.TrimSpace(workload.Annotations[ReviewedByAnnotation]),
+	}
+	if decisions.reviewedBy == "" {
+		decisions.reviewedBy = "unknown"
+	}
+	return decisions
+}
+
+func (d approvalDecisions) empty() bool {
+	return len(d.approve) == 0 && len(d.reject) == 0
+}
+
+// decide returns "approve", "reject" or "" for the given action name.
+// Rejection wins when an action is listed in both annotations.
+func (d approvalDecisions) decide(actionName string) string {
+	if d.reject[actionName] || d.reject["*"] {
+		return "reject"
+	}
+	if d.approve[actionName] || d.approve["*"] {
+		return "approve"
+	}
+	return ""
+}
+
+func parseActionList(value string) map[string]bool {
+	names := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// isPendingReview returns true for proposed actions that have not been reviewed yet
+func isPendingReview(action agenticv1alpha1.Action) bool {
+	return action.ReviewedAt == nil && (action.Approved == nil || !*action.Approved)
+}
+
+// hasPendingActions returns true when at least one proposed action awaits review
+func hasPendingActions(workload *agenticv1alpha1.AgentWorkload) bool {
+	for _, action := range workload.Status.ProposedActions {
+		if isPendingReview(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcilePendingApproval applies human review decisions to actions held in PendingApproval.
+//
+// Approved actions are executed through the MCP execute_action tool and moved to
+// ExecutedActions; rejected actions are moved to RejectedActions. Both record who
+// reviewed them and when. An approved action whose execution fails stays pending and
+// the decision annotations are kept, so the execution is retried on the next pass.
+// Without any decision the workload stays in PendingApproval and no new action is
+// proposed, until spec.timeouts.suspendGate expires and the suspend gate policy rejects
+// the actions or escalates.
+func (r *AgentWorkloadReconciler) reconcilePendingApproval(
+	ctx context.Context,
+	workload *agenticv1alpha1.AgentWorkload,
+	mcpClient *mcp.MCPClient,
+) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	execCtx, cancel := executionContext(ctx, workload)
+	defer cancel()
+
+	decisions := parseApprovalDecisions(workload)
+	if decisions.empty() {
+		since := pendingSince(workload)
+		gate := suspendGateTimeout(workload)
+		if gate == 0 || since == nil || time.Since(since.Time) < gate {
+			log.Info("Workload awaiting approval, no review decision yet", "pending", len(workload.Status.ProposedActions))
+			return approvalRequeue(workload, since), nil
+		}
+
+		waited := time.Since(since.Time)
+		if suspendGatePolicy(workload) == SuspendGatePolicyEscalate {
+			log.Info("Suspend gate timeout exceeded, escalating", "waited", waited)
+			if markApprovalEscalated(workload, waited) {
+				if err := r.Status().Update(ctx, workload); err != nil {
+					log.Error(err, "failed to update workload status after escalation")
+					return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+				}
+			}
+			return ctrl.Result{RequeueAfter: 1 * time.Hour}, nil
+		}
+
+		log.Info("Suspend gate timeout exceeded, rejecting pending actions", "waited", waited)
+		decisions = approvalDecisions{reject: map[string]bool{"*": true}, reviewedBy: suspendGateReviewer}
+	}
+
+	now := metav1.Now()
+	executeFailed := false
+	remaining := make([]agenticv1alpha1.Action, 0, len(workload.Status.ProposedActions))
+
+	for _, action := range workload.Status.ProposedActions {
+		if !isPendingReview(action) {
+			remaining = append(remaining, action)
+			continue
+		}
+
+		switch decisions.decide(action.Name) {
+		case "approve":
+			log.Info("Action approved, executing", "action", action.Name, "reviewedBy", decisions.reviewedBy)
+			if err := executeApprovedAction(execCtx, mcpClient, action); err != nil {
+				log.Error(err, "failed to execute approved action", "action", action.Name)
+				executeFailed = true
+				remaining = append(remaining, action)
+				continue
+			}
+			action.Approved = boolPtr(true)
+			action.ReviewedBy = decisions.reviewedBy
+			action.ReviewedAt = &now
+			workload.Status.ExecutedActions = pruneActions(append(workload.Status.ExecutedActions, action), maxActionsInStatus)
+		case "reject":
+			log.Info("Action rejected", "action", action.Name, "reviewedBy", decisions.reviewedBy)
+			action.Approved = boolPtr(false)
+			action.ReviewedBy = decisions.reviewedBy
+			action.ReviewedAt = &now
+			workload.Status.RejectedActions = pruneActions(append(workload.Status.RejectedActions, action), maxActionsInStatus)
+		default:
+			remaining = append(remaining, action)
+		}
+	}
+	workload.Status.ProposedActions = remaining
+
+	if hasPendingActions(workload) {
+		workload.Status.Phase = "PendingApproval"
+	} else {
+		workload.Status.Phase = "Completed"
+	}
+	setAwaitingApprovalCondition(workload)
+	workload.Status.LastReconcileTime = &now
+
+	if err := r.Status().Update(ctx, workload); err != nil {
+		log.Error(err, "failed to update workload status after review")
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	// Keep the decision while an approved action still has to be executed
+	if executeFailed {
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	// Consume the decision annotations so they are not applied to future proposals
+	if err := r.clearApprovalAnnotations(ctx, workload); err != nil {
+		log.Error(err, "failed to clear approval annotations")
+	}
+
+	if workload.Status.Phase == "PendingApproval" {
+		return approvalRequeue(workload, pendingSince(workload)), nil
+	}
+	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+}
+
+// executeApprovedAction replays the stored MCP proposal through execute_action
+func executeApprovedAction(ctx context.Context, mcpClient *mcp.MCPClient, action agenticv1alpha1.Action) error {
+	proposal := map[string]interface{}{}
+	if action.Proposal != "" {
+		if err := json.Unmarshal([]byte(action.Proposal), &proposal); err != nil {
+			return fmt.Errorf("invalid stored proposal for action %s: %w", action.Name, err)
+		}
+	}
+
+	_, err := mcpClient.CallToolContext(ctx, "execute_action", map[string]interface{}{
+		"action":     action.Name,
+		"params":     proposal,
+		"confidence": action.Confidence,
+	})
+	return err
+}
+
+// setAwaitingApprovalCondition keeps the AwaitingApproval condition in sync with ProposedActions
+func setAwaitingApprovalCondition(workload *agenticv1alpha1.AgentWorkload) {
+	condition := metav1.Condition{
+		Type:               ConditionAwaitingApproval,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: workload.Generation,
+		Reason:             "NoPendingActions",
+		Message:            "No proposed actions are awaiting review",
+	}
+	if hasPendingActions(workload) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ActionsPendingReview"
+		condition.Message = fmt.Sprintf(
+			"Approve or reject via the %s / %s annotations", ApproveActionsAnnotation, RejectActionsAnnotation)
+	}
+	meta.SetStatusCondition(&workload.Status.Conditions, condition)
+}
+
+func (r *AgentWorkloadReconciler) clearApprovalAnnotations(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) error {
+	if workload.Annotations == nil {
+		return nil
+	}
+	_, hasApprove := workload.Annotations[ApproveActionsAnnotation]
+	_, hasReject := workload.Annotations[RejectActionsAnnotation]
+	_, hasReviewer := workload.Annotations[ReviewedByAnnotation]
+	if !hasApprove && !hasReject && !hasReviewer {
+		return nil
+	}
+
+	before := workload.DeepCopy()
+	delete(workload.Annotations, ApproveActionsAnnotation)
+	delete(workload.Annotations, RejectActionsAnnotation)
+	delete(workload.Annotations, ReviewedByAnnotation)
+
+	return r.Patch(ctx, workload, client.MergeFrom(before))
+}
+
+// dropPendingActions removes proposed actions that are still awaiting review
+func dropPendingActions(workload *agenticv1alpha1.AgentWorkload) {
+	remaining := make([]agenticv1alpha1.Action, 0, len(workload.Status.ProposedActions))
+	for _, action := range workload.Status.ProposedActions {
+		if !isPendingReview(action) {
+			remaining = append(remaining, action)
+		}
+	}
+	workload.Status.ProposedActions = remaining
+}
+
+// ensureArgoGateAction records the suspended Argo approve-gate as a pending action so it
+// can be approved or rejected by name, and returns that action
+func ensureArgoGateAction(workload *agenticv1alpha1.AgentWorkload, wfStatus *argo.WorkflowStatus) agenticv1alpha1.Action {
+	name := wfStatus.SuspendedNode
+	if name == "" {
+		name = "approval-gate"
+	}
+	for _, action := range workload.Status.ProposedActions {
+		if action.Name == name && isPendingReview(action) {
+			return action
+		}
+	}
+
+	since := wfStatus.SuspendedAt
+	if since == nil {
+		now := metav1.Now()
+		since = &now
+	}
+	action := agenticv1alpha1.Action{
+		Name:        name,
+		Description: fmt.Sprintf("Argo Workflow %s is suspended at %s", workload.Status.ArgoWorkflow.Name, name),
+		Timestamp:   since,
+		Approved:    boolPtr(false),
+	}
+	workload.Status.ProposedActions = pruneActions(append(workload.Status.ProposedActions, action), maxActionsInStatus)
+	return action
+}
+
+// reconcileArgoApproval surfaces a suspended Argo Workflow as PendingApproval and applies
+// review decisions to its approve-gate: approval resumes the suspend node, rejection fails
+// it. Without a decision, spec.timeouts.suspendGate and its policy apply.
+func (r *AgentWorkloadReconciler) reconcileArgoApproval(
+	ctx context.Context,
+	wfManager *argo.WorkflowManager,
+	workload *agenticv1alpha1.AgentWorkload,
+	namespace string,
+	wfStatus *argo.WorkflowStatus,
+) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	workflowName := workload.Status.ArgoWorkflow.Name
+
+	gate := ensureArgoGateAction(workload, wfStatus)
+	workload.Status.Phase = "PendingApproval"
+
+	decisions := parseApprovalDecisions(workload)
+	timedOut := false
+	if decisions.decide(gate.Name) == "" {
+		waited := time.Since(gate.Timestamp.Time)
+		if timeout := suspendGateTimeout(workload); timeout == 0 || waited < timeout {
+			setAwaitingApprovalCondition(workload)
+			if err := r.Status().Update(ctx, workload); err != nil {
+				log.Error(err, "failed to update status")
+			}
+			return r.argoGateRequeue(workload, gate), nil
+		}
+
+		if suspendGatePolicy(workload) == SuspendGatePolicyEscalate {
+			log.Info("Argo Workflow suspended past suspend gate timeout, escalating", "workflowName", workflowName, "waited", waited)
+			markApprovalEscalated(workload, waited)
+			setAwaitingApprovalCondition(workload)
+			if err := r.Status().Update(ctx, workload); err != nil {
+				log.Error(err, "failed to update status")
+			}
+			return r.argoStatusRequeue(), nil
+		}
+
+		log.Info("Argo Workflow suspended past suspend gate timeout, rejecting", "workflowName", workflowName, "waited", waited)
+		decisions = approvalDecisions{reject: map[string]bool{"*": true}, reviewedBy: suspendGateReviewer}
+		timedOut = true
+	}
+
+	now := metav1.Now()
+	gate.ReviewedBy = decisions.reviewedBy
+	gate.ReviewedAt = &now
+
+	if decisions.decide(gate.Name) == "approve" {
+		log.Info("Approval gate approved, resuming workflow", "workflowName", workflowName, "reviewedBy", decisions.reviewedBy)
+		if err := wfManager.ResumeArgoWorkflow(ctx, workflowName, namespace); err != nil {
+			log.Error(err, "failed to resume workflow")
+			return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+		}
+		gate.Approved = boolPtr(true)
+		workload.Status.ExecutedActions = pruneActions(append(workload.Status.ExecutedActions, gate), maxActionsInStatus)
+		workload.Status.Phase = "Running"
+	} else {
+		log.Info("Approval gate rejected, failing suspend node", "workflowName", workflowName, "reviewedBy", decisions.reviewedBy)
+		if err := wfManager.RejectArgoWorkflow(ctx, workflowName, namespace, fmt.Sprintf("Rejected by %s", decisions.reviewedBy)); err != nil {
+			log.Error(err, "failed to reject workflow")
+			return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+		}
+		gate.Approved = boolPtr(false)
+		workload.Status.RejectedActions = pruneActions(append(workload.Status.RejectedActions, gate), maxActionsInStatus)
+		workload.Status.Phase = "Failed"
+		if timedOut {
+			markTimedOut(workload, "SuspendGateTimeoutExceeded",
+				fmt.Sprintf("Workflow %s was not approved within spec.timeouts.suspendGate and was rejected", workflowName))
+		}
+	}
+
+	dropPendingActions(workload)
+	setAwaitingApprovalCondition(workload)
+	workload.Status.LastReconcileTime = &now
+
+	if err := r.Status().Update(ctx, workload); err != nil {
+		log.Error(err, "failed to update status after review")
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
+	if err := r.clearApprovalAnnotations(ctx, workload); err != nil {
+		log.Error(err, "failed to clear approval annotations")
+	}
+
+	if timedOut {
+		return ctrl.Result{}, nil
+	}
+	return r.argoStatusRequeue(), nil
+}
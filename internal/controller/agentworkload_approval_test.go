@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// countingMCPServer proposes a low-confidence action and counts tool invocations
+type countingMCPServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	calls map[string]int
+
+	// executeFailures is the number of execute_action calls to fail before succeeding
+	executeFailures int
+}
+
+func newCountingMCPServer() *countingMCPServer {
+	s := &countingMCPServer{calls: map[string]int{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req mockMCPRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.calls[req.Tool]++
+		failExecute := req.Tool == "execute_action" && s.executeFailures > 0
+		if failExecute {
+			s.executeFailures--
+		}
+		s.mu.Unlock()
+
+		resp := mockMCPResponse{Tool: req.Tool, Success: true}
+		switch req.Tool {
+		case "get_status":
+			resp.Result = map[string]interface{}{"cluster_health": 90.0}
+		case "propose_action":
+			resp.Result = map[string]interface{}{
+				"action":      "optimize",
+				"description": "Tune resource requests based on observed usage",
+				"confidence":  "0.82",
+			}
+		case "execute_action":
+			if failExecute || req.Params["action"] != "optimize" {
+				resp.Success = false
+				resp.Error = "unexpected action"
+			}
+			resp.Result = map[string]interface{}{"executed": true}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	return s
+}
+
+func (s *countingMCPServer) count(tool string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[tool]
+}
+
+func newApprovalTestReconciler(t *testing.T, endpoint string) (*AgentWorkloadReconciler, client.Client, types.NamespacedName) {
+	t.Helper()
+	scheme := newControllerTestScheme(t)
+	objective := "optimize resources for this namespace"
+	workload := &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "approval-workload", Namespace: "default"},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			MCPServerEndpoint: &endpoint,
+			Objective:         &objective,
+		},
+	}
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agenticv1alpha1.AgentWorkload{}).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, workload).
+		Build()
+	return &AgentWorkloadReconciler{Client: k8sClient, Scheme: scheme}, k8sClient,
+		types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+}
+
+func reconcileAndGet(t *testing.T, r *AgentWorkloadReconciler, c client.Client, key types.NamespacedName) *agenticv1alpha1.AgentWorkload {
+	t.Helper()
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile returned error: %v", err)
+	}
+	updated := &agenticv1alpha1.AgentWorkload{}
+	if err := c.Get(context.Background(), key, updated); err != nil {
+		t.Fatalf("failed to fetch workload: %v", err)
+	}
+	return updated
+}
+
+func annotateWorkload(t *testing.T, c client.Client, key types.NamespacedName, annotations map[string]string) {
+	t.Helper()
+	workload := &agenticv1alpha1.AgentWorkload{}
+	if err := c.Get(context.Background(), key, workload); err != nil {
+		t.Fatalf("failed to fetch workload: %v", err)
+	}
+	before := workload.DeepCopy()
+	if workload.Annotations == nil {
+		workload.Annotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		workload.Annotations[k] = v
+	}
+	if err := c.Patch(context.Background(), workload, client.MergeFrom(before)); err != nil {
+		t.Fatalf("failed to annotate workload: %v", err)
+	}
+}
+
+func TestReconcile_PendingApprovalDoesNotReproposeWithoutDecision(t *testing.T) {
+	server := newCountingMCPServer()
+	defer server.Close()
+	r, c, key := newApprovalTestReconciler(t, server.URL)
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "PendingApproval" {
+		t.Fatalf("expected PendingApproval, got %q", updated.Status.Phase)
+	}
+	if updated.Status.ProposedActions[0].Proposal == "" {
+		t.Fatal("expected MCP proposal to be stored on the pending action")
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionAwaitingApproval) {
+		t.Fatal("expected AwaitingApproval condition to be true")
+	}
+
+	updated = reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "PendingApproval" {
+		t.Fatalf("expected workload to stay PendingApproval, got %q", updated.Status.Phase)
+	}
+	if got := server.count("propose_action"); got != 1 {
+		t.Fatalf("expected a single propose_action call while pending, got %d", got)
+	}
+	if len(updated.Status.ProposedActions) != 1 {
+		t.Fatalf("expected 1 pending action, got %d", len(updated.Status.ProposedActions))
+	}
+}
+
+func TestReconcile_ApprovedActionIsExecuted(t *testing.T) {
+	server := newCountingMCPServer()
+	defer server.Close()
+	r, c, key := newApprovalTestReconciler(t, server.URL)
+
+	reconcileAndGet(t, r, c, key)
+	annotateWorkload(t, c, key, map[string]string{
+		ApproveActionsAnnotation: "optimize",
+		ReviewedByAnnotation:     "alice@example.com",
+	})
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "Completed" {
+		t.Fatalf("expected Completed after approval, got %q", updated.Status.Phase)
+	}
+	if got := server.count("execute_action"); got != 1 {
+		t.Fatalf("expected 1 execute_action call, got %d", got)
+	}
+	if len(updated.Status.ProposedActions) != 0 {
+		t.Fatalf("expected no pending actions, got %d", len(updated.Status.ProposedActions))
+	}
+	if len(updated.Status.ExecutedActions) != 1 {
+		t.Fatalf("expected 1 executed action, got %d", len(updated.Status.ExecutedActions))
+	}
+	executed := updated.Status.ExecutedActions[0]
+	if executed.Approved == nil || !*executed.Approved {
+		t.Fatal("expected executed action to be marked approved")
+	}
+	if executed.ReviewedBy != "alice@example.com" || executed.ReviewedAt == nil {
+		t.Fatalf("expected reviewer to be recorded, got %q at %v", executed.ReviewedBy, executed.ReviewedAt)
+	}
+	if _, ok := updated.Annotations[ApproveActionsAnnotation]; ok {
+		t.Fatal("expected approve annotation to be cleared after review")
+	}
+	if meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionAwaitingApproval) {
+		t.Fatal("expected AwaitingApproval condition to be false")
+	}
+}
+
+func TestReconcile_RejectedActionIsNotExecuted(t *testing.T) {
+	server := newCountingMCPServer()
+	defer server.Close()
+	r, c, key := newApprovalTestReconciler(t, server.URL)
+
+	reconcileAndGet(t, r, c, key)
+	annotateWorkload(t, c, key, map[string]string{
+		RejectActionsAnnotation: "*",
+		ReviewedByAnnotation:    "bob",
+	})
+
+	updated := reconcileAndGet(t, r, c, key)
+	if got := server.count("execute_action"); got != 0 {
+		t.Fatalf("expected no execute_action calls, got %d", got)
+	}
+	if len(updated.Status.RejectedActions) != 1 {
+		t.Fatalf("expected 1 rejected action, got %d", len(updated.Status.RejectedActions))
+	}
+	if updated.Status.RejectedActions[0].ReviewedBy != "bob" {
+		t.Fatalf("expected reviewer bob, got %q", updated.Status.RejectedActions[0].ReviewedBy)
+	}
+	if len(updated.Status.ExecutedActions) != 0 {
+		t.Fatalf("expected no executed actions, got %d", len(updated.Status.ExecutedActions))
+	}
+}
+
+func TestReconcile_FailedExecutionIsRetried(t *testing.T) {
+	server := newCountingMCPServer()
+	server.executeFailures = 1
+	defer server.Close()
+	r, c, key := newApprovalTestReconciler(t, server.URL)
+
+	reconcileAndGet(t, r, c, key)
+	annotateWorkload(t, c, key, map[string]string{
+		ApproveActionsAnnotation: "optimize",
+		ReviewedByAnnotation:     "alice@example.com",
+	})
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "PendingApproval" {
+		t.Fatalf("expected PendingApproval after a failed execution, got %q", updated.Status.Phase)
+	}
+	if updated.Annotations[ApproveActionsAnnotation] != "optimize" {
+		t.Fatal("expected the approval to be kept for the retry")
+	}
+
+	updated = reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "Completed" {
+		t.Fatalf("expected Completed after the retried execution, got %q", updated.Status.Phase)
+	}
+	if got := server.count("execute_action"); got != 2 {
+		t.Fatalf("expected 2 execute_action calls, got %d", got)
+	}
+	if got := server.count("propose_action"); got != 1 {
+		t.Fatalf("expected no new proposal while retrying, got %d propose_action calls", got)
+	}
+	if len(updated.Status.ExecutedActions) != 1 || len(updated.Status.ProposedActions) != 0 {
+		t.Fatalf("expected the action to move to ExecutedActions, got %+v", updated.Status)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/argo"
+)
+
+// ConditionWorkflowFailed reports that the Argo Workflow failed, naming the failing step
+const ConditionWorkflowFailed = "WorkflowFailed"
+
+// maxWorkflowNodesInStatus bounds status.workflowNodes; the most recently started steps are kept
+const maxWorkflowNodesInStatus = 50
+
+// applyArgoWorkflowStatus copies Argo Workflow progress into the workload status:
+// per-step node status, S3 output artifacts, and the failure reason when the workflow failed
+func applyArgoWorkflowStatus(workload *agenticv1alpha1.AgentWorkload, wfStatus *argo.WorkflowStatus) {
+	workload.Status.ArgoPhase = wfStatus.Phase
+	workload.Status.ArgoMessage = wfStatus.Message
+
+	completed := 0
+	nodes := make([]agenticv1alpha1.WorkflowNodeStatus, 0, len(wfStatus.Nodes))
+	artifacts := map[string]string{}
+	bucket := argo.ArtifactBucket(workload)
+	for _, node := range wfStatus.Nodes {
+		nodes = append(nodes, agenticv1alpha1.WorkflowNodeStatus{
+			Name:       node.Name,
+			Type:       node.Type,
+			Phase:      node.Phase,
+			StartedAt:  node.StartedAt,
+			FinishedAt: node.FinishedAt,
+			Message:    node.Message,
+		})
+		if node.Phase == "Succeeded" || node.Phase == "Skipped" || node.Phase == "Omitted" {
+			completed++
+		}
+		for _, artifact := range node.Artifacts {
+			artifacts[node.Name+"/"+artifact.Name] = artifact.URI(bucket)
+		}
+	}
+	if len(nodes) > maxWorkflowNodesInStatus {
+		nodes = nodes[len(nodes)-maxWorkflowNodesInStatus:]
+	}
+	workload.Status.WorkflowNodes = nodes
+	if len(artifacts) > 0 {
+		workload.Status.WorkflowArtifacts = artifacts
+	}
+
+	workload.Status.ArgoProgress = wfStatus.Progress
+	if workload.Status.ArgoProgress == "" && len(wfStatus.Nodes) > 0 {
+		workload.Status.ArgoProgress = fmt.Sprintf("%d/%d", completed, len(wfStatus.Nodes))
+	}
+
+	if wfStatus.Phase != "Failed" && wfStatus.Phase != "Error" {
+		meta.RemoveStatusCondition(&workload.Status.Conditions, ConditionWorkflowFailed)
+		return
+	}
+	message := wfStatus.Message
+	if node := failedNode(wfStatus.Nodes); node != nil {
+		message = fmt.Sprintf("step %q %s", node.Name, node.Phase)
+		if node.Message != "" {
+			message += ": " + node.Message
+		}
+	}
+	if message == "" {
+		message = "Argo Workflow " + wfStatus.Phase
+	}
+	meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+		Type:               ConditionWorkflowFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workload.Generation,
+		Reason:             "Workflow" + wfStatus.Phase,
+		Message:            message,
+	})
+}
+
+// failedNode returns the first step (by start time) that failed or errored
+func failedNode(nodes []argo.NodeStatus) *argo.NodeStatus {
+	for i := range nodes {
+		if nodes[i].Phase == "Failed" || nodes[i].Phase == "Error" {
+			return &nodes[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/argo"
+)
+
+func newArgoWorkload(name string, templateRef *agenticv1alpha1.WorkflowTemplateRef) *agenticv1alpha1.AgentWorkload {
+	argoType := "argo"
+	return &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name + "-uid")},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			TargetURLs: []string{"https://acme.example.com"},
+			Orchestration: &agenticv1alpha1.OrchestrationSpec{
+				Type:                &argoType,
+				WorkflowTemplateRef: templateRef,
+			},
+		},
+	}
+}
+
+func newWorkflowTemplate(name, namespace string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": argo.WorkflowGroupVersion,
+		"kind":       argo.WorkflowTemplateKind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+	}}
+}
+
+func newArgoTestReconciler(t *testing.T, objs ...client.Object) (*AgentWorkloadReconciler, client.Client) {
+	t.Helper()
+	scheme := newControllerTestScheme(t)
+	objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agenticv1alpha1.AgentWorkload{}).
+		WithObjects(objs...).
+		Build()
+	return &AgentWorkloadReconciler{Client: c, Scheme: scheme}, c
+}
+
+func TestReconcile_ArgoMissingWorkflowTemplateFails(t *testing.T) {
+	name := "missing-template"
+	workload := newArgoWorkload("argo-missing", &agenticv1alpha1.WorkflowTemplateRef{Name: &name})
+	r, c := newArgoTestReconciler(t, workload)
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "Failed" {
+		t.Fatalf("expected Failed, got %q", updated.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionWorkflowTemplateValid)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "WorkflowTemplateNotFound" {
+		t.Fatalf("expected WorkflowTemplateValid=False, got %+v", cond)
+	}
+	if updated.Status.ArgoWorkflow != nil {
+		t.Fatal("expected no workflow to be submitted")
+	}
+}
+
+func TestReconcile_ArgoUsesReferencedWorkflowTemplate(t *testing.T) {
+	name, namespace := "research-template", "default"
+	workload := newArgoWorkload("argo-ref", &agenticv1alpha1.WorkflowTemplateRef{Name: &name, Namespace: &namespace})
+	r, c := newArgoTestReconciler(t, workload, newWorkflowTemplate(name, namespace))
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "Running" {
+		t.Fatalf("expected Running, got %q", updated.Status.Phase)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionWorkflowTemplateValid) {
+		t.Fatal("expected WorkflowTemplateValid condition to be true")
+	}
+	if updated.Status.ArgoWorkflow == nil || updated.Status.ArgoWorkflow.Namespace != namespace {
+		t.Fatalf("expected workflow in namespace %q, got %+v", namespace, updated.Status.ArgoWorkflow)
+	}
+}
+
+func TestReconcile_ArgoRejectsForeignTemplateNamespace(t *testing.T) {
+	name, namespace := "research-template", "kube-system"
+	workload := newArgoWorkload("argo-foreign", &agenticv1alpha1.WorkflowTemplateRef{Name: &name, Namespace: &namespace})
+	r, c := newArgoTestReconciler(t, workload, newWorkflowTemplate(name, namespace))
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	updated := reconcileAndGet(t, r, c, key)
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionWorkflowTemplateValid)
+	if updated.Status.Phase != "Failed" || cond == nil || cond.Reason != "WorkflowTemplateNamespaceNotAllowed" {
+		t.Fatalf("expected Failed with WorkflowTemplateNamespaceNotAllowed, got %q %+v", updated.Status.Phase, cond)
+	}
+	if _, err := getWorkflow(t, c, workload.Name, namespace); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected no workflow in %s, got %v", namespace, err)
+	}
+}
+
+func getWorkflow(t *testing.T, c client.Client, name, namespace string) (*unstructured.Unstructured, error) {
+	t.Helper()
+	wf := &unstructured.Unstructured{}
+	wf.SetAPIVersion(argo.WorkflowGroupVersion)
+	wf.SetKind(argo.WorkflowKind)
+	err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, wf)
+	return wf, err
+}
+
+// deleteAndReconcile creates the workflow, deletes the workload and runs the finalizer
+func deleteAndReconcile(t *testing.T, retention string) (client.Client, *agenticv1alpha1.AgentWorkload) {
+	t.Helper()
+	workload := newArgoWorkload("argo-cleanup", nil)
+	workload.Spec.Orchestration.WorkflowRetention = &retention
+	r, c := newArgoTestReconciler(t, workload, newWorkflowTemplate(argo.DefaultWorkflowTemplate, argo.DefaultWorkflowNamespace))
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	updated := reconcileAndGet(t, r, c, key)
+	if !controllerutil.ContainsFinalizer(updated, ArgoWorkflowCleanupFinalizer) {
+		t.Fatal("expected cleanup finalizer on Argo-orchestrated workload")
+	}
+	if _, err := getWorkflow(t, c, workload.Name, argo.DefaultWorkflowNamespace); err != nil {
+		t.Fatalf("expected workflow to be created: %v", err)
+	}
+
+	if err := c.Delete(context.Background(), updated); err != nil {
+		t.Fatalf("failed to delete workload: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile returned error: %v", err)
+	}
+
+	remaining := &agenticv1alpha1.AgentWorkload{}
+	if err := c.Get(context.Background(), key, remaining); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected workload to be gone after finalizer, got err=%v finalizers=%v", err, remaining.Finalizers)
+	}
+	return c, workload
+}
+
+func TestReconcile_ArgoFinalizerDeletesWorkflow(t *testing.T) {
+	c, workload := deleteAndReconcile(t, argo.WorkflowRetentionDelete)
+
+	if _, err := getWorkflow(t, c, workload.Name, argo.DefaultWorkflowNamespace); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected workflow to be deleted, got err=%v", err)
+	}
+}
+
+func TestReconcile_ArgoFinalizerRetainsWorkflowHistory(t *testing.T) {
+	c, workload := deleteAndReconcile(t, argo.WorkflowRetentionRetain)
+
+	wf, err := getWorkflow(t, c, workload.Name, argo.DefaultWorkflowNamespace)
+	if err != nil {
+		t.Fatalf("expected workflow to be retained: %v", err)
+	}
+	if wf.GetLabels()[argo.ArchivedLabel] != "true" {
+		t.Fatalf("expected retained workflow to be labelled %s=true", argo.ArchivedLabel)
+	}
+}
+
+// newSuspendedWorkflow returns a Workflow waiting at its approval-gate suspend node
+func newSuspendedWorkflow(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": argo.WorkflowGroupVersion,
+		"kind":       argo.WorkflowKind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": argo.DefaultWorkflowNamespace},
+		"status": map[string]interface{}{
+			"phase": "Running",
+			"nodes": map[string]interface{}{
+				name + "-123": map[string]interface{}{
+					"displayName": "approval-gate",
+					"type":        "Suspend",
+					"phase":       "Running",
+					"startedAt":   "2026-02-24T00:02:00Z",
+				},
+			},
+		},
+	}}
+}
+
+func newSuspendedArgoReconciler(t *testing.T) (*AgentWorkloadReconciler, client.Client, types.NamespacedName) {
+	t.Helper()
+	workload := newArgoWorkload("argo-gate", nil)
+	workload.Status = agenticv1alpha1.AgentWorkloadStatus{
+		Phase:        "Running",
+		ArgoWorkflow: &agenticv1alpha1.ArgoWorkflowRef{Name: workload.Name, Namespace: argo.DefaultWorkflowNamespace},
+	}
+	r, c := newArgoTestReconciler(t, workload, newSuspendedWorkflow(workload.Name))
+	return r, c, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+}
+
+func suspendNodePhase(t *testing.T, c client.Client, name string) string {
+	t.Helper()
+	wf, err := getWorkflow(t, c, name, argo.DefaultWorkflowNamespace)
+	if err != nil {
+		t.Fatalf("failed to fetch workflow: %v", err)
+	}
+	phase, _, _ := unstructured.NestedString(wf.Object, "status", "nodes", name+"-123", "phase")
+	return phase
+}
+
+func TestReconcile_ArgoSuspendedWorkflowIsPendingApproval(t *testing.T) {
+	r, c, key := newSuspendedArgoReconciler(t)
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "PendingApproval" {
+		t.Fatalf("expected PendingApproval, got %q", updated.Status.Phase)
+	}
+	if len(updated.Status.ProposedActions) != 1 || updated.Status.ProposedActions[0].Name != "approval-gate" {
+		t.Fatalf("expected approval-gate pending action, got %+v", updated.Status.ProposedActions)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionAwaitingApproval) {
+		t.Fatal("expected AwaitingApproval condition to be true")
+	}
+
+	// A second pass must not duplicate the gate
+	updated = reconcileAndGet(t, r, c, key)
+	if len(updated.Status.ProposedActions) != 1 {
+		t.Fatalf("expected 1 pending action, got %d", len(updated.Status.ProposedActions))
+	}
+	if phase := suspendNodePhase(t, c, key.Name); phase != "Running" {
+		t.Fatalf("expected suspend node to keep waiting, got %q", phase)
+	}
+}
+
+func TestReconcile_ArgoApprovalResumesWorkflow(t *testing.T) {
+	r, c, key := newSuspendedArgoReconciler(t)
+
+	reconcileAndGet(t, r, c, key)
+	annotateWorkload(t, c, key, map[string]string{
+		ApproveActionsAnnotation: "approval-gate",
+		ReviewedByAnnotation:     "alice",
+	})
+
+	updated := reconcileAndGet(t, r, c, key)
+	if phase := suspendNodePhase(t, c, key.Name); phase != "Succeeded" {
+		t.Fatalf("expected suspend node to be resumed, got %q", phase)
+	}
+	if updated.Status.Phase != "Running" {
+		t.Fatalf("expected Running after approval, got %q", updated.Status.Phase)
+	}
+	if len(updated.Status.ExecutedActions) != 1 || updated.Status.ExecutedActions[0].ReviewedBy != "alice" {
+		t.Fatalf("expected gate approved by alice, got %+v", updated.Status.ExecutedActions)
+	}
+	if len(updated.Status.ProposedActions) != 0 {
+		t.Fatalf("expected no pending actions, got %d", len(updated.Status.ProposedActions))
+	}
+}
+
+func TestReconcile_ArgoRejectionFailsSuspendNode(t *testing.T) {
+	r, c, key := newSuspendedArgoReconciler(t)
+
+	reconcileAndGet(t, r, c, key)
+	annotateWorkload(t, c, key, map[string]string{
+		RejectActionsAnnotation: "*",
+		ReviewedByAnnotation:    "bob",
+	})
+
+	updated := reconcileAndGet(t, r, c, key)
+	if phase := suspendNodePhase(t, c, key.Name); phase != "Failed" {
+		t.Fatalf("expected suspend node to be failed, got %q", phase)
+	}
+	if updated.Status.Phase != "Failed" {
+		t.Fatalf("expected Failed after rejection, got %q", updated.Status.Phase)
+	}
+	if len(updated.Status.RejectedActions) != 1 || updated.Status.RejectedActions[0].ReviewedBy != "bob" {
+		t.Fatalf("expected gate rejected by bob, got %+v", updated.Status.RejectedActions)
+	}
+}
+
+func TestReconcile_ArgoFailedWorkflowReportsFailingStep(t *testing.T) {
+	workload := newArgoWorkload("argo-failed", nil)
+	workload.Status = agenticv1alpha1.AgentWorkloadStatus{
+		Phase:        "Running",
+		ArgoWorkflow: &agenticv1alpha1.ArgoWorkflowRef{Name: workload.Name, Namespace: argo.DefaultWorkflowNamespace},
+	}
+	workflow := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": argo.WorkflowGroupVersion,
+		"kind":       argo.WorkflowKind,
+		"metadata":   map[string]interface{}{"name": workload.Name, "namespace": argo.DefaultWorkflowNamespace},
+		"status": map[string]interface{}{
+			"phase":   "Failed",
+			"message": "child 'argo-failed-2' failed",
+			"nodes": map[string]interface{}{
+				"argo-failed-1": map[string]interface{}{
+					"displayName": "scraper",
+					"type":        "Pod",
+					"phase":       "Succeeded",
+					"startedAt":   "2026-02-24T00:00:00Z",
+					"finishedAt":  "2026-02-24T00:01:00Z",
+					"outputs": map[string]interface{}{
+						"artifacts": []interface{}{
+							map[string]interface{}{"name": "raw-html", "s3": map[string]interface{}{"key": "argo-failed/raw_html.json"}},
+						},
+					},
+				},
+				"argo-failed-2": map[string]interface{}{
+					"displayName": "analyzer",
+					"type":        "Pod",
+					"phase":       "Failed",
+					"message":     "OOMKilled (exit code 137)",
+					"startedAt":   "2026-02-24T00:01:05Z",
+				},
+			},
+		},
+	}}
+	r, c := newArgoTestReconciler(t, workload, workflow)
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "Failed" {
+		t.Fatalf("expected Failed, got %q", updated.Status.Phase)
+	}
+	if updated.Status.ArgoProgress != "1/2" {
+		t.Fatalf("expected progress 1/2, got %q", updated.Status.ArgoProgress)
+	}
+	if len(updated.Status.WorkflowNodes) != 2 || updated.Status.WorkflowNodes[1].Message != "OOMKilled (exit code 137)" {
+		t.Fatalf("expected analyzer failure in workflowNodes, got %+v", updated.Status.WorkflowNodes)
+	}
+	if got := updated.Status.WorkflowArtifacts["scraper/raw-html"]; got != "s3://"+argo.DefaultMinioBucket+"/argo-failed/raw_html.json" {
+		t.Fatalf("unexpected artifact location %q", got)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionWorkflowFailed)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Message != `step "analyzer" Failed: OOMKilled (exit code 137)` {
+		t.Fatalf("expected WorkflowFailed condition naming the analyzer step, got %+v", cond)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/argo"
+)
+
+// argoPollInterval is how often workflow status is polled when Workflows cannot be watched
+const argoPollInterval = 15 * time.Second
+
+// workflowGVK is the Argo Workflow kind watched as an unstructured resource
+var workflowGVK = schema.FromAPIVersionAndKind(argo.WorkflowGroupVersion, argo.WorkflowKind)
+
+func newWorkflowWatchObject() *unstructured.Unstructured {
+	workflow := &unstructured.Unstructured{}
+	workflow.SetGroupVersionKind(workflowGVK)
+	return workflow
+}
+
+// argoWorkflowsInstalled reports whether the cluster serves the Argo Workflow kind
+func argoWorkflowsInstalled(mapper meta.RESTMapper) bool {
+	_, err := mapper.RESTMapping(workflowGVK.GroupKind(), workflowGVK.Version)
+	return err == nil
+}
+
+// argoStatusRequeue returns the requeue used while waiting on an Argo Workflow: none when
+// Workflow events are watched, otherwise a poll every argoPollInterval
+func (r *AgentWorkloadReconciler) argoStatusRequeue() ctrl.Result {
+	if r.watchingWorkflows {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: argoPollInterval}
+}
+
+// argoGateRequeue is argoStatusRequeue, shortened so a pending approve-gate is
+// re-checked when spec.timeouts.suspendGate expires
+func (r *AgentWorkloadReconciler) argoGateRequeue(workload *agenticv1alpha1.AgentWorkload, gate agenticv1alpha1.Action) ctrl.Result {
+	result := r.argoStatusRequeue()
+	timeout := suspendGateTimeout(workload)
+	if timeout == 0 || gate.Timestamp == nil {
+		return result
+	}
+	remaining := timeout - time.Since(gate.Timestamp.Time)
+	if remaining <= 0 {
+		remaining = time.Second
+	}
+	if result.RequeueAfter == 0 || remaining < result.RequeueAfter {
+		result.RequeueAfter = remaining
+	}
+	return result
+}
+
+// workflowToWorkload maps a Workflow event to the AgentWorkload it was created for,
+// using the agentic.io/job-id and agentic.io/workload-namespace labels. Workflows created
+// before the namespace label existed are matched against status.argoWorkflow instead.
+func (r *AgentWorkloadReconciler) workflowToWorkload(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name := labels[argo.JobIDLabel]
+	if name == "" {
+		return nil
+	}
+
+	if namespace := labels[argo.WorkloadNamespaceLabel]; namespace != "" {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}}
+	}
+
+	var workloads agenticv1alpha1.AgentWorkloadList
+	if err := r.List(ctx, &workloads); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list workloads for workflow event", "workflow", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, workload := range workloads.Items {
+		ref := workload.Status.ArgoWorkflow
+		if workload.Name == name && ref != nil && ref.Name == obj.GetName() && ref.Namespace == obj.GetNamespace() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace},
+			})
+		}
+	}
+	return requests
+}
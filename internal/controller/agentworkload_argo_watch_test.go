@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/argo"
+)
+
+func TestArgoWorkflowsInstalled(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{workflowGVK.GroupVersion()})
+	if argoWorkflowsInstalled(mapper) {
+		t.Fatal("expected Workflow kind to be reported missing")
+	}
+
+	mapper.Add(workflowGVK, meta.RESTScopeNamespace)
+	if !argoWorkflowsInstalled(mapper) {
+		t.Fatal("expected Workflow kind to be reported installed")
+	}
+}
+
+func TestWorkflowToWorkload_MapsJobIDLabel(t *testing.T) {
+	r, _ := newArgoTestReconciler(t)
+	wf := newWorkflowWatchObject()
+	wf.SetName("research")
+	wf.SetNamespace(argo.DefaultWorkflowNamespace)
+	wf.SetLabels(map[string]string{argo.JobIDLabel: "research", argo.WorkloadNamespaceLabel: "team-a"})
+
+	requests := r.workflowToWorkload(context.Background(), wf)
+	want := types.NamespacedName{Name: "research", Namespace: "team-a"}
+	if len(requests) != 1 || requests[0].NamespacedName != want {
+		t.Fatalf("expected request for %v, got %+v", want, requests)
+	}
+
+	wf.SetLabels(nil)
+	if requests := r.workflowToWorkload(context.Background(), wf); len(requests) != 0 {
+		t.Fatalf("expected unlabelled workflow to be ignored, got %+v", requests)
+	}
+}
+
+func TestWorkflowToWorkload_FallsBackToStatusReference(t *testing.T) {
+	workload := newArgoWorkload("legacy", nil)
+	workload.Status.ArgoWorkflow = &agenticv1alpha1.ArgoWorkflowRef{Name: "legacy", Namespace: argo.DefaultWorkflowNamespace}
+	r, _ := newArgoTestReconciler(t, workload)
+
+	wf := newWorkflowWatchObject()
+	wf.SetName("legacy")
+	wf.SetNamespace(argo.DefaultWorkflowNamespace)
+	wf.SetLabels(map[string]string{argo.JobIDLabel: "legacy"})
+
+	requests := r.workflowToWorkload(context.Background(), wf)
+	want := types.NamespacedName{Name: "legacy", Namespace: "default"}
+	if len(requests) != 1 || requests[0].NamespacedName != want {
+		t.Fatalf("expected request for %v, got %+v", want, requests)
+	}
+}
+
+func TestReconcile_ArgoWatchSkipsPolling(t *testing.T) {
+	workload := newArgoWorkload("argo-watch", nil)
+	r, _ := newArgoTestReconciler(t, workload, newWorkflowTemplate(argo.DefaultWorkflowTemplate, argo.DefaultWorkflowNamespace))
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("reconcile returned error: %v", err)
+	}
+	if result.RequeueAfter != argoPollInterval {
+		t.Fatalf("expected %v poll without a watch, got %v", argoPollInterval, result.RequeueAfter)
+	}
+
+	r.watchingWorkflows = true
+	result, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("reconcile returned error: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Fatalf("expected no requeue while watching workflows, got %v", result.RequeueAfter)
+	}
+}
+
+func TestArgoGateRequeue_WakesForSuspendGateTimeout(t *testing.T) {
+	r := &AgentWorkloadReconciler{watchingWorkflows: true}
+	workload := &agenticv1alpha1.AgentWorkload{}
+	since := metav1.NewTime(time.Now().Add(-50 * time.Second))
+	gate := agenticv1alpha1.Action{Name: "approval-gate", Timestamp: &since}
+
+	if result := r.argoGateRequeue(workload, gate); result.RequeueAfter != 0 {
+		t.Fatalf("expected no requeue without a suspend gate timeout, got %v", result.RequeueAfter)
+	}
+
+	workload.Spec.Timeouts = &agenticv1alpha1.TimeoutSpec{SuspendGate: int32Ptr(60)}
+	result := r.argoGateRequeue(workload, gate)
+	if result.RequeueAfter <= 0 || result.RequeueAfter > 10*time.Second {
+		t.Fatalf("expected requeue when the gate expires (~10s), got %v", result.RequeueAfter)
+	}
+}
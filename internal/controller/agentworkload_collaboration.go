@@ -0,0 +1,602 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/a2a"
+)
+
+const (
+	// ConditionAgentsAvailable reports whether every AgentCard in spec.agentRefs exists and
+	// is Available; when false, its message names the unavailable agents
+	ConditionAgentsAvailable = "AgentsAvailable"
+
+	// ConditionDelegated reports the progress of the sub-tasks a "delegation" workload's
+	// lead dispatched to its specialists
+	ConditionDelegated = "Delegated"
+
+	// Values for spec.collaborationMode
+	CollaborationModeSolo       = "solo"
+	CollaborationModeTeam       = "team"
+	CollaborationModeDelegation = "delegation"
+
+	// leadRole marks the agentRef that delegates sub-tasks in "delegation" mode
+	leadRole = "lead"
+
+	// delegationPollInterval is how often outstanding A2A sub-tasks are checked
+	delegationPollInterval = 15 * time.Second
+)
+
+// collaborationMode returns spec.collaborationMode, defaulting to "solo"
+func collaborationMode(workload *agenticv1alpha1.AgentWorkload) string {
+	if workload.Spec.CollaborationMode == nil || *workload.Spec.CollaborationMode == "" {
+		return CollaborationModeSolo
+	}
+	return *workload.Spec.CollaborationMode
+}
+
+// collaborationAgent is an agentRef resolved to its AgentCard
+type collaborationAgent struct {
+	ref  agenticv1alpha1.AgentRef
+	card *agenticv1alpha1.AgentCard
+
+	// cardName is the AgentCard the agentRef names or, for an agentRef naming only a skill,
+	// the one its sub-task was scheduled on; it is empty until the sub-task is scheduled
+	cardName string
+
+	// schedulable reports, for an agentRef not scheduled yet, whether an Available agent
+	// serving its skill has spare capacity
+	schedulable bool
+}
+
+func (a collaborationAgent) role() string {
+	if a.ref.Role == nil {
+		return ""
+	}
+	return *a.ref.Role
+}
+
+func (a collaborationAgent) skill() string {
+	if a.ref.Skill == nil {
+		return ""
+	}
+	return *a.ref.Skill
+}
+
+// name identifies the agent in messages: its AgentCard, or its skill until it is scheduled
+func (a collaborationAgent) name() string {
+	if a.cardName != "" {
+		return a.cardName
+	}
+	return "skill " + a.skill()
+}
+
+// reconcileCollaboration resolves spec.agentRefs for "team" and "delegation" workloads and,
+// in "delegation" mode, dispatches the lead's sub-tasks to its specialists over A2A.
+// It returns true when reconciliation must stop with the returned result: the workload
+// does not start until every referenced agent is Available and every sub-task completed.
+func (r *AgentWorkloadReconciler) reconcileCollaboration(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (bool, ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	mode := collaborationMode(workload)
+	if mode != CollaborationModeTeam && mode != CollaborationModeDelegation {
+		return false, ctrl.Result{}, nil
+	}
+
+	agents, unavailable, err := r.resolveAgentRefs(ctx, workload)
+	if err != nil {
+		log.Error(err, "failed to resolve agentRefs")
+		return true, ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	syncAgentStatuses(workload, agents)
+
+	if len(unavailable) > 0 {
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionAgentsAvailable,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workload.Generation,
+			Reason:             "AgentsUnavailable",
+			Message:            "Unavailable agents: " + strings.Join(unavailable, ", "),
+		})
+		// A workload that already started keeps going; only the start is gated
+		if workload.Status.Phase == "" || workload.Status.Phase == "Pending" {
+			log.Info("Waiting for referenced agents to become available", "unavailable", unavailable)
+			workload.Status.Phase = "Pending"
+			if err := r.Status().Update(ctx, workload); err != nil {
+				log.Error(err, "failed to update status")
+			}
+			return true, ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	} else {
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionAgentsAvailable,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: workload.Generation,
+			Reason:             "AgentsAvailable",
+			Message:            fmt.Sprintf("All %d referenced agents are Available", len(agents)),
+		})
+	}
+
+	if mode == CollaborationModeDelegation {
+		return r.reconcileDelegation(ctx, workload, agents)
+	}
+	return false, ctrl.Result{}, nil
+}
+
+// resolveAgentRefs fetches the AgentCard of every agentRef from the workload namespace and
+// describes each agent that is missing or not Available. An agentRef naming only a skill
+// resolves to the AgentCard its sub-task was scheduled on; before that it is available
+// while some Available agent serving the skill has spare capacity.
+func (r *AgentWorkloadReconciler) resolveAgentRefs(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) ([]collaborationAgent, []string, error) {
+	agents := make([]collaborationAgent, 0, len(workload.Spec.AgentRefs))
+	var unavailable []string
+	for i, ref := range workload.Spec.AgentRefs {
+		agent := collaborationAgent{ref: ref, cardName: ref.Name}
+		if agent.cardName == "" {
+			if status := agentStatusFor(workload, i, agent); status != nil {
+				agent.cardName = status.Name
+			}
+		}
+		if agent.cardName == "" {
+			schedulable, err := r.skillSchedulable(ctx, workload, agent.skill())
+			if err != nil {
+				return nil, nil, err
+			}
+			if !schedulable {
+				unavailable = append(unavailable, agent.name()+" (no Available agent with spare capacity)")
+			}
+			agent.schedulable = schedulable
+			agents = append(agents, agent)
+			continue
+		}
+
+		card := &agenticv1alpha1.AgentCard{}
+		err := r.Get(ctx, types.NamespacedName{Name: agent.cardName, Namespace: workload.Namespace}, card)
+		switch {
+		case apierrors.IsNotFound(err):
+			card = nil
+			unavailable = append(unavailable, agent.cardName+" (not found)")
+		case err != nil:
+			return nil, nil, err
+		case card.Status.Phase != "Available":
+			unavailable = append(unavailable, fmt.Sprintf("%s (%s)", agent.cardName, safePhase(card.Status.Phase)))
+		}
+		agent.card = card
+		agents = append(agents, agent)
+	}
+	return agents, unavailable, nil
+}
+
+// skillSchedulable reports whether a sub-task for skill can be scheduled: an Available agent
+// serving it has spare capacity, or already holds a slot for this workload
+func (r *AgentWorkloadReconciler) skillSchedulable(ctx context.Context, workload *agenticv1alpha1.AgentWorkload, skill string) (bool, error) {
+	var cards agenticv1alpha1.AgentCardList
+	if err := r.List(ctx, &cards, client.InNamespace(workload.Namespace)); err != nil {
+		return false, err
+	}
+	if len(a2a.Rank(cards.Items, skill)) > 0 {
+		return true, nil
+	}
+	for i := range cards.Items {
+		if a2a.HoldsReservation(&cards.Items[i], workload.Name, skill) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// agentStatusFor returns the status.agentStatuses entry of the agentRef at index i. Entries
+// are kept in spec order; an agentRef naming only a skill is matched by position and skill
+// because its entry carries no name until the sub-task is scheduled.
+func agentStatusFor(workload *agenticv1alpha1.AgentWorkload, i int, agent collaborationAgent) *agenticv1alpha1.AgentInstanceStatus {
+	if agent.ref.Name == "" {
+		if i < len(workload.Status.AgentStatuses) && workload.Status.AgentStatuses[i].Skill == agent.skill() {
+			return &workload.Status.AgentStatuses[i]
+		}
+		return nil
+	}
+	for j := range workload.Status.AgentStatuses {
+		status := &workload.Status.AgentStatuses[j]
+		if status.Name == agent.ref.Name && status.Skill == agent.skill() {
+			return status
+		}
+	}
+	return nil
+}
+
+func safePhase(phase string) string {
+	if phase == "" {
+		return "Pending"
+	}
+	return phase
+}
+
+// syncAgentStatuses keeps one status.agentStatuses entry per agentRef, in spec order, and
+// derives each agent's phase from its AgentCard and the workload phase
+func syncAgentStatuses(workload *agenticv1alpha1.AgentWorkload, agents []collaborationAgent) {
+	statuses := make([]agenticv1alpha1.AgentInstanceStatus, 0, len(agents))
+	for i, agent := range agents {
+		status := agenticv1alpha1.AgentInstanceStatus{Name: agent.cardName, Phase: "Pending"}
+		if existing := agentStatusFor(workload, i, agent); existing != nil {
+			status = *existing
+		}
+		status.Role = agent.role()
+		status.Skill = agent.skill()
+
+		var message string
+		switch {
+		case agent.cardName == "" && !agent.schedulable:
+			message = "AgentCard not scheduled: no Available agent serving skill " + agent.skill() + " has spare capacity"
+		case agent.cardName == "":
+		case agent.card == nil:
+			message = "AgentCard not found"
+		case agent.card.Status.Phase != "Available":
+			message = "AgentCard is " + safePhase(agent.card.Status.Phase)
+		}
+		if message != "" {
+			status.Message = &message
+		} else if status.Message != nil && strings.HasPrefix(*status.Message, "AgentCard ") {
+			status.Message = nil
+		}
+
+		// Agents without a delegated task follow the workload lifecycle
+		if status.TaskID == "" && status.Phase != "Completed" && status.Phase != "Failed" {
+			switch workload.Status.Phase {
+			case "Completed", "Failed":
+				status.Phase = workload.Status.Phase
+			case "Running", "PendingApproval":
+				status.Phase = "Running"
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	workload.Status.AgentStatuses = statuses
+}
+
+// delegationLead returns the agent with role "lead", or the first agent when none has it
+func delegationLead(agents []collaborationAgent) int {
+	for i, agent := range agents {
+		if agent.role() == leadRole {
+			return i
+		}
+	}
+	return 0
+}
+
+// reconcileDelegation dispatches one A2A sub-task from the lead to each specialist and
+// tracks them until they finish. Each sub-task holds a task slot on its specialist's
+// AgentCard while it runs. The workload starts once every sub-task completed. Once one
+// failed no more are dispatched, and the workload fails when the sub-tasks still running
+// have finished and released their slots.
+func (r *AgentWorkloadReconciler) reconcileDelegation(
+	ctx context.Context,
+	workload *agenticv1alpha1.AgentWorkload,
+	agents []collaborationAgent,
+) (bool, ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	if len(agents) < 2 {
+		return false, ctrl.Result{}, nil
+	}
+	// Sub-tasks are dispatched before the workload runs; once it started they are settled
+	if workload.Status.Phase != "" && workload.Status.Phase != "Pending" && !meta.IsStatusConditionFalse(workload.Status.Conditions, ConditionDelegated) {
+		return false, ctrl.Result{}, nil
+	}
+
+	leadIndex := delegationLead(agents)
+	lead := agents[leadIndex]
+	scheduler := a2a.NewScheduler(r.Client)
+
+	// No more sub-tasks are dispatched once one failed
+	abandoned := false
+	for i := range agents {
+		if i != leadIndex && workload.Status.AgentStatuses[i].Phase == "Failed" {
+			abandoned = true
+		}
+	}
+
+	var outstanding, running, failed []string
+	completed := 0
+	for i, agent := range agents {
+		// Skip the lead and agents whose AgentCard is missing
+		if i == leadIndex || (agent.card == nil && agent.cardName != "") {
+			continue
+		}
+		status := &workload.Status.AgentStatuses[i]
+		skill := delegationSkill(agent)
+
+		if status.TaskID == "" {
+			if abandoned || len(failed) > 0 {
+				continue
+			}
+			card, err := reserveAgent(ctx, scheduler, workload, agent, skill)
+			if err != nil {
+				message := "waiting for capacity: " + err.Error()
+				if !errors.Is(err, a2a.ErrAgentAtCapacity) && !errors.Is(err, a2a.ErrNoAgentAvailable) {
+					log.Error(err, "failed to reserve agent capacity", "agent", agent.name())
+					message = "failed to reserve capacity: " + err.Error()
+				}
+				status.Message = &message
+				outstanding = append(outstanding, agent.name())
+				continue
+			}
+			agent.card, agent.cardName = card, card.Name
+			cardKey := types.NamespacedName{Name: card.Name, Namespace: card.Namespace}
+			a2aClient, token, err := r.agentA2AClient(ctx, card)
+			if err != nil {
+				log.Error(err, "failed to load A2A credentials", "agent", agent.name())
+				if releaseErr := scheduler.Release(ctx, cardKey, skill, workload.Name); releaseErr != nil {
+					log.Error(releaseErr, "failed to release agent capacity", "agent", agent.name())
+				}
+				return true, ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+			task, err := a2aClient.CreateTask(ctx, a2a.BaseURL(card), token, delegationTask(workload, lead, agent))
+			if err != nil {
+				log.Error(err, "failed to delegate sub-task", "agent", agent.name())
+				if releaseErr := scheduler.Release(ctx, cardKey, skill, workload.Name); releaseErr != nil {
+					log.Error(releaseErr, "failed to release agent capacity", "agent", agent.name())
+				}
+				message := err.Error()
+				status.Message = &message
+				outstanding = append(outstanding, agent.name())
+				continue
+			}
+			log.Info("Delegated sub-task", "lead", lead.name(), "agent", agent.name(), "taskId", task.ID, "skill", task.Skill)
+			now := metav1.Now()
+			status.Name = card.Name
+			status.TaskID = task.ID
+			status.Phase = "Running"
+			status.LastActivity = &now
+			status.Message = nil
+			outstanding = append(outstanding, agent.name())
+			running = append(running, agent.name())
+			continue
+		}
+
+		if status.Phase == "Completed" {
+			completed++
+			continue
+		}
+		if status.Phase == "Failed" {
+			failed = append(failed, agent.name())
+			continue
+		}
+
+		cardKey := types.NamespacedName{Name: agent.card.Name, Namespace: agent.card.Namespace}
+		a2aClient, token, err := r.agentA2AClient(ctx, agent.card)
+		if err != nil {
+			log.Error(err, "failed to load A2A credentials", "agent", agent.name())
+			return true, ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+		task, err := a2aClient.GetTask(ctx, a2a.BaseURL(agent.card), token, status.TaskID)
+		if err != nil {
+			log.Error(err, "failed to check delegated sub-task", "agent", agent.name(), "taskId", status.TaskID)
+			outstanding = append(outstanding, agent.name())
+			running = append(running, agent.name())
+			continue
+		}
+		if !task.Done() {
+			outstanding = append(outstanding, agent.name())
+			running = append(running, agent.name())
+			continue
+		}
+		if err := scheduler.Release(ctx, cardKey, skill, workload.Name); err != nil {
+			log.Error(err, "failed to release agent capacity", "agent", agent.name())
+		}
+		now := metav1.Now()
+		status.LastActivity = &now
+		if task.Status == a2a.TaskCompleted {
+			status.Phase = "Completed"
+			status.TasksCompleted++
+			status.Message = nil
+			completed++
+		} else {
+			message := fmt.Sprintf("sub-task %s %s", task.ID, task.Status)
+			if task.Error != "" {
+				message += ": " + task.Error
+			}
+			status.Phase = "Failed"
+			status.Message = &message
+			failed = append(failed, agent.name())
+		}
+	}
+
+	switch {
+	case len(failed) > 0 && len(running) > 0:
+		// Keep polling the remaining sub-tasks so their task slots are released
+		workload.Status.Phase = "Pending"
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionDelegated,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workload.Generation,
+			Reason:             "SubTaskFailed",
+			Message: fmt.Sprintf("Sub-tasks failed on: %s; waiting for running sub-tasks on: %s",
+				strings.Join(failed, ", "), strings.Join(running, ", ")),
+		})
+		if err := r.Status().Update(ctx, workload); err != nil {
+			log.Error(err, "failed to update status")
+		}
+		return true, ctrl.Result{RequeueAfter: delegationPollInterval}, nil
+	case len(failed) > 0:
+		workload.Status.Phase = "Failed"
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionDelegated,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workload.Generation,
+			Reason:             "SubTaskFailed",
+			Message:            "Sub-tasks failed on: " + strings.Join(failed, ", "),
+		})
+		if err := r.Status().Update(ctx, workload); err != nil {
+			log.Error(err, "failed to update status")
+		}
+		return true, ctrl.Result{}, nil
+	case len(outstanding) > 0:
+		workload.Status.Phase = "Pending"
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionDelegated,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workload.Generation,
+			Reason:             "SubTasksInProgress",
+			Message:            fmt.Sprintf("%s is waiting on sub-tasks from: %s", lead.name(), strings.Join(outstanding, ", ")),
+		})
+		if err := r.Status().Update(ctx, workload); err != nil {
+			log.Error(err, "failed to update status")
+		}
+		return true, ctrl.Result{RequeueAfter: delegationPollInterval}, nil
+	}
+
+	meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+		Type:               ConditionDelegated,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workload.Generation,
+		Reason:             "SubTasksCompleted",
+		Message:            fmt.Sprintf("%d sub-tasks delegated by %s completed", completed, lead.name()),
+	})
+	leadStatus := &workload.Status.AgentStatuses[leadIndex]
+	leadStatus.Phase = "Running"
+	return false, ctrl.Result{}, nil
+}
+
+// reserveAgent holds a task slot for skill on the specialist's AgentCard so concurrent
+// workloads can't oversubscribe it. An agentRef naming only a skill is scheduled on the
+// best Available agent serving the skill.
+func reserveAgent(
+	ctx context.Context,
+	scheduler *a2a.Scheduler,
+	workload *agenticv1alpha1.AgentWorkload,
+	agent collaborationAgent,
+	skill string,
+) (*agenticv1alpha1.AgentCard, error) {
+	if agent.cardName == "" {
+		return scheduler.Schedule(ctx, workload.Namespace, skill, workload.Name)
+	}
+	key := types.NamespacedName{Name: agent.card.Name, Namespace: agent.card.Namespace}
+	if err := scheduler.Reserve(ctx, key, skill, workload.Name); err != nil {
+		return nil, err
+	}
+	return agent.card, nil
+}
+
+// delegationSkill is the skill delegated to a specialist: the skill of its agentRef, else
+// its skill named after its role, else its first advertised skill
+func delegationSkill(specialist collaborationAgent) string {
+	if skill := specialist.skill(); skill != "" || specialist.card == nil {
+		return skill
+	}
+	skill := specialist.card.Spec.Skills[0].Name
+	for _, s := range specialist.card.Spec.Skills {
+		if s.Name == specialist.role() {
+			skill = s.Name
+			break
+		}
+	}
+	return skill
+}
+
+// delegationTask builds the sub-task the lead sends to a specialist
+func delegationTask(workload *agenticv1alpha1.AgentWorkload, lead, specialist collaborationAgent) a2a.CreateTaskRequest {
+	input := map[string]interface{}{
+		"workload": workload.Name,
+		"role":     specialist.role(),
+	}
+	if workload.Spec.Objective != nil {
+		input["objective"] = *workload.Spec.Objective
+	}
+	if len(workload.Spec.TargetURLs) > 0 {
+		input["target_urls"] = workload.Spec.TargetURLs
+	}
+
+	timeout := 300
+	if t := executionTimeout(workload); t > 0 {
+		timeout = int(t.Seconds())
+	}
+	return a2a.CreateTaskRequest{
+		Skill:          delegationSkill(specialist),
+		InputData:      input,
+		SenderAgent:    lead.name(),
+		TimeoutSeconds: timeout,
+		Metadata: map[string]interface{}{
+			"workload_namespace": workload.Namespace,
+			"workload_uid":       string(workload.UID),
+		},
+	}
+}
+
+// agentA2AClient returns the A2A client and bearer token for an agent. Agents served over
+// HTTPS get a client with their CA bundle and client certificate.
+func (r *AgentWorkloadReconciler) agentA2AClient(ctx context.Context, card *agenticv1alpha1.AgentCard) (*a2a.Client, string, error) {
+	creds, err := loadAgentCredentials(ctx, r.Client, card)
+	if err != nil {
+		return nil, "", err
+	}
+	if creds.tlsConfig != nil {
+		return a2a.NewClient(creds.httpClient(10 * time.Second)), creds.token, nil
+	}
+	if r.A2AClient != nil {
+		return r.A2AClient, creds.token, nil
+	}
+	return a2a.NewClient(nil), creds.token, nil
+}
+
+// agentCardToWorkloads maps an AgentCard event to the workloads that reference it by name
+// or by one of its skills, so workloads waiting for an agent start as soon as it becomes
+// Available
+func (r *AgentWorkloadReconciler) agentCardToWorkloads(ctx context.Context, obj client.Object) []reconcile.Request {
+	var workloads agenticv1alpha1.AgentWorkloadList
+	if err := r.List(ctx, &workloads, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list workloads for AgentCard event", "agentCard", obj.GetName())
+		return nil
+	}
+	card, _ := obj.(*agenticv1alpha1.AgentCard)
+	var requests []reconcile.Request
+	for _, workload := range workloads.Items {
+		for _, ref := range workload.Spec.AgentRefs {
+			if ref.Name == obj.GetName() || (ref.Name == "" && ref.Skill != nil && card != nil && servesSkill(card, *ref.Skill)) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// servesSkill reports whether an AgentCard declares skill
+func servesSkill(card *agenticv1alpha1.AgentCard, skill string) bool {
+	for _, s := range card.Spec.Skills {
+		if s.Name == skill {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,352 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/a2a"
+)
+
+func newCollaborationWorkload(name, mode string, refs ...agenticv1alpha1.AgentRef) *agenticv1alpha1.AgentWorkload {
+	workload := newJobWorkload(name)
+	workload.Spec.CollaborationMode = &mode
+	workload.Spec.AgentRefs = refs
+	return workload
+}
+
+func agentRef(name, role string) agenticv1alpha1.AgentRef {
+	return agenticv1alpha1.AgentRef{Name: name, Role: &role}
+}
+
+func newAgentCard(name, phase, host string, port int32) *agenticv1alpha1.AgentCard {
+	return &agenticv1alpha1.AgentCard{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: agenticv1alpha1.AgentCardSpec{
+			DisplayName: name,
+			Skills:      []agenticv1alpha1.AgentSkill{{Name: "summarize"}, {Name: "analyst"}},
+			Endpoint:    agenticv1alpha1.AgentEndpoint{Host: host, Port: &port},
+		},
+		Status: agenticv1alpha1.AgentCardStatus{Phase: phase},
+	}
+}
+
+func newCollaborationTestReconciler(t *testing.T, objs ...client.Object) *AgentWorkloadReconciler {
+	t.Helper()
+	scheme := newControllerTestScheme(t)
+	objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agenticv1alpha1.AgentWorkload{}, &agenticv1alpha1.AgentCard{}, &batchv1.Job{}).
+		WithObjects(objs...).
+		Build()
+	return &AgentWorkloadReconciler{Client: c, Scheme: scheme}
+}
+
+// fakeA2AServer serves the A2A task API, completing each task once it was fetched
+type fakeA2AServer struct {
+	mu      sync.Mutex
+	created []a2a.CreateTaskRequest
+	status  string
+
+	// taskStatus overrides status for individual task IDs
+	taskStatus map[string]string
+}
+
+func (s *fakeA2AServer) setTaskStatus(id, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.taskStatus == nil {
+		s.taskStatus = map[string]string{}
+	}
+	s.taskStatus[id] = status
+}
+
+func (s *fakeA2AServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/a2a/tasks":
+		var req a2a.CreateTaskRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		s.created = append(s.created, req)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(a2a.Task{ID: "task-" + strconv.Itoa(len(s.created)), Skill: req.Skill, Status: a2a.TaskQueued})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/a2a/tasks/"):
+		id := strings.TrimPrefix(r.URL.Path, "/a2a/tasks/")
+		status := s.status
+		if override, ok := s.taskStatus[id]; ok {
+			status = override
+		}
+		_ = json.NewEncoder(w).Encode(a2a.Task{ID: id, Status: status, Error: "boom"})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func startFakeA2AServer(t *testing.T, status string) (*fakeA2AServer, string, int32) {
+	t.Helper()
+	fake := &fakeA2AServer{status: status}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	host, portString, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	port, _ := strconv.Atoi(portString)
+	return fake, host, int32(port)
+}
+
+func TestReconcile_TeamWorkloadWaitsForUnavailableAgents(t *testing.T) {
+	workload := newCollaborationWorkload("team", CollaborationModeTeam,
+		agentRef("lead-agent", "lead"), agentRef("analyst-agent", "analyst"), agentRef("ghost", "collector"))
+	r := newCollaborationTestReconciler(t, workload,
+		newAgentCard("lead-agent", "Available", "lead", 8080),
+		newAgentCard("analyst-agent", "Unavailable", "analyst", 8080))
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	updated := reconcileAndGet(t, r, r.Client, key)
+	if updated.Status.Phase != "Pending" {
+		t.Fatalf("expected Pending while agents are unavailable, got %q", updated.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionAgentsAvailable)
+	if cond == nil || cond.Status != metav1.ConditionFalse {
+		t.Fatalf("expected AgentsAvailable=False, got %+v", cond)
+	}
+	if !strings.Contains(cond.Message, "analyst-agent (Unavailable)") || !strings.Contains(cond.Message, "ghost (not found)") ||
+		strings.Contains(cond.Message, "lead-agent") {
+		t.Fatalf("expected condition to name the unavailable agents, got %q", cond.Message)
+	}
+	if len(updated.Status.AgentStatuses) != 3 || updated.Status.AgentStatuses[2].Role != "collector" {
+		t.Fatalf("expected one agent status per agentRef, got %+v", updated.Status.AgentStatuses)
+	}
+	var jobs batchv1.JobList
+	if err := r.List(context.Background(), &jobs); err != nil || len(jobs.Items) != 0 {
+		t.Fatalf("expected no Job before agents are available, got %d (err %v)", len(jobs.Items), err)
+	}
+
+	// Once every card is Available the workload starts and its agents run with it
+	card := &agenticv1alpha1.AgentCard{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "analyst-agent", Namespace: "default"}, card); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	card.Status.Phase = "Available"
+	if err := r.Status().Update(context.Background(), card); err != nil {
+		t.Fatalf("update card: %v", err)
+	}
+	if err := r.Create(context.Background(), newAgentCard("ghost", "Available", "ghost", 8080)); err != nil {
+		t.Fatalf("create card: %v", err)
+	}
+
+	updated = reconcileAndGet(t, r, r.Client, key)
+	if updated.Status.Phase != "Running" {
+		t.Fatalf("expected Running once agents are available, got %q", updated.Status.Phase)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionAgentsAvailable) {
+		t.Fatal("expected AgentsAvailable=True")
+	}
+}
+
+func TestReconcile_DelegationDispatchesSubTasksToSpecialists(t *testing.T) {
+	server, host, port := startFakeA2AServer(t, a2a.TaskCompleted)
+	workload := newCollaborationWorkload("delegation", CollaborationModeDelegation,
+		agentRef("analyst-agent", "analyst"), agentRef("lead-agent", "lead"))
+	r := newCollaborationTestReconciler(t, workload,
+		newAgentCard("lead-agent", "Available", host, port),
+		newAgentCard("analyst-agent", "Available", host, port))
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	updated := reconcileAndGet(t, r, r.Client, key)
+	if len(server.created) != 1 {
+		t.Fatalf("expected one sub-task for the specialist, got %d", len(server.created))
+	}
+	task := server.created[0]
+	if task.Skill != "analyst" || task.SenderAgent != "lead-agent" || task.InputData["role"] != "analyst" {
+		t.Fatalf("unexpected sub-task: %+v", task)
+	}
+	if updated.Status.Phase != "Pending" || !meta.IsStatusConditionFalse(updated.Status.Conditions, ConditionDelegated) {
+		t.Fatalf("expected Pending with Delegated=False while sub-tasks run, got %q", updated.Status.Phase)
+	}
+	if updated.Status.AgentStatuses[0].TaskID != "task-1" {
+		t.Fatalf("expected specialist task id recorded, got %+v", updated.Status.AgentStatuses[0])
+	}
+	specialistCard := &agenticv1alpha1.AgentCard{}
+	cardKey := types.NamespacedName{Name: "analyst-agent", Namespace: "default"}
+	if err := r.Get(context.Background(), cardKey, specialistCard); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if specialistCard.Status.ActiveTaskCount != 1 || specialistCard.Status.Reservations[0].Holder != "delegation" {
+		t.Fatalf("expected the sub-task to hold a slot on the specialist, got %+v", specialistCard.Status)
+	}
+
+	updated = reconcileAndGet(t, r, r.Client, key)
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionDelegated) {
+		t.Fatalf("expected Delegated=True, got %+v", meta.FindStatusCondition(updated.Status.Conditions, ConditionDelegated))
+	}
+	specialist := updated.Status.AgentStatuses[0]
+	if specialist.Phase != "Completed" || specialist.TasksCompleted != 1 {
+		t.Fatalf("expected completed specialist, got %+v", specialist)
+	}
+	if updated.Status.Phase != "Running" {
+		t.Fatalf("expected workload to start after delegation, got %q", updated.Status.Phase)
+	}
+	if len(server.created) != 1 {
+		t.Fatalf("expected no further sub-tasks, got %d", len(server.created))
+	}
+	if err := r.Get(context.Background(), cardKey, specialistCard); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if specialistCard.Status.ActiveTaskCount != 0 {
+		t.Fatalf("expected the slot released once the sub-task completed, got %+v", specialistCard.Status)
+	}
+}
+
+func TestReconcile_DelegationSchedulesSkillOnlySpecialist(t *testing.T) {
+	server, host, port := startFakeA2AServer(t, a2a.TaskCompleted)
+	skill := "summarize"
+	workload := newCollaborationWorkload("delegation-skill", CollaborationModeDelegation,
+		agentRef("lead-agent", "lead"), agenticv1alpha1.AgentRef{Skill: &skill})
+	summarizer := func(name string, maxTasks int32) *agenticv1alpha1.AgentCard {
+		card := newAgentCard(name, "Available", host, port)
+		card.Spec.MaxConcurrentTasks = &maxTasks
+		card.Status.Skills = []agenticv1alpha1.SkillStatus{{Name: skill, Available: true}}
+		return card
+	}
+	r := newCollaborationTestReconciler(t, workload,
+		newAgentCard("lead-agent", "Available", host, port), summarizer("small", 1), summarizer("large", 4))
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	updated := reconcileAndGet(t, r, r.Client, key)
+	if len(server.created) != 1 || server.created[0].Skill != skill {
+		t.Fatalf("expected one %s sub-task, got %+v", skill, server.created)
+	}
+	specialist := updated.Status.AgentStatuses[1]
+	if specialist.Name != "large" || specialist.Skill != skill || specialist.TaskID != "task-1" {
+		t.Fatalf("expected the sub-task scheduled on the agent with most spare capacity, got %+v", specialist)
+	}
+	card := &agenticv1alpha1.AgentCard{}
+	cardKey := types.NamespacedName{Name: "large", Namespace: "default"}
+	if err := r.Get(context.Background(), cardKey, card); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if card.Status.ActiveTaskCount != 1 || card.Status.Reservations[0].Skill != skill {
+		t.Fatalf("expected the sub-task to hold a %s slot, got %+v", skill, card.Status)
+	}
+
+	updated = reconcileAndGet(t, r, r.Client, key)
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionDelegated) || updated.Status.AgentStatuses[1].Name != "large" {
+		t.Fatalf("expected the scheduled sub-task to complete, got %+v", updated.Status.AgentStatuses)
+	}
+	if err := r.Get(context.Background(), cardKey, card); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if card.Status.ActiveTaskCount != 0 {
+		t.Fatalf("expected the slot released once the sub-task completed, got %+v", card.Status)
+	}
+}
+
+func TestReconcile_DelegationWaitsForSpecialistCapacity(t *testing.T) {
+	server, host, port := startFakeA2AServer(t, a2a.TaskCompleted)
+	workload := newCollaborationWorkload("delegation-busy", CollaborationModeDelegation,
+		agentRef("lead-agent", "lead"), agentRef("analyst-agent", "analyst"))
+	busy := newAgentCard("analyst-agent", "Available", host, port)
+	maxTasks := int32(1)
+	busy.Spec.MaxConcurrentTasks = &maxTasks
+	busy.Status.ActiveTaskCount = 1
+	busy.Status.Reservations = []agenticv1alpha1.TaskReservation{{Holder: "other-workload"}}
+	r := newCollaborationTestReconciler(t, workload, newAgentCard("lead-agent", "Available", host, port), busy)
+
+	updated := reconcileAndGet(t, r, r.Client, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace})
+	if len(server.created) != 0 {
+		t.Fatalf("expected no sub-task on an agent at capacity, got %d", len(server.created))
+	}
+	if updated.Status.Phase != "Pending" {
+		t.Fatalf("expected Pending while the specialist is at capacity, got %q", updated.Status.Phase)
+	}
+	if msg := updated.Status.AgentStatuses[1].Message; msg == nil || !strings.Contains(*msg, "waiting for capacity") {
+		t.Fatalf("expected capacity message, got %v", msg)
+	}
+}
+
+func TestReconcile_DelegationFailedSubTaskFailsWorkload(t *testing.T) {
+	_, host, port := startFakeA2AServer(t, a2a.TaskFailed)
+	workload := newCollaborationWorkload("delegation-failed", CollaborationModeDelegation,
+		agentRef("lead-agent", "lead"), agentRef("analyst-agent", "analyst"))
+	r := newCollaborationTestReconciler(t, workload,
+		newAgentCard("lead-agent", "Available", host, port),
+		newAgentCard("analyst-agent", "Available", host, port))
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	reconcileAndGet(t, r, r.Client, key)
+	updated := reconcileAndGet(t, r, r.Client, key)
+	if updated.Status.Phase != "Failed" {
+		t.Fatalf("expected Failed after a sub-task failed, got %q", updated.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionDelegated)
+	if cond == nil || cond.Reason != "SubTaskFailed" || !strings.Contains(cond.Message, "analyst-agent") {
+		t.Fatalf("unexpected Delegated condition: %+v", cond)
+	}
+	if msg := updated.Status.AgentStatuses[1].Message; msg == nil || !strings.Contains(*msg, "boom") {
+		t.Fatalf("expected the sub-task error on the agent status, got %v", msg)
+	}
+}
+
+func TestReconcile_DelegationFailureWaitsForRunningSubTasks(t *testing.T) {
+	server, host, port := startFakeA2AServer(t, a2a.TaskRunning)
+	server.setTaskStatus("task-1", a2a.TaskFailed)
+	workload := newCollaborationWorkload("delegation-partial", CollaborationModeDelegation,
+		agentRef("lead-agent", "lead"), agentRef("analyst-agent", "analyst"), agentRef("writer-agent", "analyst"))
+	r := newCollaborationTestReconciler(t, workload,
+		newAgentCard("lead-agent", "Available", host, port),
+		newAgentCard("analyst-agent", "Available", host, port),
+		newAgentCard("writer-agent", "Available", host, port))
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+	writerKey := types.NamespacedName{Name: "writer-agent", Namespace: "default"}
+
+	reconcileAndGet(t, r, r.Client, key)
+	updated := reconcileAndGet(t, r, r.Client, key)
+	if updated.Status.Phase != "Pending" {
+		t.Fatalf("expected Pending while a sibling sub-task still runs, got %q", updated.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionDelegated)
+	if cond == nil || cond.Reason != "SubTaskFailed" || !strings.Contains(cond.Message, "writer-agent") {
+		t.Fatalf("unexpected Delegated condition: %+v", cond)
+	}
+	writer := &agenticv1alpha1.AgentCard{}
+	if err := r.Get(context.Background(), writerKey, writer); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if writer.Status.ActiveTaskCount != 1 {
+		t.Fatalf("expected the running sub-task to keep its slot, got %+v", writer.Status)
+	}
+
+	server.setTaskStatus("task-2", a2a.TaskCompleted)
+	updated = reconcileAndGet(t, r, r.Client, key)
+	if updated.Status.Phase != "Failed" {
+		t.Fatalf("expected Failed once the running sub-task finished, got %q", updated.Status.Phase)
+	}
+	if err := r.Get(context.Background(), writerKey, writer); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if writer.Status.ActiveTaskCount != 0 {
+		t.Fatalf("expected the slot released once the sub-task finished, got %+v", writer.Status)
+	}
+	if len(server.created) != 2 {
+		t.Fatalf("expected no sub-tasks dispatched after the failure, got %d", len(server.created))
+	}
+}
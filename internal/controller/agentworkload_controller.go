@@ -21,19 +21,25 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/a2a"
 	"github.com/shreyansh/agentic-operator/pkg/argo"
 	"github.com/shreyansh/agentic-operator/pkg/evaluation"
 	"github.com/shreyansh/agentic-operator/pkg/finops"
@@ -49,6 +55,9 @@ import (
 // Maximum number of actions to keep in status to prevent unbounded growth
 const maxActionsInStatus = 100
 
+// ConditionWorkflowTemplateValid reports whether the referenced Argo WorkflowTemplate exists
+const ConditionWorkflowTemplateValid = "WorkflowTemplateValid"
+
 // AgentWorkloadReconciler reconciles a AgentWorkload object
 type AgentWorkloadReconciler struct {
 	client.Client
@@ -60,6 +69,11 @@ type AgentWorkloadReconciler struct {
 	SLAMonitor       *multitenancy.SLAMonitor   // Phase 7: SLA tracking
 	TenantRes        *multitenancy.Resolver     // Phase 7: Tenant isolation
 	Metrics          *metrics.RoutingMetrics    // Singleton metrics recorder (initialized once)
+	A2AClient        *a2a.Client                // A2A task client for delegation (defaults to a2a.NewClient(nil))
+
+	// watchingWorkflows is set by SetupWithManager when Argo Workflow events are watched,
+	// which replaces status polling
+	watchingWorkflows bool
 }
 
 type AgentWorkloadReconcilerOption func(*AgentWorkloadReconciler)
@@ -105,13 +119,16 @@ func (r *AgentWorkloadReconciler) ensureFinopsDefaults() {
 // +kubebuilder:rbac:groups=agentic.clawdlinux.org,resources=agentworkloads/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=agentic.clawdlinux.org,resources=agentworkloads/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;patch;update
+// +kubebuilder:rbac:groups=argoproj.io,resources=workflows,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=argoproj.io,resources=workflowtemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
 // Reconcile reconciles the AgentWorkload by:
 // 1. Fetching the AgentWorkload CR
 // 2. Calling MCP to get status
 // 3. Proposing actions via MCP
 // 4. Evaluating action safety using OPA
-// 5. Executing approved actions or marking for approval
+// 5. Executing approved actions or marking for approval (pending actions are reviewed first)
 // 6. Updating status
 // 7. Requeue after 30 seconds
 func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -121,17 +138,51 @@ func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Step 1: Fetch the AgentWorkload
 	var workload agenticv1alpha1.AgentWorkload
 	if err := r.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}, &workload); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.releaseDeletedWorkloadQuota(ctx, req)
+			return ctrl.Result{}, nil
+		}
 		log.Error(err, "unable to fetch AgentWorkload")
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		return ctrl.Result{}, err
 	}
 
 	log.Info("Reconciling AgentWorkload", "name", workload.Name)
 
+	// Finished and deleted workloads settle their tenant quota reservation
+	r.settleTenantQuota(ctx, &workload)
+
+	// Workloads being deleted only need their external resources cleaned up
+	if !workload.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &workload)
+	}
+
 	if err := r.reconcilePersonaNamespaceLabels(ctx, &workload); err != nil {
 		log.Error(err, "failed to reconcile persona labels on namespace")
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	// ========== TENANT CONCURRENCY ==========
+	// Workloads wait in Pending while their tenant runs quotas.maxConcurrent workloads;
+	// waiting for a slot does not count against spec.timeouts.execution
+	if stop, result, err := r.reconcileTenantConcurrency(ctx, &workload); stop {
+		return result, err
+	}
+
+	// ========== EXECUTION TIMEOUT ==========
+	// Fail workloads that ran past spec.timeouts.execution; later calls share its deadline
+	if stop, err := r.enforceExecutionTimeout(ctx, &workload); stop {
+		return ctrl.Result{}, err
+	}
+	execCtx, cancel := executionContext(ctx, &workload)
+	defer cancel()
+
+	// ========== AGENT COLLABORATION ==========
+	// Team and delegation workloads start only once every referenced AgentCard is Available
+	// and, in delegation mode, every sub-task the lead dispatched has completed
+	if stop, result, err := r.reconcileCollaboration(execCtx, &workload); stop {
+		return result, err
+	}
+
 	// ========== LICENSE ENFORCEMENT ==========
 	// Check license validity BEFORE creating any workload.
 	currentCount := 0
@@ -160,36 +211,26 @@ func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	// ========== QUOTA ENFORCEMENT (Phase 7) ==========
-	// Check per-tenant quotas BEFORE processing the workload
-	if r.QuotaMgr != nil && r.TenantRes != nil {
-		// Extract tenant from namespace
-		tenant, err := r.TenantRes.ExtractFromNamespace(ctx, workload.Namespace)
-		if err == nil && tenant != nil {
-			// Check quota (assume $10 cost per workload for estimation)
-			estCost := 10.0
-			if err := r.QuotaMgr.CheckAndConsume(tenant.Name, estCost); err != nil {
-				log.Error(err, "quota check failed",
-					"tenant", tenant.Name,
-					"error", err.Error(),
-				)
-				workload.Status.Phase = "Failed"
-				if err := r.Status().Update(ctx, &workload); err != nil {
-					log.Error(err, "failed to update workload status")
-				}
-				return ctrl.Result{RequeueAfter: 1 * time.Hour}, nil // Requeue later when quota resets
-			}
-		}
+	// Reserve the tenant's daily workload quota and cost budget once per workload, BEFORE
+	// processing it; the reservation is settled when the workload finishes
+	if stop, result, err := r.reserveTenantQuota(ctx, &workload); stop {
+		return result, err
 	}
 
 	// ========== MODEL ROUTING (Phase 3) with Retry (Phase 5) ==========
 	// Handle cost-aware model routing if enabled
 	if workload.Spec.ModelStrategy != nil && *workload.Spec.ModelStrategy == "cost-aware" {
+		// The model call completed the workload. Its own status update triggers another
+		// reconcile, which must not call the model and charge the tenant again.
+		if workload.Status.Phase == "Completed" {
+			return ctrl.Result{}, nil
+		}
 		type routeResult struct {
 			response    *llm.ModelResponse
 			routingInfo *llm.RoutingInfo
 		}
 		retryCfg := resilience.DefaultRetryConfig()
-		result, retryInfo := resilience.WithRetry(ctx, retryCfg, "model-routing", func(retryCtx context.Context) (routeResult, error) {
+		result, retryInfo := resilience.WithRetry(execCtx, retryCfg, "model-routing", func(retryCtx context.Context) (routeResult, error) {
 			resp, ri, err := r.routeAndCallModel(retryCtx, &workload)
 			return routeResult{response: resp, routingInfo: ri}, err
 		})
@@ -197,6 +238,17 @@ func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		routingInfo := result.routingInfo
 		err := retryInfo.LastErr
 
+		// A call refused for the tenant's token budget is not a routing failure
+		if errors.Is(err, multitenancy.ErrTokenBudgetExceeded) {
+			log.Info("model call refused by the tenant token budget", "reason", err.Error())
+			setTokenBudgetCondition(&workload, err)
+			workload.Status.Phase = "Failed"
+			if err := r.Status().Update(ctx, &workload); err != nil {
+				log.Error(err, "failed to update workload status")
+			}
+			return ctrl.Result{RequeueAfter: tokenBudgetRetryInterval}, nil
+		}
+
 		if err != nil {
 			log.Error(err, "model routing failed after retries",
 				"attempts", retryInfo.Attempts,
@@ -263,7 +315,10 @@ func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				workload.Status.Conditions = append(workload.Status.Conditions, condition)
 			}
 
+			setTokenBudgetCondition(&workload, nil)
 			workload.Status.Phase = "Completed"
+			workload.Status.TokensUsed = addTokenUsage(workload.Status.TokensUsed, routingInfo, time.Now(), r.tokenUsageLocation(ctx, &workload))
+			r.recordTenantTokens(ctx, &workload, routingInfo)
 
 			// Phase 7: Track SLA success
 			if r.SLAMonitor != nil && r.TenantRes != nil {
@@ -282,9 +337,9 @@ func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
-	// Check if this is an Argo-orchestrated workload
-	if workload.Spec.Orchestration != nil && workload.Spec.Orchestration.Type != nil && *workload.Spec.Orchestration.Type == "argo" {
-		return r.reconcileArgoWorkflow(ctx, &workload)
+	// Workloads with spec.orchestration.type run on their orchestration engine
+	if engine := r.orchestrationEngineFor(&workload); engine != nil {
+		return engine.reconcile(ctx, &workload)
 	}
 
 	// Step 2: Connect to MCP server and fetch status
@@ -294,7 +349,12 @@ func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 	mcpClient := mcp.NewMCPClient(mcpEndpoint)
 
-	status, err := mcpClient.CallTool("get_status", map[string]interface{}{})
+	// Actions awaiting human review are resolved before anything new is proposed
+	if workload.Status.Phase == "PendingApproval" && hasPendingActions(&workload) {
+		return r.reconcilePendingApproval(ctx, &workload, mcpClient)
+	}
+
+	status, err := mcpClient.CallToolContext(execCtx, "get_status", map[string]interface{}{})
 	if err != nil {
 		log.Error(err, "failed to get status from MCP server")
 		workload.Status.Phase = "Failed"
@@ -330,7 +390,7 @@ func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		"status":    status,
 	}
 
-	proposal, err := mcpClient.CallTool("propose_action", proposalParams)
+	proposal, err := mcpClient.CallToolContext(execCtx, "propose_action", proposalParams)
 	if err != nil {
 		log.Error(err, "failed to propose action from MCP server")
 		workload.Status.Phase = "Failed"
@@ -443,7 +503,7 @@ func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			"confidence": confidenceStr,
 		}
 
-		execution, err := mcpClient.CallTool("execute_action", executeParams)
+		execution, err := mcpClient.CallToolContext(execCtx, "execute_action", executeParams)
 		if err != nil {
 			log.Error(err, "failed to execute action", "action", action.Name)
 			workload.Status.Phase = "Failed"
@@ -463,10 +523,14 @@ func (r *AgentWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		// Step 5b: Mark for human approval
 		log.Info("OPA denied action, requiring human approval", "action", action.Name, "reasons", opaResult.Reasons)
 		action.Approved = boolPtr(false)
+		if proposalJSON, err := json.Marshal(proposal); err == nil {
+			action.Proposal = string(proposalJSON)
+		}
 		workload.Status.ProposedActions = append(workload.Status.ProposedActions, action)
 		prunedProposed := pruneActions(workload.Status.ProposedActions, maxActionsInStatus)
 		workload.Status.ProposedActions = prunedProposed
 		workload.Status.Phase = "PendingApproval"
+		setAwaitingApprovalCondition(&workload)
 	}
 
 	// Step 6: Update status
@@ -560,6 +624,12 @@ func (r *AgentWorkloadReconciler) reconcileArgoWorkflow(ctx context.Context, wor
 	log := logf.FromContext(ctx)
 	log.Info("Reconciling Argo-orchestrated workload", "name", workload.Name)
 
+	// Workflows outlive cross-namespace ownerReferences, so deletion goes through a finalizer
+	if err := r.ensureArgoCleanupFinalizer(ctx, workload); err != nil {
+		log.Error(err, "failed to add workflow cleanup finalizer")
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
+
 	// Initialize Argo workflow manager
 	wfManager := argo.NewWorkflowManager(r.Client, r.Scheme)
 
@@ -567,8 +637,13 @@ func (r *AgentWorkloadReconciler) reconcileArgoWorkflow(ctx context.Context, wor
 	if workload.Status.ArgoWorkflow != nil && workload.Status.ArgoWorkflow.Name != "" {
 		log.Info("Workflow already exists", "workflowName", workload.Status.ArgoWorkflow.Name)
 
+		workflowNamespace := workload.Status.ArgoWorkflow.Namespace
+		if workflowNamespace == "" {
+			workflowNamespace = argo.DefaultWorkflowNamespace
+		}
+
 		// Get workflow status
-		wfStatus, err := wfManager.GetArgoWorkflowStatus(ctx, workload.Status.ArgoWorkflow.Name, "argo-workflows")
+		wfStatus, err := wfManager.GetArgoWorkflowStatus(ctx, workload.Status.ArgoWorkflow.Name, workflowNamespace)
 		if err != nil {
 			log.Error(err, "failed to get workflow status")
 			workload.Status.Phase = "Failed"
@@ -579,26 +654,63 @@ func (r *AgentWorkloadReconciler) reconcileArgoWorkflow(ctx context.Context, wor
 			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 		}
 
-		// Update phase based on workflow status
-		workload.Status.ArgoPhase = wfStatus.Phase
+		// Reflect workflow phase, per-step progress, artifacts and failure reason
+		applyArgoWorkflowStatus(workload, wfStatus)
+
+		// A workflow waiting at its approve-gate is reviewed like any other pending action
+		if wfStatus.IsSuspended && wfStatus.Phase != "Succeeded" && wfStatus.Phase != "Failed" && wfStatus.Phase != "Error" {
+			return r.reconcileArgoApproval(ctx, wfManager, workload, workflowNamespace, wfStatus)
+		}
+		// The gate was resolved outside the operator (e.g. `argo resume`): drop the stale request
+		dropPendingActions(workload)
+		setAwaitingApprovalCondition(workload)
+
 		if wfStatus.Phase == "Succeeded" {
 			workload.Status.Phase = "Completed"
 		} else if wfStatus.Phase == "Failed" || wfStatus.Phase == "Error" {
 			workload.Status.Phase = "Failed"
 		} else if wfStatus.Phase == "Running" || wfStatus.Phase == "Pending" {
 			workload.Status.Phase = "Running"
-		} else if wfStatus.Phase == "Suspended" {
-			workload.Status.Phase = "Running"
 		}
 
 		if err := r.Status().Update(ctx, workload); err != nil {
 			log.Error(err, "failed to update status")
 		}
 
-		// Requeue to check status again
-		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+		// Workflow events trigger the next reconcile; poll only when they cannot be watched
+		return r.argoStatusRequeue(), nil
 	}
 
+	// Make sure the referenced WorkflowTemplate exists before submitting anything
+	templateName, templateNamespace, err := argo.ResolveWorkflowTemplateRef(workload)
+	reason := "WorkflowTemplateNamespaceNotAllowed"
+	if err == nil {
+		err = wfManager.ValidateWorkflowTemplateRef(ctx, templateName, templateNamespace)
+		reason = "WorkflowTemplateNotFound"
+	}
+	if err != nil {
+		log.Error(err, "referenced WorkflowTemplate is not available")
+		workload.Status.Phase = "Failed"
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionWorkflowTemplateValid,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workload.Generation,
+			Reason:             reason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, workload); err != nil {
+			log.Error(err, "failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+		Type:               ConditionWorkflowTemplateValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workload.Generation,
+		Reason:             "WorkflowTemplateFound",
+		Message:            fmt.Sprintf("WorkflowTemplate %s/%s found", templateNamespace, templateName),
+	})
+
 	// Create new workflow
 	log.Info("Creating new Argo Workflow", "jobId", workload.Spec.JobID)
 
@@ -631,7 +743,7 @@ func (r *AgentWorkloadReconciler) reconcileArgoWorkflow(ctx context.Context, wor
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
-	return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	return r.argoStatusRequeue(), nil
 }
 
 // routeAndCallModel handles cost-aware model routing for instructions
@@ -688,14 +800,19 @@ func (r *AgentWorkloadReconciler) routeAndCallModel(
 		log.Error(err, "budget check failed")
 		return nil, nil, err
 	}
+	if err := r.checkTenantTokenBudget(ctx, workload, instructions); err != nil {
+		return nil, nil, err
+	}
 
-	// Route and call the model
-	response, routingInfo, err := router.RouteAndCall(
+	// Route and call the model, streaming so long outputs are not cut off by a call timeout;
+	// a stream that goes silent is cut off after llm.DefaultStreamIdleTimeout
+	response, routingInfo, err := router.RouteAndStream(
 		ctx,
 		r.Client,
 		workload.Namespace,
 		&workload.Spec,
 		instructions,
+		nil,
 	)
 
 	if err != nil {
@@ -735,6 +852,9 @@ func (r *AgentWorkloadReconciler) routeAndCallModel(
 	if r.Metrics != nil {
 		r.Metrics.RecordModelRouting(routingInfo.TaskCategory, routingInfo.ProviderName, routingInfo.ModelName)
 		r.Metrics.RecordTokenUsage(routingInfo.ProviderName, routingInfo.ModelName, routingInfo.InputTokens, routingInfo.OutputTokens)
+		if routingInfo.TimeToFirstToken > 0 {
+			r.Metrics.RecordTimeToFirstToken(routingInfo.ProviderName, routingInfo.ModelName, routingInfo.TimeToFirstToken)
+		}
 	}
 
 	// Phase 4: Agent Evaluation — score quality of the model response
@@ -771,10 +891,22 @@ func (r *AgentWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		r.Metrics = metrics.NewRoutingMetrics()
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&agenticv1alpha1.AgentWorkload{}).
-		Named("agentworkload").
-		Complete(r)
+		Owns(&batchv1.Job{}).
+		Watches(&agenticv1alpha1.AgentCard{}, handler.EnqueueRequestsFromMapFunc(r.agentCardToWorkloads)).
+		Named("agentworkload")
+
+	// Watch Argo Workflows when their CRDs are installed; otherwise fall back to polling
+	if argoWorkflowsInstalled(mgr.GetRESTMapper()) {
+		builder = builder.Watches(newWorkflowWatchObject(), handler.EnqueueRequestsFromMapFunc(r.workflowToWorkload))
+		r.watchingWorkflows = true
+	} else {
+		mgr.GetLogger().Info("Argo Workflow CRDs not installed, polling workflow status instead of watching",
+			"interval", argoPollInterval)
+	}
+
+	return builder.Complete(r)
 }
 
 func (r *AgentWorkloadReconciler) updateWorkloadCostAnnotation(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) error {
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/argo"
+)
+
+// ArgoWorkflowCleanupFinalizer holds an Argo-orchestrated AgentWorkload until its Workflow
+// has been deleted or archived. Workflows usually live in another namespace, where
+// ownerReference garbage collection does not apply.
+const ArgoWorkflowCleanupFinalizer = "agentworkload.clawdlinux.io/argo-workflow-cleanup"
+
+// ensureArgoCleanupFinalizer adds the cleanup finalizer to an Argo-orchestrated workload
+func (r *AgentWorkloadReconciler) ensureArgoCleanupFinalizer(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) error {
+	if controllerutil.ContainsFinalizer(workload, ArgoWorkflowCleanupFinalizer) {
+		return nil
+	}
+
+	// Patch a copy so in-memory status changes are not overwritten by the server response
+	patched := workload.DeepCopy()
+	controllerutil.AddFinalizer(patched, ArgoWorkflowCleanupFinalizer)
+	if err := r.Patch(ctx, patched, client.MergeFrom(workload)); err != nil {
+		return err
+	}
+	workload.ObjectMeta = patched.ObjectMeta
+	return nil
+}
+
+// reconcileDelete deletes or archives the workload's Argo Workflow, according to
+// spec.orchestration.workflowRetention, then releases the finalizer
+func (r *AgentWorkloadReconciler) reconcileDelete(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(workload, ArgoWorkflowCleanupFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if ref := workload.Status.ArgoWorkflow; ref != nil && ref.Name != "" {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = argo.DefaultWorkflowNamespace
+		}
+
+		wfManager := argo.NewWorkflowManager(r.Client, r.Scheme)
+		retention := argo.WorkflowRetention(workload)
+
+		var err error
+		if retention == argo.WorkflowRetentionRetain {
+			err = wfManager.ArchiveArgoWorkflow(ctx, ref.Name, namespace)
+		} else {
+			err = wfManager.DeleteArgoWorkflow(ctx, ref.Name, namespace)
+		}
+		if err != nil {
+			log.Error(err, "failed to clean up Argo Workflow", "workflowName", ref.Name, "retention", retention)
+			return ctrl.Result{}, err
+		}
+		log.Info("Cleaned up Argo Workflow", "workflowName", ref.Name, "namespace", namespace, "retention", retention)
+	}
+
+	before := workload.DeepCopy()
+	controllerutil.RemoveFinalizer(workload, ArgoWorkflowCleanupFinalizer)
+	if err := r.Patch(ctx, workload, client.MergeFrom(before)); err != nil {
+		log.Error(err, "failed to remove finalizer")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{}, nil
+}
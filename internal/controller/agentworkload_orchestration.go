@@ -0,0 +1,140 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/jobs"
+)
+
+// Values for spec.orchestration.type
+const (
+	OrchestrationTypeArgo = "argo"
+	OrchestrationTypeJob  = "job"
+)
+
+// orchestrationEngine runs a workload's agent runtime on an orchestrator and reflects
+// its progress in the workload status
+type orchestrationEngine interface {
+	reconcile(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (ctrl.Result, error)
+}
+
+// orchestrationEngineFor returns the engine selected by spec.orchestration.type, or nil
+// when the workload is driven directly through its MCP server
+func (r *AgentWorkloadReconciler) orchestrationEngineFor(workload *agenticv1alpha1.AgentWorkload) orchestrationEngine {
+	if workload.Spec.Orchestration == nil || workload.Spec.Orchestration.Type == nil {
+		return nil
+	}
+	switch *workload.Spec.Orchestration.Type {
+	case OrchestrationTypeArgo:
+		return argoEngine{r}
+	case OrchestrationTypeJob:
+		return jobEngine{r}
+	}
+	return nil
+}
+
+// argoEngine runs workloads as Argo Workflows
+type argoEngine struct {
+	r *AgentWorkloadReconciler
+}
+
+func (e argoEngine) reconcile(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (ctrl.Result, error) {
+	return e.r.reconcileArgoWorkflow(ctx, workload)
+}
+
+// jobEngine runs workloads as a sequence of Kubernetes Jobs, without Argo
+type jobEngine struct {
+	r *AgentWorkloadReconciler
+}
+
+func (e jobEngine) reconcile(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (ctrl.Result, error) {
+	return e.r.reconcileJobWorkload(ctx, workload)
+}
+
+// reconcileJobWorkload advances the workload's Job sequence and maps its progress to the
+// workload phase the same way the Argo path maps Workflow phases
+func (r *AgentWorkloadReconciler) reconcileJobWorkload(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.Info("Reconciling Job-orchestrated workload", "name", workload.Name)
+
+	jobManager := jobs.NewJobManager(r.Client, r.Scheme)
+	sequence, err := jobManager.ReconcileJobSequence(ctx, workload)
+	if err != nil {
+		log.Error(err, "failed to reconcile Job sequence")
+		workload.Status.Phase = "Failed"
+		if err := r.Status().Update(ctx, workload); err != nil {
+			log.Error(err, "failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	applyJobSequenceStatus(workload, sequence)
+
+	if err := r.Status().Update(ctx, workload); err != nil {
+		log.Error(err, "failed to update status")
+	}
+
+	// Job events (the controller owns its Jobs) trigger the next reconcile
+	return ctrl.Result{}, nil
+}
+
+// applyJobSequenceStatus copies Job sequence progress into the workload status
+func applyJobSequenceStatus(workload *agenticv1alpha1.AgentWorkload, sequence *jobs.SequenceStatus) {
+	nodes := make([]agenticv1alpha1.WorkflowNodeStatus, 0, len(sequence.Steps))
+	for _, step := range sequence.Steps {
+		nodes = append(nodes, agenticv1alpha1.WorkflowNodeStatus{
+			Name:       step.Name,
+			Type:       "Job",
+			Phase:      step.Phase,
+			StartedAt:  step.StartedAt,
+			FinishedAt: step.FinishedAt,
+			Message:    step.Message,
+		})
+	}
+	workload.Status.WorkflowNodes = nodes
+
+	switch sequence.Phase {
+	case jobs.PhaseSucceeded:
+		workload.Status.Phase = "Completed"
+	case jobs.PhaseFailed:
+		workload.Status.Phase = "Failed"
+	default:
+		workload.Status.Phase = "Running"
+	}
+
+	if sequence.Phase != jobs.PhaseFailed {
+		meta.RemoveStatusCondition(&workload.Status.Conditions, ConditionWorkflowFailed)
+		return
+	}
+	meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+		Type:               ConditionWorkflowFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workload.Generation,
+		Reason:             "JobFailed",
+		Message:            fmt.Sprintf("%s (%d/%d steps completed)", sequence.Message, sequence.Completed, sequence.Total),
+	})
+}
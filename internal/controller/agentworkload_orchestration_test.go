@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+func newJobTestReconciler(t *testing.T, workload *agenticv1alpha1.AgentWorkload) (*AgentWorkloadReconciler, types.NamespacedName) {
+	t.Helper()
+	scheme := newControllerTestScheme(t)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agenticv1alpha1.AgentWorkload{}, &batchv1.Job{}).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, workload).
+		Build()
+	return &AgentWorkloadReconciler{Client: c, Scheme: scheme}, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+}
+
+func newJobWorkload(name string) *agenticv1alpha1.AgentWorkload {
+	jobType := OrchestrationTypeJob
+	return &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name + "-uid")},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			TargetURLs:    []string{"https://acme.example.com"},
+			Orchestration: &agenticv1alpha1.OrchestrationSpec{Type: &jobType},
+		},
+	}
+}
+
+func completeJob(t *testing.T, r *AgentWorkloadReconciler, key types.NamespacedName, condType batchv1.JobConditionType) {
+	t.Helper()
+	job := &batchv1.Job{}
+	if err := r.Get(context.Background(), key, job); err != nil {
+		t.Fatalf("expected Job %s: %v", key, err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type: condType, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded", Message: "Job was active longer than specified deadline",
+	}}
+	if err := r.Status().Update(context.Background(), job); err != nil {
+		t.Fatalf("failed to update Job status: %v", err)
+	}
+}
+
+func TestReconcile_JobEngineRunsWorkloadWithoutArgo(t *testing.T) {
+	r, key := newJobTestReconciler(t, newJobWorkload("job-workload"))
+
+	updated := reconcileAndGet(t, r, r.Client, key)
+	if updated.Status.Phase != "Running" {
+		t.Fatalf("expected Running while the Job runs, got %q", updated.Status.Phase)
+	}
+	if updated.Status.ArgoWorkflow != nil {
+		t.Fatal("expected no Argo Workflow for the job engine")
+	}
+
+	completeJob(t, r, key, batchv1.JobComplete)
+	updated = reconcileAndGet(t, r, r.Client, key)
+	if updated.Status.Phase != "Completed" {
+		t.Fatalf("expected Completed once the Job succeeded, got %q", updated.Status.Phase)
+	}
+	if len(updated.Status.WorkflowNodes) != 1 || updated.Status.WorkflowNodes[0].Phase != "Succeeded" {
+		t.Fatalf("expected one succeeded step, got %+v", updated.Status.WorkflowNodes)
+	}
+}
+
+func TestReconcile_JobEngineFailedJobFailsWorkload(t *testing.T) {
+	r, key := newJobTestReconciler(t, newJobWorkload("job-failing"))
+
+	reconcileAndGet(t, r, r.Client, key)
+	completeJob(t, r, key, batchv1.JobFailed)
+
+	updated := reconcileAndGet(t, r, r.Client, key)
+	if updated.Status.Phase != "Failed" {
+		t.Fatalf("expected Failed, got %q", updated.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionWorkflowFailed)
+	if cond == nil || cond.Reason != "JobFailed" {
+		t.Fatalf("expected WorkflowFailed condition from the Job, got %+v", cond)
+	}
+}
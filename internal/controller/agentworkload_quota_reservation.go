@@ -0,0 +1,172 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/metrics"
+	"github.com/shreyansh/agentic-operator/pkg/multitenancy"
+)
+
+// ConditionQuotaReserved reports whether the workload holds a reservation against its
+// tenant's daily workload quota and cost budget; workloads wait in Pending while it is False
+const ConditionQuotaReserved = "QuotaReserved"
+
+// workloadCostEstimateUSD is the cost reserved when a workload is admitted, until its
+// actual cost is known
+const workloadCostEstimateUSD = 10.0
+
+// quotaRetryInterval is how often a workload refused by its tenant's quota is retried;
+// the quota frees up when the window rolls over or reservations are settled
+const quotaRetryInterval = time.Hour
+
+// workloadQuotaKey identifies the workload in the reservation ledger when its UID is gone
+func workloadQuotaKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// reserveTenantQuota admits a workload that has not started against its tenant's quota.
+// The reservation is keyed by the workload UID, so requeues and retries do not charge it
+// again. A started workload that comes back from a retry without a reservation gets one
+// back, so its actual cost is committed when it completes. It returns true when the
+// workload must wait for quota.
+func (r *AgentWorkloadReconciler) reserveTenantQuota(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (bool, ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if r.QuotaMgr == nil || workloadFinished(workload) {
+		return false, ctrl.Result{}, nil
+	}
+	tenant := r.workloadTenant(ctx, workload)
+	if tenant == nil {
+		return false, ctrl.Result{}, nil
+	}
+
+	// A started workload was admitted before and is not stopped for quota now
+	if workload.Status.Phase != "" && workload.Status.Phase != "Pending" {
+		err := r.QuotaMgr.Hold(tenant.Name, string(workload.UID),
+			workloadQuotaKey(workload.Namespace, workload.Name), workloadCostEstimateUSD)
+		if err != nil && !errors.Is(err, multitenancy.ErrTenantNotFound) {
+			log.Error(err, "failed to hold tenant quota reservation", "tenant", tenant.Name)
+		}
+		return false, ctrl.Result{}, nil
+	}
+
+	err := r.QuotaMgr.Reserve(tenant.Name, string(workload.UID),
+		workloadQuotaKey(workload.Namespace, workload.Name), workloadCostEstimateUSD)
+	if errors.Is(err, multitenancy.ErrTenantNotFound) {
+		// Tenants the quota manager does not track yet have no quota to enforce
+		return false, ctrl.Result{}, nil
+	}
+	if err != nil {
+		reason := "DailyWorkloadQuotaExceeded"
+		if errors.Is(err, multitenancy.ErrBudgetExceeded) {
+			reason = "CostBudgetExceeded"
+		}
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionQuotaReserved,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workload.Generation,
+			Reason:             reason,
+			Message:            fmt.Sprintf("Tenant %s: %v", tenant.Name, err),
+		})
+		log.Info("Waiting for tenant quota", "tenant", tenant.Name, "reason", err.Error())
+		workload.Status.Phase = "Pending"
+		if err := r.Status().Update(ctx, workload); err != nil {
+			log.Error(err, "failed to update workload status")
+		}
+		return true, ctrl.Result{RequeueAfter: quotaRetryInterval}, nil
+	}
+
+	meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+		Type:               ConditionQuotaReserved,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workload.Generation,
+		Reason:             "Reserved",
+		Message:            fmt.Sprintf("Reserved $%.2f of tenant %s's cost budget", workloadCostEstimateUSD, tenant.Name),
+	})
+	return false, ctrl.Result{}, nil
+}
+
+// settleTenantQuota settles the reservation of a finished workload: a completed workload
+// is charged the cost of the tokens it used, and one that failed for good or was deleted
+// gets its reservation released. A failure that is retried keeps the reservation. Settling
+// is a no-op for a workload without a reservation.
+func (r *AgentWorkloadReconciler) settleTenantQuota(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) {
+	if r.QuotaMgr == nil {
+		return
+	}
+	deleted := !workload.DeletionTimestamp.IsZero()
+	if !workloadFinished(workload) && !deleted {
+		return
+	}
+	tenant := r.workloadTenant(ctx, workload)
+	if tenant == nil {
+		return
+	}
+	log := logf.FromContext(ctx)
+
+	uid := string(workload.UID)
+	if workload.Status.Phase == "Completed" {
+		cost := workloadActualCost(workload)
+		if settled, err := r.QuotaMgr.Commit(tenant.Name, uid, cost); err == nil && settled {
+			log.Info("Committed tenant quota reservation", "tenant", tenant.Name, "costUSD", cost)
+		}
+		return
+	}
+	if released, err := r.QuotaMgr.Release(tenant.Name, uid); err == nil && released {
+		log.Info("Released tenant quota reservation", "tenant", tenant.Name, "phase", workload.Status.Phase, "deleted", deleted)
+	}
+}
+
+// releaseDeletedWorkloadQuota releases the reservation of a workload that was deleted
+// before a reconcile saw it terminating
+func (r *AgentWorkloadReconciler) releaseDeletedWorkloadQuota(ctx context.Context, req ctrl.Request) {
+	if r.QuotaMgr == nil || r.TenantRes == nil {
+		return
+	}
+	tenant, err := r.TenantRes.ExtractFromNamespace(ctx, req.Namespace)
+	if err != nil || tenant == nil {
+		return
+	}
+	if released, err := r.QuotaMgr.ReleaseWorkload(tenant.Name, workloadQuotaKey(req.Namespace, req.Name)); err == nil && released > 0 {
+		logf.FromContext(ctx).Info("Released tenant quota reservation of deleted workload", "tenant", tenant.Name)
+	}
+}
+
+// workloadActualCost prices the tokens the workload used; usage of providers without
+// known pricing costs nothing
+func workloadActualCost(workload *agenticv1alpha1.AgentWorkload) float64 {
+	usage := workload.Status.TokensUsed
+	if usage == nil {
+		return 0
+	}
+	cost, err := metrics.NewCostCalculator().CalculateCost(usage.Provider, int(usage.InputTokens), int(usage.OutputTokens))
+	if err != nil {
+		return 0
+	}
+	return cost
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+func TestReserveTenantQuota_ChargesWorkloadOnce(t *testing.T) {
+	ctx := context.Background()
+	workload := newUsageWorkload("report", "", 0, metav1.Now().Time)
+	workload.UID = types.UID("uid-report")
+	tenant := newQuotaTestTenant(0, 0)
+	tenant.QuotaPerDay = 1
+	r := newTenantQuotaReconciler(t, tenant, workload)
+
+	// Requeues of an admitted workload keep its reservation
+	for i := 0; i < 3; i++ {
+		if stop, _, err := r.reserveTenantQuota(ctx, workload); stop || err != nil {
+			t.Fatalf("reconcile %d: expected the workload admitted, got stop=%v err=%v", i, stop, err)
+		}
+	}
+	status, _ := r.QuotaMgr.GetStatus("acme")
+	if status.WorkloadsUsed != 1 || status.Reservations != 1 || status.CostReserved != workloadCostEstimateUSD {
+		t.Fatalf("expected one reservation, got %+v", status)
+	}
+	if !meta.IsStatusConditionTrue(workload.Status.Conditions, ConditionQuotaReserved) {
+		t.Fatalf("expected QuotaReserved=True, got %+v", workload.Status.Conditions)
+	}
+
+	// Completing commits the cost of the tokens used instead of the estimate
+	workload.Status.Phase = "Completed"
+	workload.Status.TokensUsed = &agenticv1alpha1.TokenUsage{Provider: "openai", InputTokens: 1000, OutputTokens: 1000}
+	r.settleTenantQuota(ctx, workload)
+	status, _ = r.QuotaMgr.GetStatus("acme")
+	if status.Reservations != 0 || status.CostReserved != 0 || math.Abs(status.CostThisMonth-0.009) > 1e-9 {
+		t.Fatalf("expected $0.009 committed, got %+v", status)
+	}
+
+	// The daily quota is used up: the next workload waits in Pending
+	next := newUsageWorkload("next", "", 0, metav1.Now().Time)
+	next.UID = types.UID("uid-next")
+	if err := r.Create(ctx, next); err != nil {
+		t.Fatalf("failed to create workload: %v", err)
+	}
+	stop, result, err := r.reserveTenantQuota(ctx, next)
+	if !stop || err != nil || result.RequeueAfter != quotaRetryInterval {
+		t.Fatalf("expected the workload to wait for quota, got stop=%v result=%v err=%v", stop, result, err)
+	}
+	condition := meta.FindStatusCondition(next.Status.Conditions, ConditionQuotaReserved)
+	if next.Status.Phase != "Pending" || condition == nil || condition.Reason != "DailyWorkloadQuotaExceeded" {
+		t.Fatalf("expected Pending with DailyWorkloadQuotaExceeded, got %q %+v", next.Status.Phase, condition)
+	}
+}
+
+func TestSettleTenantQuota_ReleasesFinishedAndDeletedWorkloads(t *testing.T) {
+	ctx := context.Background()
+	failed := newUsageWorkload("failed", "", 0, metav1.Now().Time)
+	failed.UID = types.UID("uid-failed")
+	gone := newUsageWorkload("gone", "", 0, metav1.Now().Time)
+	gone.UID = types.UID("uid-gone")
+	r := newTenantQuotaReconciler(t, newQuotaTestTenant(0, 0))
+
+	for _, workload := range []*agenticv1alpha1.AgentWorkload{failed, gone} {
+		if stop, _, err := r.reserveTenantQuota(ctx, workload); stop || err != nil {
+			t.Fatalf("expected %s admitted, got stop=%v err=%v", workload.Name, stop, err)
+		}
+	}
+
+	// A failure that is retried keeps the reservation; a timeout ends the workload
+	failed.Status.Phase = "Failed"
+	r.settleTenantQuota(ctx, failed)
+	if status, _ := r.QuotaMgr.GetStatus("acme"); status.Reservations != 2 {
+		t.Fatalf("expected the retried workload to keep its reservation, got %+v", status)
+	}
+	markTimedOut(failed, "ExecutionTimeoutExceeded", "timed out")
+	r.settleTenantQuota(ctx, failed)
+
+	// A workload deleted before it was seen terminating is released by name
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(gone)}); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	status, _ := r.QuotaMgr.GetStatus("acme")
+	if status.Reservations != 0 || status.CostReserved != 0 || status.CostThisMonth != 0 || status.WorkloadsUsed != 2 {
+		t.Fatalf("expected both reservations released without cost, got %+v", status)
+	}
+}
+
+func TestReserveTenantQuota_HoldsRetriedWorkloadWithoutReservation(t *testing.T) {
+	ctx := context.Background()
+	workload := newUsageWorkload("retried", "Failed", 0, metav1.Now().Time)
+	workload.UID = types.UID("uid-retried")
+	tenant := newQuotaTestTenant(0, 0)
+	tenant.QuotaPerDay = 1
+	r := newTenantQuotaReconciler(t, tenant, workload)
+	// The daily quota is used up by another workload
+	if err := r.QuotaMgr.Reserve("acme", "uid-other", "tenant-acme/other", 0); err != nil {
+		t.Fatalf("Reserve failed: %v", err)
+	}
+
+	// A workload coming back from a retry is not stopped, and holds a reservation again
+	if stop, _, err := r.reserveTenantQuota(ctx, workload); stop || err != nil {
+		t.Fatalf("expected the retried workload to go on, got stop=%v err=%v", stop, err)
+	}
+	status, _ := r.QuotaMgr.GetStatus("acme")
+	if status.Reservations != 2 || status.WorkloadsUsed != 1 || status.CostReserved != workloadCostEstimateUSD {
+		t.Fatalf("expected the reservation held without another workload counted, got %+v", status)
+	}
+
+	// Completing commits its actual cost
+	workload.Status.Phase = "Completed"
+	workload.Status.TokensUsed = &agenticv1alpha1.TokenUsage{Provider: "openai", InputTokens: 1000, OutputTokens: 1000}
+	r.settleTenantQuota(ctx, workload)
+	status, _ = r.QuotaMgr.GetStatus("acme")
+	if status.Reservations != 1 || math.Abs(status.CostThisMonth-0.009) > 1e-9 {
+		t.Fatalf("expected $0.009 committed, got %+v", status)
+	}
+}
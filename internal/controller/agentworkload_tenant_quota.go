@@ -0,0 +1,244 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/llm"
+	"github.com/shreyansh/agentic-operator/pkg/multitenancy"
+	"github.com/shreyansh/agentic-operator/pkg/resilience"
+)
+
+const (
+	// ConditionConcurrencyAvailable reports whether the workload's tenant has a free slot
+	// under quotas.maxConcurrent; workloads wait in Pending while it is False
+	ConditionConcurrencyAvailable = "ConcurrencyAvailable"
+
+	// ConditionTokenBudgetAvailable reports whether the tenant's monthly token budget
+	// (quotas.maxMonthlyTokens) allows the workload's model call
+	ConditionTokenBudgetAvailable = "TokenBudgetAvailable"
+)
+
+// tokenBudgetRetryInterval is how often a workload refused for its tenant's token budget
+// is retried; the budget frees up when the month changes or the quota is raised
+const tokenBudgetRetryInterval = time.Hour
+
+// workloadTenant returns the tenant owning the workload's namespace, or nil when the
+// namespace belongs to no tenant or no tenant resolver is configured
+func (r *AgentWorkloadReconciler) workloadTenant(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) *multitenancy.TenantContext {
+	if r.TenantRes == nil {
+		return nil
+	}
+	tenant, err := r.TenantRes.ExtractFromNamespace(ctx, workload.Namespace)
+	if err != nil {
+		return nil
+	}
+	return tenant
+}
+
+// workloadFinished reports whether the workload will not run again: it completed, or it
+// failed in a way the reconciler does not retry (it timed out, its workflow or Job failed,
+// or a delegated sub-task failed). Other failures are retried, so the workload is still live.
+func workloadFinished(workload *agenticv1alpha1.AgentWorkload) bool {
+	switch workload.Status.Phase {
+	case "Completed":
+		return true
+	case "Failed":
+		conditions := workload.Status.Conditions
+		if meta.IsStatusConditionTrue(conditions, ConditionTimedOut) || meta.IsStatusConditionTrue(conditions, ConditionWorkflowFailed) {
+			return true
+		}
+		delegated := meta.FindStatusCondition(conditions, ConditionDelegated)
+		return delegated != nil && delegated.Status == metav1.ConditionFalse && delegated.Reason == "SubTaskFailed"
+	}
+	return false
+}
+
+// holdsConcurrencySlot reports whether the workload counts against its tenant's
+// quotas.maxConcurrent: it is Running, or it was admitted and has not finished yet
+func holdsConcurrencySlot(workload *agenticv1alpha1.AgentWorkload) bool {
+	if workloadFinished(workload) {
+		return false
+	}
+	return workload.Status.Phase == "Running" || meta.IsStatusConditionTrue(workload.Status.Conditions, ConditionConcurrencyAvailable)
+}
+
+// reconcileTenantConcurrency keeps a workload that has not started Pending while its tenant
+// already holds quotas.maxConcurrent slots. An admitted workload is marked with
+// ConcurrencyAvailable=True before it goes on, so it holds its slot from then on, including
+// while it is still Pending or retrying a failure. It returns true when the workload must wait.
+func (r *AgentWorkloadReconciler) reconcileTenantConcurrency(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (bool, ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	// A workload that already started or was admitted keeps going; only the start is gated
+	if workload.Status.Phase != "" && workload.Status.Phase != "Pending" {
+		return false, ctrl.Result{}, nil
+	}
+	if meta.IsStatusConditionTrue(workload.Status.Conditions, ConditionConcurrencyAvailable) {
+		return false, ctrl.Result{}, nil
+	}
+	tenant := r.workloadTenant(ctx, workload)
+	if tenant == nil || tenant.MaxConcurrent <= 0 {
+		return false, ctrl.Result{}, nil
+	}
+
+	var workloads agenticv1alpha1.AgentWorkloadList
+	if err := r.List(ctx, &workloads, client.InNamespace(workload.Namespace)); err != nil {
+		log.Error(err, "failed to list workloads for tenant concurrency")
+		return true, ctrl.Result{}, err
+	}
+	admitted := 0
+	for i := range workloads.Items {
+		if workloads.Items[i].Name != workload.Name && holdsConcurrencySlot(&workloads.Items[i]) {
+			admitted++
+		}
+	}
+
+	if admitted >= tenant.MaxConcurrent {
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionConcurrencyAvailable,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workload.Generation,
+			Reason:             "TenantConcurrencyLimit",
+			Message:            fmt.Sprintf("Tenant %s runs %d of %d concurrent workloads", tenant.Name, admitted, tenant.MaxConcurrent),
+		})
+		log.Info("Waiting for a tenant concurrency slot", "tenant", tenant.Name, "admitted", admitted, "maxConcurrent", tenant.MaxConcurrent)
+		workload.Status.Phase = "Pending"
+		if err := r.Status().Update(ctx, workload); err != nil {
+			log.Error(err, "failed to update status")
+		}
+		return true, ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	// The slot is recorded before the workload goes on, so the next workload counts it
+	meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+		Type:               ConditionConcurrencyAvailable,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workload.Generation,
+		Reason:             "SlotAvailable",
+		Message:            fmt.Sprintf("Admitted as %d of %d concurrent workloads of tenant %s", admitted+1, tenant.MaxConcurrent, tenant.Name),
+	})
+	workload.Status.Phase = "Pending"
+	if err := r.Status().Update(ctx, workload); err != nil {
+		log.Error(err, "failed to record tenant concurrency slot")
+		return true, ctrl.Result{}, err
+	}
+	return false, ctrl.Result{}, nil
+}
+
+// checkTenantTokenBudget refuses a model call for prompt when its estimated tokens would
+// exceed the monthly token budget of the workload's tenant. The refusal wraps
+// multitenancy.ErrTokenBudgetExceeded and is not retried.
+func (r *AgentWorkloadReconciler) checkTenantTokenBudget(ctx context.Context, workload *agenticv1alpha1.AgentWorkload, prompt string) error {
+	if r.QuotaMgr == nil {
+		return nil
+	}
+	tenant := r.workloadTenant(ctx, workload)
+	if tenant == nil {
+		return nil
+	}
+	err := r.QuotaMgr.CheckTokens(tenant.Name, llm.EstimateCallTokens(prompt))
+	if errors.Is(err, multitenancy.ErrTokenBudgetExceeded) {
+		return resilience.Permanent(err)
+	}
+	// Tenants the quota manager does not track yet have no token budget to enforce
+	return nil
+}
+
+// recordTenantTokens adds the tokens of the model call that completed the workload to the
+// monthly usage of the workload's tenant
+func (r *AgentWorkloadReconciler) recordTenantTokens(ctx context.Context, workload *agenticv1alpha1.AgentWorkload, routingInfo *llm.RoutingInfo) {
+	if r.QuotaMgr == nil || routingInfo == nil {
+		return
+	}
+	tenant := r.workloadTenant(ctx, workload)
+	if tenant == nil {
+		return
+	}
+	if err := r.QuotaMgr.RecordTokens(tenant.Name, int64(routingInfo.InputTokens+routingInfo.OutputTokens)); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to record tenant token usage", "tenant", tenant.Name)
+	}
+}
+
+// tokenUsageLocation returns the time zone of the monthly token window of the workload's
+// tenant, UTC for workloads outside tenant namespaces
+func (r *AgentWorkloadReconciler) tokenUsageLocation(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) *time.Location {
+	tenant := r.workloadTenant(ctx, workload)
+	switch {
+	case tenant == nil:
+		return time.UTC
+	case r.QuotaMgr != nil:
+		return r.QuotaMgr.QuotaLocation(tenant)
+	case tenant.QuotaLocation != nil:
+		return tenant.QuotaLocation
+	}
+	return time.UTC
+}
+
+// addTokenUsage adds the tokens of a model call completed at now to the workload's usage,
+// so a workload that runs again keeps counting the tokens of its earlier runs. The count
+// covers one month in loc: the first call of a new month starts it over, so tokens of
+// earlier months never count towards the tenant's usage this month.
+func addTokenUsage(usage *agenticv1alpha1.TokenUsage, routingInfo *llm.RoutingInfo, now time.Time, loc *time.Location) *agenticv1alpha1.TokenUsage {
+	total := &agenticv1alpha1.TokenUsage{
+		InputTokens:  int64(routingInfo.InputTokens),
+		OutputTokens: int64(routingInfo.OutputTokens),
+		Provider:     routingInfo.ProviderName,
+		Model:        routingInfo.ModelName,
+		RecordedAt:   &metav1.Time{Time: now},
+	}
+	if usage != nil && usage.RecordedAt != nil && sameMonth(usage.RecordedAt.Time, now, loc) {
+		total.InputTokens += usage.InputTokens
+		total.OutputTokens += usage.OutputTokens
+	}
+	return total
+}
+
+// setTokenBudgetCondition records on the workload whether its tenant's token budget
+// refused the model call. A workload that was never refused gets no condition.
+func setTokenBudgetCondition(workload *agenticv1alpha1.AgentWorkload, err error) {
+	if err != nil {
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionTokenBudgetAvailable,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workload.Generation,
+			Reason:             "MonthlyTokenBudgetExceeded",
+			Message:            err.Error(),
+		})
+		return
+	}
+	if meta.FindStatusCondition(workload.Status.Conditions, ConditionTokenBudgetAvailable) != nil {
+		meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+			Type:               ConditionTokenBudgetAvailable,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: workload.Generation,
+			Reason:             "WithinBudget",
+			Message:            "The tenant's monthly token budget allows the model call",
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/llm"
+	"github.com/shreyansh/agentic-operator/pkg/multitenancy"
+	"github.com/shreyansh/agentic-operator/pkg/resilience"
+)
+
+func newQuotaTestTenant(maxConcurrent int, maxMonthlyTokens int64) *multitenancy.TenantContext {
+	return &multitenancy.TenantContext{
+		Name:             "acme",
+		Namespace:        "tenant-acme",
+		MaxConcurrent:    maxConcurrent,
+		MaxMonthlyTokens: maxMonthlyTokens,
+		IsActive:         true,
+	}
+}
+
+func newTenantQuotaReconciler(t *testing.T, tenant *multitenancy.TenantContext, objs ...client.Object) *AgentWorkloadReconciler {
+	t.Helper()
+	scheme := newControllerTestScheme(t)
+	resolver := multitenancy.NewResolver()
+	if err := resolver.UpsertTenant(tenant); err != nil {
+		t.Fatalf("UpsertTenant failed: %v", err)
+	}
+	return &AgentWorkloadReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&agenticv1alpha1.AgentWorkload{}).Build(),
+		Scheme:           scheme,
+		LicenceValidator: &capturingValidator{},
+		TenantRes:        resolver,
+		QuotaMgr:         multitenancy.NewQuotaManager([]*multitenancy.TenantContext{tenant}),
+	}
+}
+
+func TestTenantConcurrency_WaitsForRunningWorkloads(t *testing.T) {
+	ctx := context.Background()
+	busy := newUsageWorkload("busy", "Running", 0, metav1.Now().Time)
+	waiting := newUsageWorkload("waiting", "", 0, metav1.Now().Time)
+	r := newTenantQuotaReconciler(t, newQuotaTestTenant(1, 0), busy, waiting)
+
+	stop, result, err := r.reconcileTenantConcurrency(ctx, waiting)
+	if !stop || err != nil || result.RequeueAfter == 0 {
+		t.Fatalf("expected workload to wait with a requeue, got stop=%v result=%v err=%v", stop, result, err)
+	}
+	if waiting.Status.Phase != "Pending" {
+		t.Fatalf("expected Pending, got %q", waiting.Status.Phase)
+	}
+	condition := meta.FindStatusCondition(waiting.Status.Conditions, ConditionConcurrencyAvailable)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "TenantConcurrencyLimit" {
+		t.Fatalf("expected ConcurrencyAvailable=False, got %+v", condition)
+	}
+
+	// The slot frees up once the running workload completes
+	busy.Status.Phase = "Completed"
+	if err := r.Status().Update(ctx, busy); err != nil {
+		t.Fatalf("failed to complete workload: %v", err)
+	}
+	stop, _, err = r.reconcileTenantConcurrency(ctx, waiting)
+	if stop || err != nil {
+		t.Fatalf("expected workload to start, got stop=%v err=%v", stop, err)
+	}
+	if !meta.IsStatusConditionTrue(waiting.Status.Conditions, ConditionConcurrencyAvailable) {
+		t.Fatalf("expected ConcurrencyAvailable=True, got %+v", waiting.Status.Conditions)
+	}
+}
+
+func TestTenantConcurrency_CountsAdmittedWorkloads(t *testing.T) {
+	ctx := context.Background()
+	first := newUsageWorkload("first", "", 0, metav1.Now().Time)
+	second := newUsageWorkload("second", "", 0, metav1.Now().Time)
+	r := newTenantQuotaReconciler(t, newQuotaTestTenant(1, 0), first, second)
+
+	if stop, _, err := r.reconcileTenantConcurrency(ctx, first); stop || err != nil {
+		t.Fatalf("expected the first workload admitted, got stop=%v err=%v", stop, err)
+	}
+	// The first workload is still Pending, but it holds the only slot
+	if stop, _, _ := r.reconcileTenantConcurrency(ctx, second); !stop {
+		t.Fatal("expected the second workload to wait for the admitted one")
+	}
+
+	// A failure that is retried keeps the slot; a timeout frees it
+	first.Status.Phase = "Failed"
+	if err := r.Status().Update(ctx, first); err != nil {
+		t.Fatalf("failed to update workload: %v", err)
+	}
+	if stop, _, _ := r.reconcileTenantConcurrency(ctx, second); !stop {
+		t.Fatal("expected a retrying workload to keep its slot")
+	}
+	markTimedOut(first, "ExecutionTimeoutExceeded", "timed out")
+	if err := r.Status().Update(ctx, first); err != nil {
+		t.Fatalf("failed to update workload: %v", err)
+	}
+	if stop, _, err := r.reconcileTenantConcurrency(ctx, second); stop || err != nil {
+		t.Fatalf("expected the slot freed by the timed out workload, got stop=%v err=%v", stop, err)
+	}
+}
+
+func TestTenantConcurrency_IgnoresStartedAndUnlimitedWorkloads(t *testing.T) {
+	ctx := context.Background()
+	busy := newUsageWorkload("busy", "Running", 0, metav1.Now().Time)
+	started := newUsageWorkload("started", "Running", 0, metav1.Now().Time)
+
+	r := newTenantQuotaReconciler(t, newQuotaTestTenant(1, 0), busy, started)
+	if stop, _, _ := r.reconcileTenantConcurrency(ctx, started); stop {
+		t.Fatal("expected a started workload to keep running")
+	}
+
+	fresh := newUsageWorkload("fresh", "", 0, metav1.Now().Time)
+	r = newTenantQuotaReconciler(t, newQuotaTestTenant(0, 0), busy, fresh)
+	if stop, _, _ := r.reconcileTenantConcurrency(ctx, fresh); stop {
+		t.Fatal("expected no limit when maxConcurrent is zero")
+	}
+}
+
+func TestReconcile_RefusesCallOverTenantTokenBudget(t *testing.T) {
+	ctx := context.Background()
+	strategy := "cost-aware"
+	objective := "Analyze quarterly revenue trends"
+	workload := &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "over-budget", Namespace: "tenant-acme"},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			ModelStrategy: &strategy,
+			Objective:     &objective,
+		},
+	}
+	r := newTenantQuotaReconciler(t, newQuotaTestTenant(0, 100), workload)
+	reporter := &stubCostReporter{recordCh: make(chan struct{}, 1)}
+	r.CostReporter = reporter
+
+	_, _, err := r.routeAndCallModel(ctx, workload)
+	if !errors.Is(err, multitenancy.ErrTokenBudgetExceeded) || !resilience.IsPermanent(err) {
+		t.Fatalf("expected a permanent token budget error, got %v", err)
+	}
+
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(workload)})
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if result.RequeueAfter != tokenBudgetRetryInterval {
+		t.Fatalf("expected retry after %v, got %v", tokenBudgetRetryInterval, result.RequeueAfter)
+	}
+	var got agenticv1alpha1.AgentWorkload
+	if err := r.Get(ctx, client.ObjectKeyFromObject(workload), &got); err != nil {
+		t.Fatalf("failed to get workload: %v", err)
+	}
+	if got.Status.Phase != "Failed" {
+		t.Fatalf("expected Failed, got %q", got.Status.Phase)
+	}
+	condition := meta.FindStatusCondition(got.Status.Conditions, ConditionTokenBudgetAvailable)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "MonthlyTokenBudgetExceeded" {
+		t.Fatalf("expected TokenBudgetAvailable=False, got %+v", condition)
+	}
+	select {
+	case <-reporter.recordCh:
+		t.Fatal("expected no usage recorded for a refused call")
+	default:
+	}
+}
+
+func TestReconcile_CompletedCostAwareWorkloadIsNotRoutedAgain(t *testing.T) {
+	ctx := context.Background()
+	mockServer := newMockOpenAIServer(mockOpenAIScenarioSuccess)
+	defer mockServer.Close()
+
+	strategy := "cost-aware"
+	objective := "Analyze quarterly revenue data and identify top trends."
+	endpoint := mockServer.URL
+	secretKey := "api-key"
+	workload := &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "routed", Namespace: "tenant-acme"},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			ModelStrategy: &strategy,
+			Objective:     &objective,
+			Providers: []agenticv1alpha1.LLMProvider{{
+				Name:         "mock-openai",
+				Type:         "openai-compatible",
+				Endpoint:     &endpoint,
+				APIKeySecret: &agenticv1alpha1.SecretKeyRef{Name: "provider-secret", Key: &secretKey},
+			}},
+			ModelMapping: map[string]string{"analysis": "mock-openai/gpt-4", "reasoning": "mock-openai/gpt-4"},
+		},
+	}
+	r := newTenantQuotaReconciler(t, newQuotaTestTenant(0, 0), workload,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "provider-secret", Namespace: "tenant-acme"}, Data: map[string][]byte{"api-key": []byte("test-token")}})
+	r.CostReporter = &stubCostReporter{recordCh: make(chan struct{}, 4)}
+	key := client.ObjectKeyFromObject(workload)
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	var completed agenticv1alpha1.AgentWorkload
+	if err := r.Get(ctx, key, &completed); err != nil {
+		t.Fatalf("failed to get workload: %v", err)
+	}
+	status, err := r.QuotaMgr.GetStatus("acme")
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if completed.Status.Phase != "Completed" || completed.Status.TokensUsed == nil || status.TokensThisMonth == 0 {
+		t.Fatalf("expected the model call to complete the workload and charge the tenant, got phase %q, tenant tokens %d",
+			completed.Status.Phase, status.TokensThisMonth)
+	}
+
+	// The status update of the completed workload triggers further reconciles
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("unexpected reconcile error: %v", err)
+		}
+	}
+	again, err := r.QuotaMgr.GetStatus("acme")
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if again.TokensThisMonth != status.TokensThisMonth {
+		t.Fatalf("expected tenant tokens to stay at %d, got %d", status.TokensThisMonth, again.TokensThisMonth)
+	}
+	var got agenticv1alpha1.AgentWorkload
+	if err := r.Get(ctx, key, &got); err != nil {
+		t.Fatalf("failed to get workload: %v", err)
+	}
+	if used, was := got.Status.TokensUsed, completed.Status.TokensUsed; used.InputTokens != was.InputTokens ||
+		used.OutputTokens != was.OutputTokens || !used.RecordedAt.Equal(was.RecordedAt) {
+		t.Fatalf("expected workload tokens unchanged, got %+v, was %+v", got.Status.TokensUsed, completed.Status.TokensUsed)
+	}
+}
+
+func TestAddTokenUsage_AccumulatesRunsWithinTheMonth(t *testing.T) {
+	now := time.Date(2026, time.April, 1, 0, 30, 0, 0, time.UTC)
+	first := addTokenUsage(nil, &llm.RoutingInfo{InputTokens: 100, OutputTokens: 50, ProviderName: "openai", ModelName: "gpt-4o-mini"},
+		now.Add(-time.Hour), time.UTC)
+	second := addTokenUsage(first, &llm.RoutingInfo{InputTokens: 30, OutputTokens: 20, ProviderName: "anthropic", ModelName: "claude-haiku"},
+		now, time.UTC)
+	if second.InputTokens != 30 || second.OutputTokens != 20 {
+		t.Fatalf("expected a run in a new month to start over at 30/20 tokens, got %d/%d", second.InputTokens, second.OutputTokens)
+	}
+	if second.Provider != "anthropic" || second.Model != "claude-haiku" || !second.RecordedAt.Time.Equal(now) {
+		t.Fatalf("expected the latest call's provider and time, got %+v", second)
+	}
+
+	// Both calls fall in March in a time zone behind UTC
+	newYork := time.FixedZone("EDT", -4*60*60)
+	second = addTokenUsage(first, &llm.RoutingInfo{InputTokens: 30, OutputTokens: 20}, now, newYork)
+	if second.InputTokens != 130 || second.OutputTokens != 70 {
+		t.Fatalf("expected 130/70 tokens across both runs of the month, got %d/%d", second.InputTokens, second.OutputTokens)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+const (
+	// ConditionTimedOut reports that the workload exceeded spec.timeouts.execution
+	// or was stopped because an approval gate exceeded spec.timeouts.suspendGate
+	ConditionTimedOut = "TimedOut"
+
+	// ConditionApprovalEscalated reports that an approval gate exceeded
+	// spec.timeouts.suspendGate under the "escalate" policy
+	ConditionApprovalEscalated = "ApprovalEscalated"
+
+	// Values for spec.timeouts.suspendGatePolicy
+	SuspendGatePolicyReject   = "reject"
+	SuspendGatePolicyEscalate = "escalate"
+
+	// suspendGateReviewer is recorded as the reviewer of actions rejected by the suspend gate timeout
+	suspendGateReviewer = "system:suspend-gate-timeout"
+)
+
+// executionTimeout returns spec.timeouts.execution, or 0 when unset
+func executionTimeout(workload *agenticv1alpha1.AgentWorkload) time.Duration {
+	if workload.Spec.Timeouts == nil || workload.Spec.Timeouts.Execution == nil || *workload.Spec.Timeouts.Execution <= 0 {
+		return 0
+	}
+	return time.Duration(*workload.Spec.Timeouts.Execution) * time.Second
+}
+
+// suspendGateTimeout returns spec.timeouts.suspendGate, or 0 when unset
+func suspendGateTimeout(workload *agenticv1alpha1.AgentWorkload) time.Duration {
+	if workload.Spec.Timeouts == nil || workload.Spec.Timeouts.SuspendGate == nil || *workload.Spec.Timeouts.SuspendGate <= 0 {
+		return 0
+	}
+	return time.Duration(*workload.Spec.Timeouts.SuspendGate) * time.Second
+}
+
+// suspendGatePolicy returns spec.timeouts.suspendGatePolicy, defaulting to "reject"
+func suspendGatePolicy(workload *agenticv1alpha1.AgentWorkload) string {
+	if workload.Spec.Timeouts == nil || workload.Spec.Timeouts.SuspendGatePolicy == nil || *workload.Spec.Timeouts.SuspendGatePolicy == "" {
+		return SuspendGatePolicyReject
+	}
+	return *workload.Spec.Timeouts.SuspendGatePolicy
+}
+
+// executionContext derives a context that expires when the workload's execution
+// timeout (measured from status.startTime) elapses. MCP and LLM calls use it so
+// they cannot outlive the workload's time budget.
+func executionContext(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (context.Context, context.CancelFunc) {
+	timeout := executionTimeout(workload)
+	if timeout == 0 || workload.Status.StartTime == nil {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, workload.Status.StartTime.Add(timeout))
+}
+
+// enforceExecutionTimeout records the workload start time and stops workloads that
+// ran past spec.timeouts.execution. It returns true when reconciliation must stop.
+func (r *AgentWorkloadReconciler) enforceExecutionTimeout(ctx context.Context, workload *agenticv1alpha1.AgentWorkload) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	if workload.Status.StartTime == nil {
+		now := metav1.Now()
+		workload.Status.StartTime = &now
+	}
+
+	// Already timed out on a previous reconcile: the workload is terminal
+	if meta.IsStatusConditionTrue(workload.Status.Conditions, ConditionTimedOut) {
+		return true, nil
+	}
+
+	timeout := executionTimeout(workload)
+	if timeout == 0 || time.Since(workload.Status.StartTime.Time) < timeout {
+		return false, nil
+	}
+
+	// A workload that completed within its budget is simply not run again
+	if workload.Status.Phase == "Completed" {
+		log.Info("Execution window elapsed, not re-running completed workload", "timeout", timeout)
+		return true, nil
+	}
+
+	log.Info("Execution timeout exceeded, failing workload", "timeout", timeout, "startTime", workload.Status.StartTime)
+	markTimedOut(workload, "ExecutionTimeoutExceeded",
+		fmt.Sprintf("Workload did not finish within spec.timeouts.execution (%s)", timeout))
+	if err := r.Status().Update(ctx, workload); err != nil {
+		log.Error(err, "failed to update workload status after execution timeout")
+		return true, err
+	}
+	return true, nil
+}
+
+// markTimedOut fails the workload and sets the TimedOut condition
+func markTimedOut(workload *agenticv1alpha1.AgentWorkload, reason, message string) {
+	now := metav1.Now()
+	workload.Status.Phase = "Failed"
+	workload.Status.LastReconcileTime = &now
+	meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+		Type:               ConditionTimedOut,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workload.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// markApprovalEscalated sets the ApprovalEscalated condition and reports whether it changed
+func markApprovalEscalated(workload *agenticv1alpha1.AgentWorkload, waited time.Duration) bool {
+	return meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+		Type:               ConditionApprovalEscalated,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workload.Generation,
+		Reason:             "SuspendGateTimeoutExceeded",
+		Message:            fmt.Sprintf("Approval has been pending for %s, longer than spec.timeouts.suspendGate", waited.Round(time.Second)),
+	})
+}
+
+// pendingSince returns the timestamp of the oldest action awaiting review
+func pendingSince(workload *agenticv1alpha1.AgentWorkload) *metav1.Time {
+	var oldest *metav1.Time
+	for _, action := range workload.Status.ProposedActions {
+		if !isPendingReview(action) || action.Timestamp == nil {
+			continue
+		}
+		if oldest == nil || action.Timestamp.Before(oldest) {
+			oldest = action.Timestamp
+		}
+	}
+	return oldest
+}
+
+// approvalRequeue returns how long to wait before re-checking an approval gate:
+// one hour, or sooner if the suspend gate timeout expires first
+func approvalRequeue(workload *agenticv1alpha1.AgentWorkload, since *metav1.Time) ctrl.Result {
+	requeue := 1 * time.Hour
+	if gate := suspendGateTimeout(workload); gate > 0 && since != nil {
+		if remaining := gate - time.Since(since.Time); remaining > 0 && remaining < requeue {
+			requeue = remaining
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeue}
+}
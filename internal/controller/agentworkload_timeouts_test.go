@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/argo"
+)
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestReconcile_ExecutionTimeoutFailsWorkload(t *testing.T) {
+	server := newCountingMCPServer()
+	defer server.Close()
+
+	scheme := newControllerTestScheme(t)
+	endpoint := server.URL
+	startTime := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	workload := &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "slow-workload", Namespace: "default"},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			MCPServerEndpoint: &endpoint,
+			Timeouts:          &agenticv1alpha1.TimeoutSpec{Execution: int32Ptr(60)},
+		},
+		Status: agenticv1alpha1.AgentWorkloadStatus{Phase: "Running", StartTime: &startTime},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agenticv1alpha1.AgentWorkload{}).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, workload).
+		Build()
+	r := &AgentWorkloadReconciler{Client: c, Scheme: scheme}
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("reconcile returned error: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Fatalf("expected no requeue after timeout, got %v", result.RequeueAfter)
+	}
+
+	updated := &agenticv1alpha1.AgentWorkload{}
+	if err := c.Get(context.Background(), key, updated); err != nil {
+		t.Fatalf("failed to fetch workload: %v", err)
+	}
+	if updated.Status.Phase != "Failed" {
+		t.Fatalf("expected Failed, got %q", updated.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionTimedOut)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != "ExecutionTimeoutExceeded" {
+		t.Fatalf("expected TimedOut condition with ExecutionTimeoutExceeded, got %+v", cond)
+	}
+	if got := server.count("get_status"); got != 0 {
+		t.Fatalf("expected no MCP calls after timeout, got %d", got)
+	}
+}
+
+func TestReconcile_RecordsStartTime(t *testing.T) {
+	server := newCountingMCPServer()
+	defer server.Close()
+	r, c, key := newApprovalTestReconciler(t, server.URL)
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.StartTime == nil {
+		t.Fatal("expected status.startTime to be recorded on first reconcile")
+	}
+}
+
+func TestExecutionContext_UsesStartTimeDeadline(t *testing.T) {
+	startTime := metav1.NewTime(time.Now().Add(-30 * time.Second))
+	workload := &agenticv1alpha1.AgentWorkload{
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			Timeouts: &agenticv1alpha1.TimeoutSpec{Execution: int32Ptr(90)},
+		},
+		Status: agenticv1alpha1.AgentWorkloadStatus{StartTime: &startTime},
+	}
+
+	ctx, cancel := executionContext(context.Background(), workload)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected execution context to carry a deadline")
+	}
+	if want := startTime.Add(90 * time.Second); !deadline.Equal(want) {
+		t.Fatalf("deadline = %v, want %v", deadline, want)
+	}
+
+	workload.Spec.Timeouts = nil
+	ctx, cancel = executionContext(context.Background(), workload)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("expected no deadline without spec.timeouts.execution")
+	}
+}
+
+// expirePendingActions backdates pending actions and sets the suspend gate policy
+func expirePendingActions(t *testing.T, r *AgentWorkloadReconciler, key types.NamespacedName, policy string) {
+	t.Helper()
+	workload := &agenticv1alpha1.AgentWorkload{}
+	if err := r.Get(context.Background(), key, workload); err != nil {
+		t.Fatalf("failed to fetch workload: %v", err)
+	}
+	workload.Spec.Timeouts = &agenticv1alpha1.TimeoutSpec{SuspendGate: int32Ptr(60), SuspendGatePolicy: &policy}
+	if err := r.Update(context.Background(), workload); err != nil {
+		t.Fatalf("failed to update spec: %v", err)
+	}
+	past := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	for i := range workload.Status.ProposedActions {
+		workload.Status.ProposedActions[i].Timestamp = &past
+	}
+	if err := r.Status().Update(context.Background(), workload); err != nil {
+		t.Fatalf("failed to backdate actions: %v", err)
+	}
+}
+
+func TestReconcile_SuspendGateTimeoutRejectsPendingActions(t *testing.T) {
+	server := newCountingMCPServer()
+	defer server.Close()
+	r, c, key := newApprovalTestReconciler(t, server.URL)
+
+	reconcileAndGet(t, r, c, key)
+	expirePendingActions(t, r, key, SuspendGatePolicyReject)
+
+	updated := reconcileAndGet(t, r, c, key)
+	if got := server.count("execute_action"); got != 0 {
+		t.Fatalf("expected no execute_action calls, got %d", got)
+	}
+	if len(updated.Status.RejectedActions) != 1 {
+		t.Fatalf("expected 1 rejected action, got %d", len(updated.Status.RejectedActions))
+	}
+	if updated.Status.RejectedActions[0].ReviewedBy != suspendGateReviewer {
+		t.Fatalf("expected reviewer %q, got %q", suspendGateReviewer, updated.Status.RejectedActions[0].ReviewedBy)
+	}
+	if updated.Status.Phase == "PendingApproval" {
+		t.Fatal("expected workload to leave PendingApproval")
+	}
+}
+
+func TestReconcile_SuspendGateTimeoutEscalates(t *testing.T) {
+	server := newCountingMCPServer()
+	defer server.Close()
+	r, c, key := newApprovalTestReconciler(t, server.URL)
+
+	reconcileAndGet(t, r, c, key)
+	expirePendingActions(t, r, key, SuspendGatePolicyEscalate)
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "PendingApproval" {
+		t.Fatalf("expected workload to keep waiting, got %q", updated.Status.Phase)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionApprovalEscalated) {
+		t.Fatal("expected ApprovalEscalated condition to be true")
+	}
+	if len(updated.Status.RejectedActions) != 0 {
+		t.Fatalf("expected no rejected actions, got %d", len(updated.Status.RejectedActions))
+	}
+}
+
+func TestReconcile_ArgoSuspendGateTimeoutRejectsWorkflow(t *testing.T) {
+	scheme := newControllerTestScheme(t)
+	argoType := "argo"
+	policy := SuspendGatePolicyReject
+	workload := &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "argo-workload", Namespace: "default"},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			Orchestration: &agenticv1alpha1.OrchestrationSpec{Type: &argoType},
+			Timeouts:      &agenticv1alpha1.TimeoutSpec{SuspendGate: int32Ptr(60), SuspendGatePolicy: &policy},
+		},
+		Status: agenticv1alpha1.AgentWorkloadStatus{
+			Phase:        "Running",
+			ArgoWorkflow: &agenticv1alpha1.ArgoWorkflowRef{Name: "argo-workload", Namespace: argo.DefaultWorkflowNamespace},
+		},
+	}
+	workflow := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": argo.WorkflowGroupVersion,
+		"kind":       argo.WorkflowKind,
+		"metadata":   map[string]interface{}{"name": "argo-workload", "namespace": argo.DefaultWorkflowNamespace},
+		"status": map[string]interface{}{
+			"phase": "Running",
+			"nodes": map[string]interface{}{
+				"argo-workload-approve": map[string]interface{}{
+					"type":      "Suspend",
+					"phase":     "Running",
+					"startedAt": time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339),
+				},
+			},
+		},
+	}}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agenticv1alpha1.AgentWorkload{}).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, workload, workflow).
+		Build()
+	r := &AgentWorkloadReconciler{Client: c, Scheme: scheme}
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+
+	updated := reconcileAndGet(t, r, c, key)
+	if updated.Status.Phase != "Failed" {
+		t.Fatalf("expected Failed, got %q", updated.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionTimedOut)
+	if cond == nil || cond.Reason != "SuspendGateTimeoutExceeded" {
+		t.Fatalf("expected TimedOut condition with SuspendGateTimeoutExceeded, got %+v", cond)
+	}
+
+	got := &unstructured.Unstructured{}
+	got.SetAPIVersion(argo.WorkflowGroupVersion)
+	got.SetKind(argo.WorkflowKind)
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "argo-workload", Namespace: argo.DefaultWorkflowNamespace}, got); err != nil {
+		t.Fatalf("failed to fetch workflow: %v", err)
+	}
+	if phase, _, _ := unstructured.NestedString(got.Object, "status", "nodes", "argo-workload-approve", "phase"); phase != "Failed" {
+		t.Fatalf("expected suspend node to be failed, got %q", phase)
+	}
+	if len(updated.Status.RejectedActions) != 1 || updated.Status.RejectedActions[0].ReviewedBy != suspendGateReviewer {
+		t.Fatalf("expected gate rejected by %q, got %+v", suspendGateReviewer, updated.Status.RejectedActions)
+	}
+}
@@ -18,10 +18,13 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -31,29 +34,50 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/finops"
+	"github.com/shreyansh/agentic-operator/pkg/multitenancy"
 )
 
+// providerSecretNamespace holds the provider secrets copied into tenant namespaces
+const providerSecretNamespace = "agentic-system"
+
 // TenantReconciler reconciles a Tenant object
 type TenantReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// CostReporter provides the workload costs summed into status.costTodayUSD (optional)
+	CostReporter finops.CostReporter
+
+	// QuotaLocation is the time zone of the monthly usage window of tenants without
+	// quotas.timezone (optional, defaults to UTC)
+	QuotaLocation *time.Location
+
+	// QuotaMgr records the tokens of every model call, including those of workloads that
+	// were deleted since; status.tokensUsedThisMonth follows it (optional)
+	QuotaMgr *multitenancy.QuotaManager
 }
 
 // +kubebuilder:rbac:groups=agentic.clawdlinux.org,resources=tenants,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=agentic.clawdlinux.org,resources=tenants/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=agentic.clawdlinux.org,resources=tenants/finalizers,verbs=update
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;patch
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
-// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=create;get;list;patch
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=create;get;list;patch
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=create;get;list;patch
-// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=create;get;list;patch
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;get;list;patch
-
-// Reconcile implements the reconciliation loop for Tenant provisioning
+// +kubebuilder:rbac:groups=agentic.clawdlinux.org,resources=agentworkloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile implements the reconciliation loop for Tenant provisioning. Every pass compares
+// the tenant's resources with the spec and corrects any drift; the status flags and
+// per-resource conditions report what was observed.
 func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
@@ -61,7 +85,6 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	var tenant agenticv1alpha1.Tenant
 	if err := r.Get(ctx, req.NamespacedName, &tenant); err != nil {
 		if apierrors.IsNotFound(err) {
-			log.Info("Tenant deleted", "tenant", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "unable to fetch Tenant")
@@ -70,148 +93,223 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	log.Info("Reconciling Tenant", "name", tenant.Name, "namespace", tenant.Spec.Namespace)
 
-	// Update phase to Provisioning
-	meta.SetStatusCondition(&tenant.Status.Conditions, metav1.Condition{
-		Type:               "Provisioning",
-		Status:             metav1.ConditionTrue,
-		ObservedGeneration: tenant.Generation,
-		Reason:             "ProvisioningStarted",
-		Message:            "Tenant provisioning in progress",
-	})
-	tenant.Status.Phase = "Provisioning"
+	// Tenants being deleted only need their resources torn down or retained
+	if !tenant.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &tenant)
+	}
+	if err := r.ensureTenantFinalizer(ctx, &tenant); err != nil {
+		log.Error(err, "failed to add cleanup finalizer")
+		return ctrl.Result{}, err
+	}
 
-	// Step 1: Create Namespace
-	if !tenant.Status.NamespaceCreated {
-		if err := r.createNamespace(ctx, &tenant); err != nil {
-			log.Error(err, "failed to create namespace")
-			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
-		}
-		tenant.Status.NamespaceCreated = true
-		log.Info("Namespace created", "namespace", tenant.Spec.Namespace)
+	// Step 1: Namespace. Nothing else can be provisioned without it.
+	nsSync := &tenantSync{}
+	err := r.reconcileNamespace(ctx, &tenant, nsSync)
+	tenant.Status.NamespaceCreated = setTenantCondition(&tenant, nsSync.condition(ConditionTenantNamespaceReady, tenant.Generation, err))
+	var failed []string
+	conflict := false
+	if err != nil {
+		log.Error(err, "failed to reconcile namespace")
+		failed = append(failed, ConditionTenantNamespaceReady)
+		conflict = errors.Is(err, errTenantOwnershipConflict)
 	}
 
-	// Step 2: Provision Secrets
-	if !tenant.Status.SecretsProvisioned {
-		if err := r.provisionSecrets(ctx, &tenant); err != nil {
-			log.Error(err, "failed to provision secrets")
-			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	// Steps 2-5: Secrets, RBAC, quotas and network isolation
+	steps := []struct {
+		condition string
+		flag      *bool
+		apply     func(context.Context, *agenticv1alpha1.Tenant, *tenantSync) error
+	}{
+		{ConditionTenantSecretsReady, &tenant.Status.SecretsProvisioned, r.provisionSecrets},
+		{ConditionTenantRBACReady, &tenant.Status.RBACConfigured, r.configureRBAC},
+		{ConditionTenantQuotaReady, &tenant.Status.QuotasEnforced, r.enforceQuotas},
+		{ConditionTenantNetworkPolicyReady, &tenant.Status.NetworkPolicyActive, r.enforceNetworkPolicy},
+	}
+	for _, step := range steps {
+		if !tenant.Status.NamespaceCreated {
+			*step.flag = false
+			meta.SetStatusCondition(&tenant.Status.Conditions, metav1.Condition{
+				Type:               step.condition,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: tenant.Generation,
+				Reason:             tenantReasonNamespaceNotReady,
+				Message:            fmt.Sprintf("Namespace %s is not ready", tenant.Spec.Namespace),
+			})
+			continue
+		}
+		sync := &tenantSync{}
+		err := step.apply(ctx, &tenant, sync)
+		*step.flag = setTenantCondition(&tenant, sync.condition(step.condition, tenant.Generation, err))
+		if err != nil {
+			log.Error(err, "failed to reconcile tenant resources", "condition", step.condition)
+			failed = append(failed, step.condition)
+			conflict = conflict || errors.Is(err, errTenantOwnershipConflict)
+		} else if len(sync.corrected) > 0 {
+			log.Info("Corrected drift in tenant resources", "condition", step.condition, "resources", sync.corrected)
 		}
-		tenant.Status.SecretsProvisioned = true
-		log.Info("Secrets provisioned", "namespace", tenant.Spec.Namespace)
 	}
 
-	// Step 3: Configure RBAC
-	if !tenant.Status.RBACConfigured {
-		if err := r.configureRBAC(ctx, &tenant); err != nil {
-			log.Error(err, "failed to configure RBAC")
-			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	// Usage is aggregated whenever the namespace exists, even if provisioning is incomplete
+	if tenant.Status.NamespaceCreated {
+		if err := r.aggregateTenantUsage(ctx, &tenant, time.Now()); err != nil {
+			log.Error(err, "failed to aggregate tenant usage")
 		}
-		tenant.Status.RBACConfigured = true
-		log.Info("RBAC configured", "namespace", tenant.Spec.Namespace)
 	}
 
-	// Step 4: Enforce Quotas
-	if !tenant.Status.QuotasEnforced {
-		if err := r.enforceQuotas(ctx, &tenant); err != nil {
-			log.Error(err, "failed to enforce quotas")
-			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	// Isolation that is switched off is not active, even though no policy is out of sync
+	if !tenant.Spec.NetworkPolicy {
+		tenant.Status.NetworkPolicyActive = false
+		if condition := meta.FindStatusCondition(tenant.Status.Conditions, ConditionTenantNetworkPolicyReady); condition != nil && condition.Reason == tenantReasonInSync {
+			condition.Reason = tenantReasonDisabled
+			condition.Message = "Network isolation is disabled"
 		}
-		tenant.Status.QuotasEnforced = true
-		log.Info("Quotas enforced", "namespace", tenant.Spec.Namespace)
 	}
 
-	// All provisioning complete
-	meta.SetStatusCondition(&tenant.Status.Conditions, metav1.Condition{
-		Type:    "Ready",
-		Status:  metav1.ConditionTrue,
-		Reason:  "ProvisioningComplete",
-		Message: "Tenant fully provisioned and ready",
-	})
-	tenant.Status.Phase = "Active"
-	tenant.Status.LastReconciliation = &metav1.Time{Time: time.Now()}
+	if len(failed) == 0 {
+		meta.SetStatusCondition(&tenant.Status.Conditions, metav1.Condition{
+			Type:               "Provisioning",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: tenant.Generation,
+			Reason:             "ProvisioningComplete",
+			Message:            "Tenant resources match the spec",
+		})
+		meta.SetStatusCondition(&tenant.Status.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: tenant.Generation,
+			Reason:             "ProvisioningComplete",
+			Message:            "Tenant fully provisioned and ready",
+		})
+		tenant.Status.Phase = "Active"
+		tenant.Status.LastReconciliation = &metav1.Time{Time: time.Now()}
+	} else {
+		meta.SetStatusCondition(&tenant.Status.Conditions, metav1.Condition{
+			Type:               "Provisioning",
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: tenant.Generation,
+			Reason:             "ProvisioningStarted",
+			Message:            "Tenant provisioning in progress",
+		})
+		meta.SetStatusCondition(&tenant.Status.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: tenant.Generation,
+			Reason:             "ResourcesNotReady",
+			Message:            "Not ready: " + strings.Join(failed, ", "),
+		})
+		// An ownership conflict needs an operator to resolve it; other errors are retried
+		tenant.Status.Phase = "Provisioning"
+		if conflict {
+			tenant.Status.Phase = "Failed"
+		}
+	}
 
 	if err := r.Status().Update(ctx, &tenant); err != nil {
 		log.Error(err, "failed to update status")
 		return ctrl.Result{}, err
 	}
 
+	if len(failed) > 0 {
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
 	log.Info("Tenant provisioned successfully", "tenant", tenant.Name)
 	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
 }
 
-func (r *TenantReconciler) createNamespace(ctx context.Context, tenant *agenticv1alpha1.Tenant) error {
-	ns := &corev1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: tenant.Spec.Namespace,
-			Labels: map[string]string{
-				"agentic-tenant":   "true",
-				"agentic-customer": tenant.Name,
-				"managed-by":       "agentic-operator",
-			},
-		},
-	}
-
-	err := r.Create(ctx, ns)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
+// reconcileNamespace creates the tenant namespace or restores its tenant labels. Only a
+// namespace the operator creates is labelled managed-by, which makes it deleted with the
+// Tenant; a namespace labelled for another tenant is never taken over.
+func (r *TenantReconciler) reconcileNamespace(ctx context.Context, tenant *agenticv1alpha1.Tenant, sync *tenantSync) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tenant.Spec.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, ns, func() error {
+		labels := ns.GetLabels()
+		if customer := labels[tenantCustomerLabel]; customer != "" && customer != tenant.Name {
+			return fmt.Errorf("namespace %s %w %q", ns.Name, errTenantOwnershipConflict, customer)
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		if ns.ResourceVersion == "" {
+			labels[tenantManagedByLabel] = tenantManagedBy
+		}
+		labels[tenantLabel] = "true"
+		labels[tenantCustomerLabel] = tenant.Name
+		// A namespace retained from an earlier Tenant of the same name is back in use
+		delete(labels, tenantRetainedLabel)
+		delete(ns.Annotations, tenantRetainedAtAnnotation)
+		ns.SetLabels(labels)
+		return nil
+	})
+	if err != nil {
 		return err
 	}
+	sync.record("Namespace", ns.Name, op)
 	return nil
 }
 
-func (r *TenantReconciler) provisionSecrets(ctx context.Context, tenant *agenticv1alpha1.Tenant) error {
-	// Copy provider secrets from agentic-system to tenant namespace
+// provisionSecrets copies the secret of every provider in the spec from agentic-system
+// and deletes the copies of providers no longer listed
+func (r *TenantReconciler) provisionSecrets(ctx context.Context, tenant *agenticv1alpha1.Tenant, sync *tenantSync) error {
+	desired := map[string]bool{}
 	for _, provider := range tenant.Spec.Providers {
-		secretName := fmt.Sprintf("%s-token", provider)
+		secretName := providerSecretName(provider)
+		desired[secretName] = true
 
 		// Read source secret from agentic-system
 		srcSecret := &corev1.Secret{}
 		if err := r.Get(ctx, types.NamespacedName{
 			Name:      secretName,
-			Namespace: "agentic-system",
+			Namespace: providerSecretNamespace,
 		}, srcSecret); err != nil {
 			return fmt.Errorf("failed to read source secret %s: %w", secretName, err)
 		}
 
-		// Create copy in tenant namespace
-		dstSecret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      secretName,
-				Namespace: tenant.Spec.Namespace,
-				Labels: map[string]string{
-					"agentic-tenant": tenant.Name,
-				},
-			},
-			Type: srcSecret.Type,
-			Data: srcSecret.Data,
+		dstSecret := &corev1.Secret{ObjectMeta: tenantObjectMeta(tenant, secretName)}
+		op, err := r.applyTenantObject(ctx, tenant, dstSecret, func() error {
+			// The type of an existing secret is immutable
+			if dstSecret.ResourceVersion == "" {
+				dstSecret.Type = srcSecret.Type
+			}
+			dstSecret.Data = srcSecret.Data
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply secret %s: %w", secretName, err)
 		}
+		sync.record("Secret", secretName, op)
+	}
 
-		err := r.Create(ctx, dstSecret)
-		if err != nil && !apierrors.IsAlreadyExists(err) {
-			return fmt.Errorf("failed to create secret %s: %w", secretName, err)
+	var copies corev1.SecretList
+	if err := r.List(ctx, &copies, client.InNamespace(tenant.Spec.Namespace),
+		client.MatchingLabels{tenantLabel: tenant.Name}); err != nil {
+		return err
+	}
+	for i := range copies.Items {
+		secret := &copies.Items[i]
+		if desired[secret.Name] {
+			continue
+		}
+		if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete secret %s: %w", secret.Name, err)
 		}
+		sync.removed("Secret", secret.Name)
 	}
 	return nil
 }
 
-func (r *TenantReconciler) configureRBAC(ctx context.Context, tenant *agenticv1alpha1.Tenant) error {
-	// Create service account
-	sa := &corev1.ServiceAccount{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-agent", tenant.Name),
-			Namespace: tenant.Spec.Namespace,
-		},
-	}
-	if err := r.Create(ctx, sa); err != nil && !apierrors.IsAlreadyExists(err) {
-		return err
+func (r *TenantReconciler) configureRBAC(ctx context.Context, tenant *agenticv1alpha1.Tenant, sync *tenantSync) error {
+	// Service account
+	sa := &corev1.ServiceAccount{ObjectMeta: tenantObjectMeta(tenant, tenantServiceAccountName(tenant))}
+	op, err := r.applyTenantObject(ctx, tenant, sa, func() error { return nil })
+	if err != nil {
+		return fmt.Errorf("failed to apply service account: %w", err)
 	}
+	sync.record("ServiceAccount", sa.Name, op)
 
-	// Create role for workload management
-	role := &rbacv1.Role{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-workload-manager", tenant.Name),
-			Namespace: tenant.Spec.Namespace,
-		},
-		Rules: []rbacv1.PolicyRule{
+	// Role for workload management
+	role := &rbacv1.Role{ObjectMeta: tenantObjectMeta(tenant, tenantRoleName(tenant))}
+	op, err = r.applyTenantObject(ctx, tenant, role, func() error {
+		role.Rules = []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{"agentic.clawdlinux.org"},
 				Resources: []string{"agentworkloads"},
@@ -222,78 +320,116 @@ func (r *TenantReconciler) configureRBAC(ctx context.Context, tenant *agenticv1a
 				Resources: []string{"secrets"},
 				Verbs:     []string{"get", "list"},
 			},
-		},
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply role: %w", err)
 	}
-	if err := r.Create(ctx, role); err != nil && !apierrors.IsAlreadyExists(err) {
+	sync.record("Role", role.Name, op)
+
+	// Role binding. Its roleRef is immutable, so a binding to another role is replaced.
+	roleRef := rbacv1.RoleRef{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "Role",
+		Name:     tenantRoleName(tenant),
+	}
+	rb := &rbacv1.RoleBinding{ObjectMeta: tenantObjectMeta(tenant, tenantRoleBindingName(tenant))}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(rb), rb); client.IgnoreNotFound(err) != nil {
 		return err
 	}
-
-	// Create role binding
-	rb := &rbacv1.RoleBinding{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-workload-binding", tenant.Name),
-			Namespace: tenant.Spec.Namespace,
-		},
-		RoleRef: rbacv1.RoleRef{
-			APIGroup: "rbac.authorization.k8s.io",
-			Kind:     "Role",
-			Name:     fmt.Sprintf("%s-workload-manager", tenant.Name),
-		},
-		Subjects: []rbacv1.Subject{
+	replaced := false
+	if rb.ResourceVersion != "" && rb.Labels[tenantLabel] == tenant.Name && rb.RoleRef != roleRef {
+		if err := r.Delete(ctx, rb); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to replace role binding: %w", err)
+		}
+		rb = &rbacv1.RoleBinding{ObjectMeta: tenantObjectMeta(tenant, tenantRoleBindingName(tenant))}
+		replaced = true
+	}
+	op, err = r.applyTenantObject(ctx, tenant, rb, func() error {
+		if rb.ResourceVersion == "" {
+			rb.RoleRef = roleRef
+		}
+		rb.Subjects = []rbacv1.Subject{
 			{
 				Kind:      "ServiceAccount",
-				Name:      fmt.Sprintf("%s-agent", tenant.Name),
+				Name:      tenantServiceAccountName(tenant),
 				Namespace: tenant.Spec.Namespace,
 			},
-		},
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply role binding: %w", err)
 	}
-	if err := r.Create(ctx, rb); err != nil && !apierrors.IsAlreadyExists(err) {
-		return err
+	if replaced {
+		op = controllerutil.OperationResultUpdated
 	}
+	sync.record("RoleBinding", rb.Name, op)
 
 	return nil
 }
 
-func (r *TenantReconciler) enforceQuotas(ctx context.Context, tenant *agenticv1alpha1.Tenant) error {
-	quotas := tenant.Spec.Quotas
-
-	rq := &corev1.ResourceQuota{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-quota", tenant.Name),
-			Namespace: tenant.Spec.Namespace,
-		},
-		Spec: corev1.ResourceQuotaSpec{
-			Hard: corev1.ResourceList{},
-		},
+func (r *TenantReconciler) enforceQuotas(ctx context.Context, tenant *agenticv1alpha1.Tenant, sync *tenantSync) error {
+	hard, err := tenantQuotaLimits(tenant.Spec.Quotas)
+	if err != nil {
+		return err
 	}
 
+	rq := &corev1.ResourceQuota{ObjectMeta: tenantObjectMeta(tenant, tenantQuotaName(tenant))}
+	op, err := r.applyTenantObject(ctx, tenant, rq, func() error {
+		rq.Spec.Hard = hard
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply resource quota: %w", err)
+	}
+	sync.record("ResourceQuota", rq.Name, op)
+	return nil
+}
+
+// tenantQuotaLimits converts the tenant quotas into ResourceQuota hard limits
+func tenantQuotaLimits(quotas agenticv1alpha1.TenantQuotas) (corev1.ResourceList, error) {
+	// The time zone is not a ResourceQuota limit, but a typo in it must not go unnoticed
+	if _, err := multitenancy.TenantQuotaLocation(quotas); err != nil {
+		return nil, err
+	}
+	hard := corev1.ResourceList{}
 	if quotas.CPULimit != "" {
-		rq.Spec.Hard[corev1.ResourceCPU] = resource.MustParse(quotas.CPULimit)
+		cpu, err := resource.ParseQuantity(quotas.CPULimit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quotas.cpuLimit %q: %w", quotas.CPULimit, err)
+		}
+		hard[corev1.ResourceCPU] = cpu
 	}
 	if quotas.MemoryLimit != "" {
-		rq.Spec.Hard[corev1.ResourceMemory] = resource.MustParse(quotas.MemoryLimit)
+		memory, err := resource.ParseQuantity(quotas.MemoryLimit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quotas.memoryLimit %q: %w", quotas.MemoryLimit, err)
+		}
+		hard[corev1.ResourceMemory] = memory
 	}
 	if quotas.MaxWorkloads > 0 {
-		rq.Spec.Hard[corev1.ResourcePods] = *resource.NewQuantity(int64(quotas.MaxWorkloads), resource.DecimalSI)
-	}
-
-	err := r.Create(ctx, rq)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return err
+		hard[corev1.ResourcePods] = *resource.NewQuantity(int64(quotas.MaxWorkloads), resource.DecimalSI)
 	}
-
-	return nil
+	return hard, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&agenticv1alpha1.Tenant{}).
-		Owns(&corev1.Namespace{}).
-		Owns(&corev1.Secret{}).
-		Owns(&rbacv1.Role{}).
-		Owns(&rbacv1.RoleBinding{}).
-		Owns(&corev1.ResourceQuota{}).
+		// Tenant resources live in another namespace than the Tenant, so they cannot carry
+		// ownerReferences; their events are mapped back to the Tenant instead
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.tenantResourceToTenants)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.tenantResourceToTenants)).
+		Watches(&corev1.ServiceAccount{}, handler.EnqueueRequestsFromMapFunc(r.tenantResourceToTenants)).
+		Watches(&rbacv1.Role{}, handler.EnqueueRequestsFromMapFunc(r.tenantResourceToTenants)).
+		Watches(&rbacv1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(r.tenantResourceToTenants)).
+		Watches(&corev1.ResourceQuota{}, handler.EnqueueRequestsFromMapFunc(r.tenantResourceToTenants)).
+		Watches(&networkingv1.NetworkPolicy{}, handler.EnqueueRequestsFromMapFunc(r.tenantResourceToTenants)).
+		// Workload changes update the usage in the Tenant status
+		Watches(&agenticv1alpha1.AgentWorkload{}, handler.EnqueueRequestsFromMapFunc(r.tenantResourceToTenants)).
 		Named("tenant").
 		Complete(r)
 }
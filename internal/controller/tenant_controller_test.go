@@ -0,0 +1,381 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+func newTestTenant(retention string) *agenticv1alpha1.Tenant {
+	return &agenticv1alpha1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "acme", Namespace: "agentic-system"},
+		Spec: agenticv1alpha1.TenantSpec{
+			DisplayName:     "Acme Corp",
+			Namespace:       "tenant-acme",
+			Providers:       []string{"openai"},
+			Quotas:          agenticv1alpha1.TenantQuotas{CPULimit: "4", MemoryLimit: "8Gi", MaxWorkloads: 10},
+			RetentionPolicy: retention,
+		},
+	}
+}
+
+func newTenantTestReconciler(t *testing.T, objs ...client.Object) *TenantReconciler {
+	t.Helper()
+	scheme := newControllerTestScheme(t)
+	objs = append(objs, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "openai-token", Namespace: "agentic-system"},
+		Data:       map[string][]byte{"api-key": []byte("sk-test")},
+	})
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&agenticv1alpha1.Tenant{}).
+		WithObjects(objs...).
+		Build()
+	return &TenantReconciler{Client: c, Scheme: scheme}
+}
+
+func reconcileTenant(t *testing.T, r *TenantReconciler, key types.NamespacedName) {
+	t.Helper()
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+}
+
+func deleteTenant(t *testing.T, r *TenantReconciler, key types.NamespacedName) {
+	t.Helper()
+	tenant := &agenticv1alpha1.Tenant{}
+	if err := r.Get(context.Background(), key, tenant); err != nil {
+		t.Fatalf("get tenant: %v", err)
+	}
+	if err := r.Delete(context.Background(), tenant); err != nil {
+		t.Fatalf("delete tenant: %v", err)
+	}
+	reconcileTenant(t, r, key)
+	if err := r.Get(context.Background(), key, tenant); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected Tenant gone once cleaned up, got %v (finalizers %v)", err, tenant.Finalizers)
+	}
+}
+
+func assertGone(t *testing.T, r *TenantReconciler, obj client.Object, name, namespace string) {
+	t.Helper()
+	if err := r.Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, obj); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected %T %s deleted, got %v", obj, name, err)
+	}
+}
+
+func TestTenantReconcile_ProvisionsAndAddsFinalizer(t *testing.T) {
+	tenant := newTestTenant("")
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+
+	reconcileTenant(t, r, key)
+
+	updated := &agenticv1alpha1.Tenant{}
+	if err := r.Get(context.Background(), key, updated); err != nil {
+		t.Fatalf("get tenant: %v", err)
+	}
+	if !controllerutil.ContainsFinalizer(updated, TenantCleanupFinalizer) {
+		t.Fatalf("expected cleanup finalizer, got %v", updated.Finalizers)
+	}
+	if updated.Status.Phase != "Active" {
+		t.Fatalf("expected Active, got %q", updated.Status.Phase)
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "openai-token", Namespace: "tenant-acme"}, secret); err != nil {
+		t.Fatalf("expected provider secret copied: %v", err)
+	}
+}
+
+func TestTenantDelete_DeletesManagedNamespace(t *testing.T) {
+	tenant := newTestTenant(TenantRetentionDelete)
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+
+	reconcileTenant(t, r, key)
+	deleteTenant(t, r, key)
+
+	assertGone(t, r, &corev1.Namespace{}, "tenant-acme", "")
+}
+
+func TestTenantDelete_UnmanagedNamespaceKeepsNamespace(t *testing.T) {
+	tenant := newTestTenant(TenantRetentionDelete)
+	existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-acme", Labels: map[string]string{"team": "acme"}}}
+	unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "tenant-acme"}}
+	r := newTenantTestReconciler(t, tenant, existing, unrelated)
+	key := client.ObjectKeyFromObject(tenant)
+
+	reconcileTenant(t, r, key)
+	deleteTenant(t, r, key)
+
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "tenant-acme"}, &corev1.Namespace{}); err != nil {
+		t.Fatalf("expected pre-existing namespace kept: %v", err)
+	}
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(unrelated), &corev1.Secret{}); err != nil {
+		t.Fatalf("expected unrelated secret kept: %v", err)
+	}
+	assertGone(t, r, &corev1.Secret{}, "openai-token", "tenant-acme")
+	assertGone(t, r, &rbacv1.RoleBinding{}, "acme-workload-binding", "tenant-acme")
+	assertGone(t, r, &corev1.ResourceQuota{}, "acme-quota", "tenant-acme")
+}
+
+func TestTenantDelete_RetainKeepsNamespaceAndRevokesAccess(t *testing.T) {
+	tenant := newTestTenant(TenantRetentionRetain)
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+
+	reconcileTenant(t, r, key)
+	deleteTenant(t, r, key)
+
+	ns := &corev1.Namespace{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "tenant-acme"}, ns); err != nil {
+		t.Fatalf("expected namespace retained: %v", err)
+	}
+	if ns.Labels[tenantRetainedLabel] != "true" || ns.Annotations[tenantRetainedAtAnnotation] == "" {
+		t.Fatalf("expected namespace marked retained, got labels %v annotations %v", ns.Labels, ns.Annotations)
+	}
+	assertGone(t, r, &corev1.Secret{}, "openai-token", "tenant-acme")
+	assertGone(t, r, &corev1.ServiceAccount{}, "acme-agent", "tenant-acme")
+	assertGone(t, r, &rbacv1.Role{}, "acme-workload-manager", "tenant-acme")
+	assertGone(t, r, &rbacv1.RoleBinding{}, "acme-workload-binding", "tenant-acme")
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "acme-quota", Namespace: "tenant-acme"}, &corev1.ResourceQuota{}); err != nil {
+		t.Fatalf("expected quota kept on a retained namespace: %v", err)
+	}
+}
+
+func TestTenantDelete_RetainKeepsAccessObjectsItDidNotProvision(t *testing.T) {
+	tenant := newTestTenant(TenantRetentionRetain)
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+	ctx := context.Background()
+
+	reconcileTenant(t, r, key)
+	// Replace the provisioned ServiceAccount and Role with same-named objects of the namespace owner
+	for _, obj := range []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "acme-agent", Namespace: "tenant-acme"}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "acme-workload-manager", Namespace: "tenant-acme"}},
+	} {
+		if err := r.Delete(ctx, obj); err != nil {
+			t.Fatalf("delete %T: %v", obj, err)
+		}
+		obj.SetLabels(map[string]string{"team": "acme"})
+		obj.SetResourceVersion("")
+		if err := r.Create(ctx, obj); err != nil {
+			t.Fatalf("create %T: %v", obj, err)
+		}
+	}
+	deleteTenant(t, r, key)
+
+	if err := r.Get(ctx, types.NamespacedName{Name: "acme-agent", Namespace: "tenant-acme"}, &corev1.ServiceAccount{}); err != nil {
+		t.Fatalf("expected ServiceAccount the operator did not provision kept: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "acme-workload-manager", Namespace: "tenant-acme"}, &rbacv1.Role{}); err != nil {
+		t.Fatalf("expected Role the operator did not provision kept: %v", err)
+	}
+	assertGone(t, r, &rbacv1.RoleBinding{}, "acme-workload-binding", "tenant-acme")
+}
+
+func getTenant(t *testing.T, r *TenantReconciler, key types.NamespacedName) *agenticv1alpha1.Tenant {
+	t.Helper()
+	tenant := &agenticv1alpha1.Tenant{}
+	if err := r.Get(context.Background(), key, tenant); err != nil {
+		t.Fatalf("get tenant: %v", err)
+	}
+	return tenant
+}
+
+func assertTenantCondition(t *testing.T, tenant *agenticv1alpha1.Tenant, conditionType string, status metav1.ConditionStatus, reason string) {
+	t.Helper()
+	condition := meta.FindStatusCondition(tenant.Status.Conditions, conditionType)
+	if condition == nil {
+		t.Fatalf("expected %s condition, got %+v", conditionType, tenant.Status.Conditions)
+	}
+	if condition.Status != status || condition.Reason != reason {
+		t.Fatalf("expected %s=%s (%s), got %s (%s): %s", conditionType, status, reason, condition.Status, condition.Reason, condition.Message)
+	}
+}
+
+func TestTenantReconcile_UpdatesQuotaWhenSpecChanges(t *testing.T) {
+	tenant := newTestTenant("")
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+	ctx := context.Background()
+
+	reconcileTenant(t, r, key)
+	assertTenantCondition(t, getTenant(t, r, key), ConditionTenantQuotaReady, metav1.ConditionTrue, tenantReasonCreated)
+
+	updated := getTenant(t, r, key)
+	updated.Spec.Quotas.CPULimit = "8"
+	updated.Spec.Quotas.MemoryLimit = ""
+	if err := r.Update(ctx, updated); err != nil {
+		t.Fatalf("update tenant: %v", err)
+	}
+	reconcileTenant(t, r, key)
+
+	rq := &corev1.ResourceQuota{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "acme-quota", Namespace: "tenant-acme"}, rq); err != nil {
+		t.Fatalf("get quota: %v", err)
+	}
+	if cpu := rq.Spec.Hard[corev1.ResourceCPU]; cpu.String() != "8" {
+		t.Fatalf("expected cpu limit 8, got %s", cpu.String())
+	}
+	if _, ok := rq.Spec.Hard[corev1.ResourceMemory]; ok {
+		t.Fatalf("expected memory limit removed, got %v", rq.Spec.Hard)
+	}
+	got := getTenant(t, r, key)
+	assertTenantCondition(t, got, ConditionTenantQuotaReady, metav1.ConditionTrue, tenantReasonDriftCorrected)
+	assertTenantCondition(t, got, ConditionTenantRBACReady, metav1.ConditionTrue, tenantReasonInSync)
+
+	reconcileTenant(t, r, key)
+	assertTenantCondition(t, getTenant(t, r, key), ConditionTenantQuotaReady, metav1.ConditionTrue, tenantReasonInSync)
+}
+
+func TestTenantReconcile_RepairsTamperedResources(t *testing.T) {
+	tenant := newTestTenant("")
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+	ctx := context.Background()
+
+	reconcileTenant(t, r, key)
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "openai-token", Namespace: "tenant-acme"}, secret); err != nil {
+		t.Fatalf("get secret: %v", err)
+	}
+	secret.Data = map[string][]byte{"api-key": []byte("tampered")}
+	if err := r.Update(ctx, secret); err != nil {
+		t.Fatalf("update secret: %v", err)
+	}
+	if err := r.Delete(ctx, &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "acme-workload-manager", Namespace: "tenant-acme"}}); err != nil {
+		t.Fatalf("delete role: %v", err)
+	}
+
+	reconcileTenant(t, r, key)
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+		t.Fatalf("get secret: %v", err)
+	}
+	if string(secret.Data["api-key"]) != "sk-test" {
+		t.Fatalf("expected secret data restored, got %q", secret.Data["api-key"])
+	}
+	role := &rbacv1.Role{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "acme-workload-manager", Namespace: "tenant-acme"}, role); err != nil {
+		t.Fatalf("expected role recreated: %v", err)
+	}
+	got := getTenant(t, r, key)
+	assertTenantCondition(t, got, ConditionTenantSecretsReady, metav1.ConditionTrue, tenantReasonDriftCorrected)
+	assertTenantCondition(t, got, ConditionTenantRBACReady, metav1.ConditionTrue, tenantReasonCreated)
+	if !got.Status.SecretsProvisioned || !got.Status.RBACConfigured || got.Status.Phase != "Active" {
+		t.Fatalf("expected tenant Active with resources provisioned, got %+v", got.Status)
+	}
+}
+
+func TestTenantReconcile_RemovesSecretsOfDroppedProviders(t *testing.T) {
+	tenant := newTestTenant("")
+	tenant.Spec.Providers = []string{"openai", "anthropic"}
+	anthropic := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "anthropic-token", Namespace: "agentic-system"},
+		Data:       map[string][]byte{"api-key": []byte("sk-ant")},
+	}
+	r := newTenantTestReconciler(t, tenant, anthropic)
+	key := client.ObjectKeyFromObject(tenant)
+	ctx := context.Background()
+
+	reconcileTenant(t, r, key)
+	if err := r.Get(ctx, types.NamespacedName{Name: "anthropic-token", Namespace: "tenant-acme"}, &corev1.Secret{}); err != nil {
+		t.Fatalf("expected anthropic secret copied: %v", err)
+	}
+
+	updated := getTenant(t, r, key)
+	updated.Spec.Providers = []string{"openai"}
+	if err := r.Update(ctx, updated); err != nil {
+		t.Fatalf("update tenant: %v", err)
+	}
+	reconcileTenant(t, r, key)
+
+	assertGone(t, r, &corev1.Secret{}, "anthropic-token", "tenant-acme")
+	assertTenantCondition(t, getTenant(t, r, key), ConditionTenantSecretsReady, metav1.ConditionTrue, tenantReasonDriftCorrected)
+}
+
+func TestTenantReconcile_ReportsOwnershipConflict(t *testing.T) {
+	tenant := newTestTenant("")
+	foreign := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "acme-quota",
+			Namespace: "tenant-acme",
+			Labels:    map[string]string{tenantLabel: "globex"},
+		},
+	}
+	r := newTenantTestReconciler(t, tenant, foreign)
+	key := client.ObjectKeyFromObject(tenant)
+
+	reconcileTenant(t, r, key)
+
+	got := getTenant(t, r, key)
+	assertTenantCondition(t, got, ConditionTenantQuotaReady, metav1.ConditionFalse, tenantReasonOwnershipConflict)
+	assertTenantCondition(t, got, "Ready", metav1.ConditionFalse, "ResourcesNotReady")
+	if got.Status.QuotasEnforced || !got.Status.RBACConfigured || got.Status.Phase != "Failed" {
+		t.Fatalf("expected quota flag false, RBAC flag true and phase Failed, got %+v", got.Status)
+	}
+	rq := &corev1.ResourceQuota{}
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(foreign), rq); err != nil {
+		t.Fatalf("get quota: %v", err)
+	}
+	if len(rq.Spec.Hard) != 0 || rq.Labels[tenantLabel] != "globex" {
+		t.Fatalf("expected foreign quota untouched, got %+v", rq)
+	}
+}
+
+func TestTenantReconcile_FlagsFollowObservedState(t *testing.T) {
+	tenant := newTestTenant("")
+	tenant.Spec.Quotas.CPULimit = "lots"
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+
+	reconcileTenant(t, r, key)
+
+	got := getTenant(t, r, key)
+	if !got.Status.NamespaceCreated || !got.Status.SecretsProvisioned || got.Status.QuotasEnforced {
+		t.Fatalf("expected only the quota flag unset, got %+v", got.Status)
+	}
+	assertTenantCondition(t, got, ConditionTenantQuotaReady, metav1.ConditionFalse, tenantReasonProvisioningFailed)
+	if got.Status.Phase != "Provisioning" {
+		t.Fatalf("expected phase Provisioning, got %q", got.Status.Phase)
+	}
+}
+
+func TestTenantResourceToTenants(t *testing.T) {
+	tenant := newTestTenant("")
+	r := newTenantTestReconciler(t, tenant)
+	ctx := context.Background()
+
+	cases := map[string]struct {
+		obj  client.Object
+		want int
+	}{
+		"tenant namespace":     {&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-acme"}}, 1},
+		"object in namespace":  {&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "x", Namespace: "tenant-acme"}}, 1},
+		"source secret":        {&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "openai-token", Namespace: "agentic-system"}}, 1},
+		"unused source secret": {&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "gemini-token", Namespace: "agentic-system"}}, 0},
+		"unrelated namespace":  {&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, 0},
+		"object elsewhere":     {&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "x", Namespace: "default"}}, 0},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := r.tenantResourceToTenants(ctx, tc.obj); len(got) != tc.want {
+				t.Fatalf("expected %d requests, got %v", tc.want, got)
+			}
+		})
+	}
+}
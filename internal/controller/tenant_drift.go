@@ -0,0 +1,172 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// Condition types reporting the state of each group of tenant resources
+const (
+	ConditionTenantNamespaceReady = "NamespaceReady"
+	ConditionTenantSecretsReady   = "SecretsReady"
+	ConditionTenantRBACReady      = "RBACReady"
+	ConditionTenantQuotaReady     = "QuotaReady"
+)
+
+// Reasons of the tenant resource conditions
+const (
+	tenantReasonCreated            = "Created"
+	tenantReasonInSync             = "InSync"
+	tenantReasonDriftCorrected     = "DriftCorrected"
+	tenantReasonOwnershipConflict  = "OwnershipConflict"
+	tenantReasonProvisioningFailed = "ProvisioningFailed"
+	tenantReasonNamespaceNotReady  = "NamespaceNotReady"
+	tenantReasonDisabled           = "Disabled"
+)
+
+// errTenantOwnershipConflict is returned when a managed object name is taken by an object
+// that belongs to another tenant
+var errTenantOwnershipConflict = errors.New("belongs to another tenant")
+
+// tenantSync records what reconciling one group of tenant resources changed
+type tenantSync struct {
+	created   []string
+	corrected []string
+}
+
+// record notes the outcome of creating or updating the object kind/name
+func (s *tenantSync) record(kind, name string, op controllerutil.OperationResult) {
+	switch op {
+	case controllerutil.OperationResultCreated:
+		s.created = append(s.created, kind+" "+name)
+	case controllerutil.OperationResultUpdated:
+		s.corrected = append(s.corrected, kind+" "+name)
+	}
+}
+
+// removed notes an object deleted because the spec no longer asks for it
+func (s *tenantSync) removed(kind, name string) {
+	s.corrected = append(s.corrected, "removed "+kind+" "+name)
+}
+
+// condition reports the outcome of a sync as a condition of type conditionType
+func (s *tenantSync) condition(conditionType string, generation int64, err error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+	}
+	switch {
+	case errors.Is(err, errTenantOwnershipConflict):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = tenantReasonOwnershipConflict
+		condition.Message = err.Error()
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = tenantReasonProvisioningFailed
+		condition.Message = err.Error()
+	case len(s.corrected) > 0:
+		condition.Reason = tenantReasonDriftCorrected
+		condition.Message = "Corrected drift: " + strings.Join(s.corrected, ", ")
+	case len(s.created) > 0:
+		condition.Reason = tenantReasonCreated
+		condition.Message = "Created " + strings.Join(s.created, ", ")
+	default:
+		condition.Reason = tenantReasonInSync
+		condition.Message = "Resources match the Tenant spec"
+	}
+	return condition
+}
+
+// applyTenantObject creates obj in the tenant namespace or brings the live object back to
+// the state mutate describes. Managed objects are labelled with the tenant name; an object
+// labelled for another tenant is never taken over.
+func (r *TenantReconciler) applyTenantObject(ctx context.Context, tenant *agenticv1alpha1.Tenant, obj client.Object, mutate func() error) (controllerutil.OperationResult, error) {
+	return controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		labels := obj.GetLabels()
+		if owner := labels[tenantLabel]; owner != "" && owner != tenant.Name {
+			return fmt.Errorf("%s %w %q", obj.GetName(), errTenantOwnershipConflict, owner)
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[tenantLabel] = tenant.Name
+		labels[tenantManagedByLabel] = tenantManagedBy
+		obj.SetLabels(labels)
+		return mutate()
+	})
+}
+
+// tenantResourceToTenants maps events on a tenant namespace, on objects in it and on the
+// provider secrets copied into it to the Tenants concerned
+func (r *TenantReconciler) tenantResourceToTenants(ctx context.Context, obj client.Object) []reconcile.Request {
+	var tenants agenticv1alpha1.TenantList
+	if err := r.List(ctx, &tenants); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list tenants for resource event", "name", obj.GetName())
+		return nil
+	}
+
+	_, isNamespace := obj.(*corev1.Namespace)
+	var requests []reconcile.Request
+	for _, tenant := range tenants.Items {
+		var matches bool
+		switch {
+		case isNamespace:
+			matches = obj.GetName() == tenant.Spec.Namespace
+		case obj.GetNamespace() == tenant.Spec.Namespace:
+			matches = true
+		case obj.GetNamespace() == providerSecretNamespace:
+			matches = tenantUsesSecret(&tenant, obj.GetName())
+		}
+		if matches {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: tenant.Name, Namespace: tenant.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+func tenantUsesSecret(tenant *agenticv1alpha1.Tenant, name string) bool {
+	for _, provider := range tenant.Spec.Providers {
+		if providerSecretName(provider) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setTenantCondition records a condition and returns whether it reports success
+func setTenantCondition(tenant *agenticv1alpha1.Tenant, condition metav1.Condition) bool {
+	meta.SetStatusCondition(&tenant.Status.Conditions, condition)
+	return condition.Status == metav1.ConditionTrue
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/multitenancy"
+)
+
+// TenantCleanupFinalizer holds a Tenant until the resources provisioned for it have been
+// torn down or retained. They live in the tenant namespace, which a namespaced Tenant
+// cannot own through ownerReferences.
+const TenantCleanupFinalizer = "tenant.clawdlinux.io/cleanup"
+
+// Values for spec.retentionPolicy
+const (
+	TenantRetentionDelete = "Delete"
+	TenantRetentionRetain = "Retain"
+)
+
+// Labels and annotations the Tenant controller sets on tenant resources
+const (
+	// tenantLabel is "true" on tenant namespaces and the tenant name on copied secrets
+	tenantLabel          = "agentic-tenant"
+	tenantCustomerLabel  = multitenancy.CustomerLabel
+	tenantManagedByLabel = "managed-by"
+	tenantManagedBy      = "agentic-operator"
+
+	// tenantRetainedLabel marks a namespace kept after its Tenant was deleted
+	tenantRetainedLabel = "agentic-tenant-retained"
+	// tenantRetainedAtAnnotation records when the Tenant of a retained namespace was deleted
+	tenantRetainedAtAnnotation = "tenant.clawdlinux.io/retained-at"
+)
+
+// tenantRetention returns spec.retentionPolicy, defaulting to "Delete"
+func tenantRetention(tenant *agenticv1alpha1.Tenant) string {
+	if tenant.Spec.RetentionPolicy == TenantRetentionRetain {
+		return TenantRetentionRetain
+	}
+	return TenantRetentionDelete
+}
+
+// Names of the per-tenant resources provisioned in the tenant namespace
+func tenantServiceAccountName(tenant *agenticv1alpha1.Tenant) string {
+	return fmt.Sprintf("%s-agent", tenant.Name)
+}
+
+func tenantRoleName(tenant *agenticv1alpha1.Tenant) string {
+	return fmt.Sprintf("%s-workload-manager", tenant.Name)
+}
+
+func tenantRoleBindingName(tenant *agenticv1alpha1.Tenant) string {
+	return fmt.Sprintf("%s-workload-binding", tenant.Name)
+}
+
+func tenantQuotaName(tenant *agenticv1alpha1.Tenant) string {
+	return fmt.Sprintf("%s-quota", tenant.Name)
+}
+
+func tenantNetworkPolicyName(tenant *agenticv1alpha1.Tenant) string {
+	return fmt.Sprintf("%s-isolation", tenant.Name)
+}
+
+func providerSecretName(provider string) string {
+	return fmt.Sprintf("%s-token", provider)
+}
+
+// ensureTenantFinalizer adds the cleanup finalizer to a Tenant
+func (r *TenantReconciler) ensureTenantFinalizer(ctx context.Context, tenant *agenticv1alpha1.Tenant) error {
+	if controllerutil.ContainsFinalizer(tenant, TenantCleanupFinalizer) {
+		return nil
+	}
+
+	// Patch a copy so in-memory status changes are not overwritten by the server response
+	patched := tenant.DeepCopy()
+	controllerutil.AddFinalizer(patched, TenantCleanupFinalizer)
+	if err := r.Patch(ctx, patched, client.MergeFrom(tenant)); err != nil {
+		return err
+	}
+	tenant.ObjectMeta = patched.ObjectMeta
+	return nil
+}
+
+// reconcileDelete tears down or retains the tenant's resources, according to
+// spec.retentionPolicy, then releases the finalizer
+func (r *TenantReconciler) reconcileDelete(ctx context.Context, tenant *agenticv1alpha1.Tenant) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(tenant, TenantCleanupFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if tenant.Status.Phase != "Terminating" {
+		tenant.Status.Phase = "Terminating"
+		if err := r.Status().Update(ctx, tenant); err != nil {
+			log.Error(err, "failed to update status")
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
+	retention := tenantRetention(tenant)
+	var err error
+	if retention == TenantRetentionRetain {
+		err = r.retainTenantNamespace(ctx, tenant)
+	} else {
+		err = r.deleteTenantResources(ctx, tenant)
+	}
+	if err != nil {
+		log.Error(err, "failed to clean up tenant resources", "retention", retention)
+		return ctrl.Result{}, err
+	}
+	log.Info("Cleaned up tenant resources", "namespace", tenant.Spec.Namespace, "retention", retention)
+
+	before := tenant.DeepCopy()
+	controllerutil.RemoveFinalizer(tenant, TenantCleanupFinalizer)
+	if err := r.Patch(ctx, tenant, client.MergeFrom(before)); err != nil {
+		log.Error(err, "failed to remove finalizer")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// deleteTenantResources deletes the tenant namespace when the operator created it for this
+// tenant; in a namespace it does not own, only the resources it provisioned are deleted
+func (r *TenantReconciler) deleteTenantResources(ctx context.Context, tenant *agenticv1alpha1.Tenant) error {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: tenant.Spec.Namespace}, ns); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if tenantOwnsNamespace(tenant, ns) {
+		return client.IgnoreNotFound(r.Delete(ctx, ns))
+	}
+
+	if err := r.revokeTenantAccess(ctx, tenant); err != nil {
+		return err
+	}
+	quota := &corev1.ResourceQuota{ObjectMeta: tenantObjectMeta(tenant, tenantQuotaName(tenant))}
+	if err := r.deleteProvisioned(ctx, tenant, []client.Object{quota}); err != nil {
+		return err
+	}
+	_, err := r.deleteTenantNetworkPolicies(ctx, tenant, nil)
+	return err
+}
+
+// retainTenantNamespace keeps the namespace and its data but revokes the tenant's access,
+// and marks the namespace as retained
+func (r *TenantReconciler) retainTenantNamespace(ctx context.Context, tenant *agenticv1alpha1.Tenant) error {
+	if err := r.revokeTenantAccess(ctx, tenant); err != nil {
+		return err
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: tenant.Spec.Namespace}, ns); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if ns.Labels[tenantRetainedLabel] == "true" {
+		return nil
+	}
+	before := ns.DeepCopy()
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+	ns.Labels[tenantRetainedLabel] = "true"
+	ns.Annotations[tenantRetainedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	return r.Patch(ctx, ns, client.MergeFrom(before))
+}
+
+// revokeTenantAccess deletes the copied provider secrets and the agent ServiceAccount,
+// Role and RoleBinding provisioned for the tenant
+func (r *TenantReconciler) revokeTenantAccess(ctx context.Context, tenant *agenticv1alpha1.Tenant) error {
+	objects := []client.Object{
+		&rbacv1.RoleBinding{ObjectMeta: tenantObjectMeta(tenant, tenantRoleBindingName(tenant))},
+		&rbacv1.Role{ObjectMeta: tenantObjectMeta(tenant, tenantRoleName(tenant))},
+		&corev1.ServiceAccount{ObjectMeta: tenantObjectMeta(tenant, tenantServiceAccountName(tenant))},
+	}
+	if err := r.deleteProvisioned(ctx, tenant, objects); err != nil {
+		return err
+	}
+
+	// Only secrets copied for this tenant are deleted, never same-named secrets it did not create
+	var secrets corev1.SecretList
+	if err := r.List(ctx, &secrets, client.InNamespace(tenant.Spec.Namespace),
+		client.MatchingLabels{tenantLabel: tenant.Name}); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		if err := r.Delete(ctx, &secrets.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete secret %s: %w", secrets.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// deleteProvisioned deletes the objects the operator provisioned for this tenant. Objects
+// are looked up by name and deleted only when labelled for the tenant, never same-named
+// objects it did not create.
+func (r *TenantReconciler) deleteProvisioned(ctx context.Context, tenant *agenticv1alpha1.Tenant, objects []client.Object) error {
+	for _, obj := range objects {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to get %T %s: %w", obj, obj.GetName(), err)
+			}
+			continue
+		}
+		labels := obj.GetLabels()
+		if labels[tenantLabel] != tenant.Name || labels[tenantManagedByLabel] != tenantManagedBy {
+			logf.FromContext(ctx).Info("Keeping object the operator did not provision for the tenant",
+				"kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+			continue
+		}
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %T %s: %w", obj, obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// tenantOwnsNamespace reports whether the operator created the namespace for this tenant
+func tenantOwnsNamespace(tenant *agenticv1alpha1.Tenant, ns *corev1.Namespace) bool {
+	return ns.Labels[tenantManagedByLabel] == tenantManagedBy && ns.Labels[tenantCustomerLabel] == tenant.Name
+}
+
+func tenantObjectMeta(tenant *agenticv1alpha1.Tenant, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: tenant.Spec.Namespace}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// ConditionTenantNetworkPolicyReady reports the state of the tenant network policies
+const ConditionTenantNetworkPolicyReady = "NetworkPolicyReady"
+
+// Destinations the tenant network policies allow
+const (
+	sharedServicesNamespace = "shared-services"
+	dnsNamespace            = "kube-system"
+	defaultProviderPort     = 443
+)
+
+// sharedServices are the app.kubernetes.io/name of the shared-services workloads tenants use
+var sharedServices = []string{"litellm", "minio", "postgres", "browserless"}
+
+// privateCIDRs are excluded from the default provider egress, which allows public HTTPS only
+var privateCIDRs = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "169.254.0.0/16"}
+
+// enforceNetworkPolicy keeps the tenant network policies in line with spec.networkPolicy:
+// a default-deny policy for the namespace, plus policies allowing traffic inside the
+// namespace and from the operator, DNS, the shared services and the provider endpoints.
+// Policies are removed when isolation is switched off.
+func (r *TenantReconciler) enforceNetworkPolicy(ctx context.Context, tenant *agenticv1alpha1.Tenant, sync *tenantSync) error {
+	desired := map[string]networkingv1.NetworkPolicySpec{}
+	if tenant.Spec.NetworkPolicy {
+		providerRules, err := tenantProviderEgress(tenant)
+		if err != nil {
+			return err
+		}
+		desired[tenantNetworkPolicyName(tenant)] = networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		}
+		desired[tenant.Name+"-allow-tenant"] = networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{
+					{PodSelector: &metav1.LabelSelector{}},
+					// The operator probes the tenant's agents
+					{NamespaceSelector: namespaceNameSelector(providerSecretNamespace)},
+				},
+			}},
+			Egress: []networkingv1.NetworkPolicyEgressRule{{
+				To: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+			}},
+		}
+		desired[tenant.Name+"-allow-dns"] = networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress: []networkingv1.NetworkPolicyEgressRule{{
+				To: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: namespaceNameSelector(dnsNamespace),
+					PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": "kube-dns"}},
+				}},
+				Ports: []networkingv1.NetworkPolicyPort{
+					networkPolicyPort(corev1.ProtocolUDP, 53),
+					networkPolicyPort(corev1.ProtocolTCP, 53),
+				},
+			}},
+		}
+		desired[tenant.Name+"-allow-shared-services"] = networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress: []networkingv1.NetworkPolicyEgressRule{{
+				To: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: namespaceNameSelector(sharedServicesNamespace),
+					PodSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      "app.kubernetes.io/name",
+							Operator: metav1.LabelSelectorOpIn,
+							Values:   sharedServices,
+						}},
+					},
+				}},
+			}},
+		}
+		desired[tenant.Name+"-allow-providers"] = networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      providerRules,
+		}
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		spec := desired[name]
+		policy := &networkingv1.NetworkPolicy{ObjectMeta: tenantObjectMeta(tenant, name)}
+		op, err := r.applyTenantObject(ctx, tenant, policy, func() error {
+			// Every policy applies to all pods in the namespace
+			spec.PodSelector = metav1.LabelSelector{}
+			policy.Spec = spec
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply network policy %s: %w", name, err)
+		}
+		sync.record("NetworkPolicy", name, op)
+	}
+
+	removed, err := r.deleteTenantNetworkPolicies(ctx, tenant, desired)
+	for _, name := range removed {
+		sync.removed("NetworkPolicy", name)
+	}
+	return err
+}
+
+// deleteTenantNetworkPolicies deletes the network policies provisioned for the tenant that
+// are not in keep, and returns their names
+func (r *TenantReconciler) deleteTenantNetworkPolicies(ctx context.Context, tenant *agenticv1alpha1.Tenant, keep map[string]networkingv1.NetworkPolicySpec) ([]string, error) {
+	var policies networkingv1.NetworkPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(tenant.Spec.Namespace),
+		client.MatchingLabels{tenantLabel: tenant.Name}); err != nil {
+		return nil, err
+	}
+	var removed []string
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if _, ok := keep[policy.Name]; ok {
+			continue
+		}
+		if err := r.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+			return removed, fmt.Errorf("failed to delete network policy %s: %w", policy.Name, err)
+		}
+		removed = append(removed, policy.Name)
+	}
+	return removed, nil
+}
+
+// tenantProviderEgress returns the egress rules for spec.providerEgress, or public HTTPS
+// when none is configured
+func tenantProviderEgress(tenant *agenticv1alpha1.Tenant) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	if len(tenant.Spec.ProviderEgress) == 0 {
+		return []networkingv1.NetworkPolicyEgressRule{{
+			To: []networkingv1.NetworkPolicyPeer{{
+				IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0", Except: privateCIDRs},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{networkPolicyPort(corev1.ProtocolTCP, defaultProviderPort)},
+		}}, nil
+	}
+
+	rules := make([]networkingv1.NetworkPolicyEgressRule, 0, len(tenant.Spec.ProviderEgress))
+	for _, egress := range tenant.Spec.ProviderEgress {
+		if _, _, err := net.ParseCIDR(egress.CIDR); err != nil {
+			return nil, fmt.Errorf("invalid providerEgress cidr %q: %w", egress.CIDR, err)
+		}
+		ports := egress.Ports
+		if len(ports) == 0 {
+			ports = []int32{defaultProviderPort}
+		}
+		rule := networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: egress.CIDR}}},
+		}
+		for _, port := range ports {
+			rule.Ports = append(rule.Ports, networkPolicyPort(corev1.ProtocolTCP, port))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func namespaceNameSelector(namespace string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: namespace}}
+}
+
+func networkPolicyPort(protocol corev1.Protocol, port int32) networkingv1.NetworkPolicyPort {
+	p := intstr.FromInt32(port)
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &p}
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+func listTenantPolicies(t *testing.T, r *TenantReconciler) map[string]networkingv1.NetworkPolicy {
+	t.Helper()
+	var policies networkingv1.NetworkPolicyList
+	if err := r.List(context.Background(), &policies, client.InNamespace("tenant-acme")); err != nil {
+		t.Fatalf("list network policies: %v", err)
+	}
+	byName := map[string]networkingv1.NetworkPolicy{}
+	for _, policy := range policies.Items {
+		byName[policy.Name] = policy
+	}
+	return byName
+}
+
+func TestTenantNetworkPolicy_DefaultDenyWithAllowRules(t *testing.T) {
+	tenant := newTestTenant("")
+	tenant.Spec.NetworkPolicy = true
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+
+	reconcileTenant(t, r, key)
+
+	policies := listTenantPolicies(t, r)
+	for _, name := range []string{"acme-isolation", "acme-allow-tenant", "acme-allow-dns", "acme-allow-shared-services", "acme-allow-providers"} {
+		if _, ok := policies[name]; !ok {
+			t.Fatalf("expected network policy %s, got %v", name, policies)
+		}
+	}
+	deny := policies["acme-isolation"]
+	if len(deny.Spec.PolicyTypes) != 2 || len(deny.Spec.Ingress) != 0 || len(deny.Spec.Egress) != 0 {
+		t.Fatalf("expected default-deny ingress and egress, got %+v", deny.Spec)
+	}
+	dns := policies["acme-allow-dns"].Spec.Egress[0]
+	if len(dns.Ports) != 2 || dns.Ports[0].Port.IntValue() != 53 {
+		t.Fatalf("expected DNS egress on port 53, got %+v", dns)
+	}
+	shared := policies["acme-allow-shared-services"].Spec.Egress[0].To[0]
+	if shared.NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"] != "shared-services" {
+		t.Fatalf("expected shared-services namespace selector, got %+v", shared)
+	}
+	providers := policies["acme-allow-providers"].Spec.Egress[0]
+	if providers.To[0].IPBlock == nil || providers.To[0].IPBlock.CIDR != "0.0.0.0/0" || len(providers.To[0].IPBlock.Except) == 0 {
+		t.Fatalf("expected public HTTPS egress by default, got %+v", providers)
+	}
+
+	got := getTenant(t, r, key)
+	if !got.Status.NetworkPolicyActive {
+		t.Fatalf("expected networkPolicyActive, got %+v", got.Status)
+	}
+	assertTenantCondition(t, got, ConditionTenantNetworkPolicyReady, metav1.ConditionTrue, tenantReasonCreated)
+}
+
+func TestTenantNetworkPolicy_ProviderEgress(t *testing.T) {
+	tenant := newTestTenant("")
+	tenant.Spec.NetworkPolicy = true
+	tenant.Spec.ProviderEgress = []agenticv1alpha1.TenantEgress{
+		{CIDR: "203.0.113.0/24"},
+		{CIDR: "198.51.100.7/32", Ports: []int32{8443, 9443}},
+	}
+	r := newTenantTestReconciler(t, tenant)
+
+	reconcileTenant(t, r, client.ObjectKeyFromObject(tenant))
+
+	egress := listTenantPolicies(t, r)["acme-allow-providers"].Spec.Egress
+	if len(egress) != 2 {
+		t.Fatalf("expected one rule per provider endpoint, got %+v", egress)
+	}
+	if egress[0].To[0].IPBlock.CIDR != "203.0.113.0/24" || egress[0].Ports[0].Port.IntValue() != 443 {
+		t.Fatalf("expected 203.0.113.0/24:443, got %+v", egress[0])
+	}
+	if egress[1].To[0].IPBlock.CIDR != "198.51.100.7/32" || len(egress[1].Ports) != 2 || egress[1].Ports[1].Port.IntValue() != 9443 {
+		t.Fatalf("expected 198.51.100.7/32:8443,9443, got %+v", egress[1])
+	}
+}
+
+func TestTenantNetworkPolicy_RepairsAndRemovesPolicies(t *testing.T) {
+	tenant := newTestTenant("")
+	tenant.Spec.NetworkPolicy = true
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+	ctx := context.Background()
+
+	reconcileTenant(t, r, key)
+
+	// Loosen the default-deny policy by hand
+	deny := &networkingv1.NetworkPolicy{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "acme-isolation", Namespace: "tenant-acme"}, deny); err != nil {
+		t.Fatalf("get policy: %v", err)
+	}
+	deny.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{}}
+	if err := r.Update(ctx, deny); err != nil {
+		t.Fatalf("update policy: %v", err)
+	}
+	reconcileTenant(t, r, key)
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deny), deny); err != nil {
+		t.Fatalf("get policy: %v", err)
+	}
+	if len(deny.Spec.Ingress) != 0 {
+		t.Fatalf("expected tampered ingress rule removed, got %+v", deny.Spec.Ingress)
+	}
+	assertTenantCondition(t, getTenant(t, r, key), ConditionTenantNetworkPolicyReady, metav1.ConditionTrue, tenantReasonDriftCorrected)
+
+	// Switching isolation off removes every tenant policy
+	updated := getTenant(t, r, key)
+	updated.Spec.NetworkPolicy = false
+	if err := r.Update(ctx, updated); err != nil {
+		t.Fatalf("update tenant: %v", err)
+	}
+	reconcileTenant(t, r, key)
+
+	if policies := listTenantPolicies(t, r); len(policies) != 0 {
+		t.Fatalf("expected network policies removed, got %v", policies)
+	}
+	got := getTenant(t, r, key)
+	if got.Status.NetworkPolicyActive {
+		t.Fatalf("expected networkPolicyActive false, got %+v", got.Status)
+	}
+
+	reconcileTenant(t, r, key)
+	assertTenantCondition(t, getTenant(t, r, key), ConditionTenantNetworkPolicyReady, metav1.ConditionTrue, tenantReasonDisabled)
+}
+
+func TestTenantNetworkPolicy_InvalidCIDR(t *testing.T) {
+	tenant := newTestTenant("")
+	tenant.Spec.NetworkPolicy = true
+	tenant.Spec.ProviderEgress = []agenticv1alpha1.TenantEgress{{CIDR: "api.openai.com"}}
+	r := newTenantTestReconciler(t, tenant)
+	key := client.ObjectKeyFromObject(tenant)
+
+	reconcileTenant(t, r, key)
+
+	got := getTenant(t, r, key)
+	assertTenantCondition(t, got, ConditionTenantNetworkPolicyReady, metav1.ConditionFalse, tenantReasonProvisioningFailed)
+	if got.Status.NetworkPolicyActive || got.Status.Phase != "Provisioning" {
+		t.Fatalf("expected isolation inactive and tenant Provisioning, got %+v", got.Status)
+	}
+}
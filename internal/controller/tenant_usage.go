@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/multitenancy"
+)
+
+// Conditions reporting tenant usage against spec.quotas
+const (
+	ConditionTenantNearQuota     = "NearQuota"
+	ConditionTenantQuotaExceeded = "QuotaExceeded"
+)
+
+// tenantNearQuotaRatio is the share of a quota from which a tenant is NearQuota
+const tenantNearQuotaRatio = 0.8
+
+// aggregateTenantUsage counts the AgentWorkloads in the tenant namespace by phase and sums
+// their cost today into the Tenant status. Tokens this month only grow: they are the
+// highest of the usage already reported for the month, the quota manager's ledger and
+// the tokens of the live workloads, so deleting or re-running a workload never lowers them.
+func (r *TenantReconciler) aggregateTenantUsage(ctx context.Context, tenant *agenticv1alpha1.Tenant, now time.Time) error {
+	var workloads agenticv1alpha1.AgentWorkloadList
+	if err := r.List(ctx, &workloads, client.InNamespace(tenant.Spec.Namespace)); err != nil {
+		return fmt.Errorf("failed to list workloads: %w", err)
+	}
+
+	loc := r.quotaLocation(tenant)
+	byPhase := map[string]int{}
+	active := 0
+	var tokens int64
+	var cost float64
+	for i := range workloads.Items {
+		workload := &workloads.Items[i]
+		phase := workload.Status.Phase
+		if phase == "" {
+			phase = "Pending"
+		}
+		byPhase[phase]++
+		if phase != "Completed" && phase != "Failed" {
+			active++
+		}
+
+		if usage := workload.Status.TokensUsed; usage != nil && usage.RecordedAt != nil && sameMonth(usage.RecordedAt.Time, now, loc) {
+			tokens += usage.InputTokens + usage.OutputTokens
+		}
+
+		if r.CostReporter != nil {
+			workloadCost, err := r.CostReporter.WorkloadCostToday(ctx, workload.Name, workload.Namespace)
+			if err != nil {
+				logf.FromContext(ctx).Error(err, "failed to read workload cost", "workload", workload.Name)
+				continue
+			}
+			cost += workloadCost
+		}
+	}
+
+	if len(byPhase) == 0 {
+		byPhase = nil
+	}
+	tenant.Status.WorkloadsByPhase = byPhase
+	tenant.Status.WorkloadCount = active
+	windowStart := monthStart(now, loc)
+	if reported := tenant.Status.TokensMonthStart; reported != nil && !reported.Time.Before(windowStart) {
+		tokens = max(tokens, tenant.Status.TokensUsedThisMonth)
+	}
+	if r.QuotaMgr != nil {
+		if status, err := r.QuotaMgr.GetStatus(tenant.Name); err == nil {
+			tokens = max(tokens, status.TokensThisMonth)
+		}
+	}
+	tenant.Status.TokensUsedThisMonth = tokens
+	tenant.Status.TokensMonthStart = &metav1.Time{Time: windowStart}
+	tenant.Status.CostTodayUSD = cost
+	setTenantQuotaConditions(tenant)
+	return nil
+}
+
+// setTenantQuotaConditions compares the tenant's usage with spec.quotas and sets the
+// NearQuota and QuotaExceeded conditions
+func setTenantQuotaConditions(tenant *agenticv1alpha1.Tenant) {
+	quotas := tenant.Spec.Quotas
+	usages := []struct {
+		name  string
+		used  int64
+		limit int64
+	}{
+		{"workloads", int64(tenant.Status.WorkloadCount), int64(quotas.MaxWorkloads)},
+		{"concurrent executions", int64(tenant.Status.WorkloadsByPhase["Running"]), int64(quotas.MaxConcurrent)},
+		{"monthly tokens", tenant.Status.TokensUsedThisMonth, quotas.MaxMonthlyTokens},
+	}
+
+	var near, exceeded []string
+	for _, usage := range usages {
+		if usage.limit <= 0 {
+			continue
+		}
+		summary := fmt.Sprintf("%s %d/%d", usage.name, usage.used, usage.limit)
+		switch {
+		case usage.used >= usage.limit:
+			exceeded = append(exceeded, summary)
+		case float64(usage.used) >= tenantNearQuotaRatio*float64(usage.limit):
+			near = append(near, summary)
+		}
+	}
+
+	exceededCondition := metav1.Condition{
+		Type:               ConditionTenantQuotaExceeded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: tenant.Generation,
+		Reason:             "WithinQuota",
+		Message:            "Usage is within the tenant quotas",
+	}
+	if len(exceeded) > 0 {
+		exceededCondition.Status = metav1.ConditionTrue
+		exceededCondition.Reason = "QuotaReached"
+		exceededCondition.Message = "Quota reached: " + strings.Join(exceeded, ", ")
+	}
+	meta.SetStatusCondition(&tenant.Status.Conditions, exceededCondition)
+
+	nearCondition := metav1.Condition{
+		Type:               ConditionTenantNearQuota,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: tenant.Generation,
+		Reason:             "WithinQuota",
+		Message:            fmt.Sprintf("Usage is below %.0f%% of the tenant quotas", tenantNearQuotaRatio*100),
+	}
+	// A reached quota is also near
+	if near = append(near, exceeded...); len(near) > 0 {
+		nearCondition.Status = metav1.ConditionTrue
+		nearCondition.Reason = "QuotaNearlyReached"
+		nearCondition.Message = fmt.Sprintf("Usage at or above %.0f%% of quota: %s", tenantNearQuotaRatio*100, strings.Join(near, ", "))
+	}
+	meta.SetStatusCondition(&tenant.Status.Conditions, nearCondition)
+}
+
+// quotaLocation returns the time zone of the tenant's monthly usage window
+func (r *TenantReconciler) quotaLocation(tenant *agenticv1alpha1.Tenant) *time.Location {
+	if loc, err := multitenancy.TenantQuotaLocation(tenant.Spec.Quotas); err == nil && loc != nil {
+		return loc
+	}
+	if r.QuotaLocation != nil {
+		return r.QuotaLocation
+	}
+	return time.UTC
+}
+
+// sameMonth reports whether t falls in the same calendar month as now in loc
+func sameMonth(t, now time.Time, loc *time.Location) bool {
+	t, now = t.In(loc), now.In(loc)
+	return t.Year() == now.Year() && t.Month() == now.Month()
+}
+
+// monthStart returns the start of the calendar month of t in loc
+func monthStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/multitenancy"
+)
+
+func newUsageWorkload(name, phase string, tokens int64, recordedAt time.Time) *agenticv1alpha1.AgentWorkload {
+	workload := &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant-acme"},
+		Status:     agenticv1alpha1.AgentWorkloadStatus{Phase: phase},
+	}
+	if tokens > 0 {
+		workload.Status.TokensUsed = &agenticv1alpha1.TokenUsage{
+			InputTokens:  tokens / 2,
+			OutputTokens: tokens - tokens/2,
+			RecordedAt:   &metav1.Time{Time: recordedAt},
+		}
+	}
+	return workload
+}
+
+func TestTenantUsage_AggregatesWorkloads(t *testing.T) {
+	now := time.Now()
+	tenant := newTestTenant("")
+	tenant.Spec.Quotas.MaxMonthlyTokens = 10000
+	r := newTenantTestReconciler(t, tenant,
+		newUsageWorkload("done", "Completed", 1000, now),
+		newUsageWorkload("failed", "Failed", 500, now),
+		newUsageWorkload("running", "Running", 0, now),
+		newUsageWorkload("new", "", 0, now),
+		newUsageWorkload("last-month", "Completed", 7000, now.AddDate(0, -1, 0)),
+		&agenticv1alpha1.AgentWorkload{ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "default"}},
+	)
+	r.CostReporter = &stubCostReporter{costToday: 0.5}
+	key := client.ObjectKeyFromObject(tenant)
+
+	reconcileTenant(t, r, key)
+
+	got := getTenant(t, r, key)
+	if got.Status.WorkloadCount != 2 {
+		t.Fatalf("expected 2 active workloads, got %d", got.Status.WorkloadCount)
+	}
+	want := map[string]int{"Completed": 2, "Failed": 1, "Running": 1, "Pending": 1}
+	for phase, count := range want {
+		if got.Status.WorkloadsByPhase[phase] != count {
+			t.Fatalf("expected %d %s workloads, got %v", count, phase, got.Status.WorkloadsByPhase)
+		}
+	}
+	if got.Status.TokensUsedThisMonth != 1500 {
+		t.Fatalf("expected 1500 tokens this month, got %d", got.Status.TokensUsedThisMonth)
+	}
+	if got.Status.CostTodayUSD != 2.5 {
+		t.Fatalf("expected $2.50 across 5 workloads, got %v", got.Status.CostTodayUSD)
+	}
+	assertTenantCondition(t, got, ConditionTenantNearQuota, metav1.ConditionFalse, "WithinQuota")
+	assertTenantCondition(t, got, ConditionTenantQuotaExceeded, metav1.ConditionFalse, "WithinQuota")
+}
+
+func TestTenantUsage_TokensOnlyGrowWithinTheMonth(t *testing.T) {
+	now := time.Now()
+	tenant := newTestTenant("")
+	done := newUsageWorkload("done", "Completed", 1000, now)
+	r := newTenantTestReconciler(t, tenant, done)
+	r.QuotaMgr = multitenancy.NewQuotaManager([]*multitenancy.TenantContext{{Name: "acme", Namespace: "tenant-acme", IsActive: true}})
+	key := client.ObjectKeyFromObject(tenant)
+	ctx := context.Background()
+
+	reconcileTenant(t, r, key)
+	if got := getTenant(t, r, key); got.Status.TokensUsedThisMonth != 1000 || got.Status.TokensMonthStart == nil {
+		t.Fatalf("expected 1000 tokens in the current window, got %d since %v", got.Status.TokensUsedThisMonth, got.Status.TokensMonthStart)
+	}
+
+	// A deleted workload keeps counting
+	if err := r.Delete(ctx, done); err != nil {
+		t.Fatalf("delete workload: %v", err)
+	}
+	reconcileTenant(t, r, key)
+	if got := getTenant(t, r, key); got.Status.TokensUsedThisMonth != 1000 {
+		t.Fatalf("expected usage kept after the workload was deleted, got %d", got.Status.TokensUsedThisMonth)
+	}
+
+	// The ledger counts calls of workloads that no longer exist
+	if err := r.QuotaMgr.RecordTokens("acme", 1800); err != nil {
+		t.Fatalf("RecordTokens failed: %v", err)
+	}
+	reconcileTenant(t, r, key)
+	if got := getTenant(t, r, key); got.Status.TokensUsedThisMonth != 1800 {
+		t.Fatalf("expected usage to follow the quota ledger, got %d", got.Status.TokensUsedThisMonth)
+	}
+
+	// Usage reported for an earlier month starts over
+	got := getTenant(t, r, key)
+	got.Status.TokensMonthStart = &metav1.Time{Time: now.AddDate(0, -2, 0)}
+	got.Status.TokensUsedThisMonth = 9000
+	if err := r.Status().Update(ctx, got); err != nil {
+		t.Fatalf("update tenant status: %v", err)
+	}
+	r.QuotaMgr = nil
+	reconcileTenant(t, r, key)
+	if got := getTenant(t, r, key); got.Status.TokensUsedThisMonth != 0 {
+		t.Fatalf("expected last month's usage dropped, got %d", got.Status.TokensUsedThisMonth)
+	}
+}
+
+func TestTenantUsage_QuotaConditions(t *testing.T) {
+	cases := map[string]struct {
+		quotas        agenticv1alpha1.TenantQuotas
+		status        agenticv1alpha1.TenantStatus
+		near, reached metav1.ConditionStatus
+	}{
+		"no limits": {
+			status: agenticv1alpha1.TenantStatus{WorkloadCount: 50, TokensUsedThisMonth: 1e9},
+			near:   metav1.ConditionFalse, reached: metav1.ConditionFalse,
+		},
+		"tokens near": {
+			quotas: agenticv1alpha1.TenantQuotas{MaxMonthlyTokens: 1000},
+			status: agenticv1alpha1.TenantStatus{TokensUsedThisMonth: 800},
+			near:   metav1.ConditionTrue, reached: metav1.ConditionFalse,
+		},
+		"workloads reached": {
+			quotas: agenticv1alpha1.TenantQuotas{MaxWorkloads: 4, MaxMonthlyTokens: 1000},
+			status: agenticv1alpha1.TenantStatus{WorkloadCount: 4, TokensUsedThisMonth: 10},
+			near:   metav1.ConditionTrue, reached: metav1.ConditionTrue,
+		},
+		"concurrency reached": {
+			quotas: agenticv1alpha1.TenantQuotas{MaxConcurrent: 2},
+			status: agenticv1alpha1.TenantStatus{WorkloadsByPhase: map[string]int{"Running": 2}},
+			near:   metav1.ConditionTrue, reached: metav1.ConditionTrue,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tenant := &agenticv1alpha1.Tenant{Spec: agenticv1alpha1.TenantSpec{Quotas: tc.quotas}, Status: tc.status}
+			setTenantQuotaConditions(tenant)
+			for conditionType, want := range map[string]metav1.ConditionStatus{
+				ConditionTenantNearQuota:     tc.near,
+				ConditionTenantQuotaExceeded: tc.reached,
+			} {
+				var condition *metav1.Condition
+				for i := range tenant.Status.Conditions {
+					if tenant.Status.Conditions[i].Type == conditionType {
+						condition = &tenant.Status.Conditions[i]
+					}
+				}
+				if condition == nil || condition.Status != want {
+					t.Fatalf("expected %s=%s, got %+v", conditionType, want, condition)
+				}
+			}
+		})
+	}
+}
+
+func TestTenantResourceToTenants_Workload(t *testing.T) {
+	tenant := newTestTenant("")
+	r := newTenantTestReconciler(t, tenant)
+
+	requests := r.tenantResourceToTenants(context.Background(), newUsageWorkload("w", "Running", 0, time.Now()))
+	if len(requests) != 1 || requests[0].Name != "acme" {
+		t.Fatalf("expected workload events to reach the tenant, got %v", requests)
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package a2a is a client for the agent-to-agent (A2A) task API served by agents/a2a.
+package a2a
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// Task statuses reported by the A2A server
+const (
+	TaskCreated   = "CREATED"
+	TaskQueued    = "QUEUED"
+	TaskAssigned  = "ASSIGNED"
+	TaskRunning   = "RUNNING"
+	TaskCompleted = "COMPLETED"
+	TaskFailed    = "FAILED"
+	TaskTimedOut  = "TIMED_OUT"
+)
+
+// Defaults applied when an AgentCard leaves its endpoint fields unset
+const (
+	DefaultPort     = 8080
+	DefaultBasePath = "/a2a"
+	DefaultCardPath = "/.well-known/agent.json"
+)
+
+// legacyCardPath is where agents/a2a servers predating the well-known path publish their
+// card, relative to the A2A base path
+const legacyCardPath = "/agent-card"
+
+// Client creates and tracks tasks on agents' A2A servers
+type Client struct {
+	client *http.Client
+}
+
+// CreateTaskRequest is the payload of POST {basePath}/tasks
+type CreateTaskRequest struct {
+	Skill          string                 `json:"skill"`
+	InputData      map[string]interface{} `json:"input_data"`
+	SenderAgent    string                 `json:"sender_agent"`
+	TimeoutSeconds int                    `json:"timeout_seconds,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Task is an A2A task as returned by the A2A server
+type Task struct {
+	ID             string                 `json:"id"`
+	Skill          string                 `json:"skill"`
+	Status         string                 `json:"status"`
+	SenderAgent    string                 `json:"sender_agent"`
+	RecipientAgent string                 `json:"recipient_agent"`
+	OutputData     map[string]interface{} `json:"output_data,omitempty"`
+	Error          string                 `json:"error,omitempty"`
+	CompletedAt    *time.Time             `json:"completed_at,omitempty"`
+}
+
+// PublishedCard is the agent card an agent publishes about itself
+type PublishedCard struct {
+	Name    string           `json:"name"`
+	Version string           `json:"version,omitempty"`
+	Skills  []PublishedSkill `json:"skills"`
+}
+
+// PublishedSkill is a skill listed in a published agent card. Skills that only carry an
+// "id" (as in the A2A specification) are named by it.
+type PublishedSkill struct {
+	ID           string          `json:"id,omitempty"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	Version      string          `json:"version,omitempty"`
+	InputSchema  json.RawMessage `json:"inputSchema,omitempty"`
+	OutputSchema json.RawMessage `json:"outputSchema,omitempty"`
+}
+
+// SkillName returns the skill's name, falling back to its id
+func (s PublishedSkill) SkillName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.ID
+}
+
+// Done reports whether the task reached a terminal status
+func (t *Task) Done() bool {
+	return t.Status == TaskCompleted || t.Status == TaskFailed || t.Status == TaskTimedOut
+}
+
+// NewClient creates a new A2A client; a nil httpClient uses a client with a 10s timeout
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{client: httpClient}
+}
+
+// BaseURL returns the A2A base URL advertised by an AgentCard (e.g. http://scraper:8080/a2a)
+func BaseURL(card *agenticv1alpha1.AgentCard) string {
+	basePath := DefaultBasePath
+	if card.Spec.Endpoint.BasePath != nil {
+		basePath = *card.Spec.Endpoint.BasePath
+	}
+	return rootURL(card) + strings.TrimSuffix(basePath, "/")
+}
+
+// UsesTLS reports whether an agent is reached over HTTPS: it configures endpoint.tls or
+// requires mTLS
+func UsesTLS(card *agenticv1alpha1.AgentCard) bool {
+	if card.Spec.Endpoint.TLS != nil {
+		return true
+	}
+	auth := card.Spec.Auth
+	return auth != nil && auth.Type != nil && *auth.Type == "mtls"
+}
+
+func rootURL(card *agenticv1alpha1.AgentCard) string {
+	scheme := "http"
+	if UsesTLS(card) {
+		scheme = "https"
+	}
+	port := int32(DefaultPort)
+	if card.Spec.Endpoint.Port != nil {
+		port = *card.Spec.Endpoint.Port
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, card.Spec.Endpoint.Host, port)
+}
+
+// CardURLs returns the URLs an AgentCard's agent may publish its card at: spec.cardPath
+// (default /.well-known/agent.json) and, when it is not set, the legacy <basePath>/agent-card
+func CardURLs(card *agenticv1alpha1.AgentCard) []string {
+	root := rootURL(card)
+	if card.Spec.CardPath != nil && *card.Spec.CardPath != "" && *card.Spec.CardPath != DefaultCardPath {
+		return []string{root + *card.Spec.CardPath}
+	}
+	return []string{root + DefaultCardPath, BaseURL(card) + legacyCardPath}
+}
+
+// GetCard fetches the agent card published at the first of urls that serves one
+func (c *Client) GetCard(ctx context.Context, urls []string, token string) (*PublishedCard, error) {
+	var lastErr error
+	for _, url := range urls {
+		var card PublishedCard
+		if err := c.do(ctx, http.MethodGet, url, token, nil, &card); err != nil {
+			lastErr = err
+			continue
+		}
+		return &card, nil
+	}
+	return nil, fmt.Errorf("failed to get agent card: %w", lastErr)
+}
+
+// CreateTask sends a task to the agent served at baseURL
+func (c *Client) CreateTask(ctx context.Context, baseURL, token string, req CreateTaskRequest) (*Task, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task: %w", err)
+	}
+	var task Task
+	if err := c.do(ctx, http.MethodPost, baseURL+"/tasks", token, body, &task); err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+	return &task, nil
+}
+
+// GetTask fetches a task from the agent served at baseURL
+func (c *Client) GetTask(ctx context.Context, baseURL, token, taskID string) (*Task, error) {
+	var task Task
+	if err := c.do(ctx, http.MethodGet, baseURL+"/tasks/"+taskID, token, nil, &task); err != nil {
+		return nil, fmt.Errorf("failed to get task %s: %w", taskID, err)
+	}
+	return &task, nil
+}
+
+func (c *Client) do(ctx context.Context, method, url, token string, body []byte, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("A2A server returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+func TestBaseURL(t *testing.T) {
+	card := &agenticv1alpha1.AgentCard{Spec: agenticv1alpha1.AgentCardSpec{
+		Endpoint: agenticv1alpha1.AgentEndpoint{Host: "scraper.agents.svc"},
+	}}
+	if got := BaseURL(card); got != "http://scraper.agents.svc:8080/a2a" {
+		t.Fatalf("BaseURL() = %q, want defaults applied", got)
+	}
+
+	port := int32(9000)
+	basePath := "/api/a2a/"
+	card.Spec.Endpoint.Port = &port
+	card.Spec.Endpoint.BasePath = &basePath
+	if got := BaseURL(card); got != "http://scraper.agents.svc:9000/api/a2a" {
+		t.Fatalf("BaseURL() = %q", got)
+	}
+}
+
+func TestClientCreateAndGetTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer s3cret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/a2a/tasks":
+			var req CreateTaskRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(Task{ID: "task-1", Skill: req.Skill, Status: TaskQueued, SenderAgent: req.SenderAgent})
+		case r.Method == http.MethodGet && r.URL.Path == "/a2a/tasks/task-1":
+			_ = json.NewEncoder(w).Encode(Task{ID: "task-1", Status: TaskCompleted, OutputData: map[string]interface{}{"ok": true}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.Client())
+	task, err := c.CreateTask(context.Background(), server.URL+"/a2a", "s3cret", CreateTaskRequest{Skill: "scrape", SenderAgent: "lead"})
+	if err != nil {
+		t.Fatalf("CreateTask returned error: %v", err)
+	}
+	if task.ID != "task-1" || task.Skill != "scrape" || task.Done() {
+		t.Fatalf("unexpected task: %+v", task)
+	}
+
+	task, err = c.GetTask(context.Background(), server.URL+"/a2a", "s3cret", "task-1")
+	if err != nil {
+		t.Fatalf("GetTask returned error: %v", err)
+	}
+	if !task.Done() || task.OutputData["ok"] != true {
+		t.Fatalf("unexpected task: %+v", task)
+	}
+
+	if _, err := c.GetTask(context.Background(), server.URL+"/a2a", "s3cret", "missing"); err == nil {
+		t.Fatal("expected an error for a 404 response")
+	}
+}
+
+func TestGetCardFallsBackToLegacyPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/a2a/agent-card" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "scraper", "version": "0.1.0", "skills": [{"name": "scrape"}, {"id": "summarize"}]}`))
+	}))
+	defer server.Close()
+
+	card := &agenticv1alpha1.AgentCard{Spec: agenticv1alpha1.AgentCardSpec{
+		Endpoint: agenticv1alpha1.AgentEndpoint{Host: "scraper"},
+	}}
+	urls := CardURLs(card)
+	if len(urls) != 2 || urls[0] != "http://scraper:8080/.well-known/agent.json" || urls[1] != "http://scraper:8080/a2a/agent-card" {
+		t.Fatalf("CardURLs() = %v", urls)
+	}
+
+	published, err := NewClient(server.Client()).GetCard(context.Background(),
+		[]string{server.URL + DefaultCardPath, server.URL + "/a2a/agent-card"}, "")
+	if err != nil {
+		t.Fatalf("GetCard returned error: %v", err)
+	}
+	if published.Version != "0.1.0" || len(published.Skills) != 2 || published.Skills[1].SkillName() != "summarize" {
+		t.Fatalf("unexpected card: %+v", published)
+	}
+
+	cardPath := "/card.json"
+	card.Spec.CardPath = &cardPath
+	if urls := CardURLs(card); len(urls) != 1 || urls[0] != "http://scraper:8080/card.json" {
+		t.Fatalf("CardURLs() with cardPath = %v", urls)
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package a2a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// DefaultMaxConcurrentTasks applies when an AgentCard leaves maxConcurrentTasks unset
+const DefaultMaxConcurrentTasks = 5
+
+// maxReserveAttempts bounds the retries of a reservation that lost an update race
+const maxReserveAttempts = 5
+
+var (
+	// ErrNoAgentAvailable is returned when no Available agent serves the skill
+	ErrNoAgentAvailable = errors.New("no available agent serves the skill")
+
+	// ErrAgentAtCapacity is returned when an agent already runs maxConcurrentTasks tasks
+	ErrAgentAtCapacity = errors.New("agent is at capacity")
+)
+
+// Scheduler places A2A tasks on AgentCards. A task slot is reserved by adding the holding
+// workload and the task's skill to the card's status.reservations with an
+// optimistic-concurrency update, so two workloads racing for the last slot of an agent
+// cannot both win. A workload holds at most one slot per skill on a card.
+type Scheduler struct {
+	client client.Client
+}
+
+// NewScheduler creates a scheduler reading and updating AgentCards through c
+func NewScheduler(c client.Client) *Scheduler {
+	return &Scheduler{client: c}
+}
+
+// Schedule picks the best Available AgentCard in namespace serving skill and reserves a
+// task slot on it for holder. Release the slot when the task finishes.
+func (s *Scheduler) Schedule(ctx context.Context, namespace, skill, holder string) (*agenticv1alpha1.AgentCard, error) {
+	for attempt := 0; attempt < maxReserveAttempts; attempt++ {
+		var cards agenticv1alpha1.AgentCardList
+		if err := s.client.List(ctx, &cards, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list AgentCards: %w", err)
+		}
+		// A holder that already reserved a card for this skill keeps it
+		for i := range cards.Items {
+			if reservation(&cards.Items[i], holder, skill) != nil && SkillAvailable(&cards.Items[i], skill) {
+				return &cards.Items[i], nil
+			}
+		}
+
+		candidates := Rank(cards.Items, skill)
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("%w %q in namespace %s", ErrNoAgentAvailable, skill, namespace)
+		}
+		raced := false
+		for i := range candidates {
+			card := &candidates[i]
+			err := s.reserve(ctx, card, skill, holder)
+			if err == nil {
+				return card, nil
+			}
+			// Another reservation updated the card first: re-rank with fresh counts
+			if apierrors.IsConflict(err) {
+				raced = true
+				break
+			}
+			if !errors.Is(err, ErrAgentAtCapacity) {
+				return nil, err
+			}
+		}
+		if !raced {
+			return nil, fmt.Errorf("%w %q in namespace %s: all agents are at capacity", ErrNoAgentAvailable, skill, namespace)
+		}
+	}
+	return nil, fmt.Errorf("failed to reserve an agent for skill %q: too many concurrent reservations", skill)
+}
+
+// Reserve reserves a task slot for skill on a specific AgentCard for holder, retrying lost
+// update races. Reserving the same skill again for the same holder is a no-op. It returns
+// ErrAgentAtCapacity when the card has no spare capacity.
+func (s *Scheduler) Reserve(ctx context.Context, key types.NamespacedName, skill, holder string) error {
+	for attempt := 0; attempt < maxReserveAttempts; attempt++ {
+		card := &agenticv1alpha1.AgentCard{}
+		if err := s.client.Get(ctx, key, card); err != nil {
+			return err
+		}
+		err := s.reserve(ctx, card, skill, holder)
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to reserve AgentCard %s: too many concurrent reservations", key)
+}
+
+// Release returns the task slot holder reserved for skill on an AgentCard
+func (s *Scheduler) Release(ctx context.Context, key types.NamespacedName, skill, holder string) error {
+	for attempt := 0; attempt < maxReserveAttempts; attempt++ {
+		card := &agenticv1alpha1.AgentCard{}
+		if err := s.client.Get(ctx, key, card); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !RemoveReservations(card, func(r agenticv1alpha1.TaskReservation) bool { return r.Holder == holder && r.Skill == skill }) {
+			return nil
+		}
+		err := s.client.Status().Update(ctx, card)
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to release AgentCard %s: too many concurrent updates", key)
+}
+
+// reserve adds holder's slot for skill to the card's reservations. The update carries the
+// card's resourceVersion, so it fails with a conflict if the card changed since it was read.
+func (s *Scheduler) reserve(ctx context.Context, card *agenticv1alpha1.AgentCard, skill, holder string) error {
+	if reservation(card, holder, skill) != nil {
+		return nil
+	}
+	if SpareCapacity(card) <= 0 {
+		return ErrAgentAtCapacity
+	}
+	card.Status.Reservations = append(card.Status.Reservations, agenticv1alpha1.TaskReservation{
+		Holder:     holder,
+		Skill:      skill,
+		ReservedAt: metav1.Now(),
+	})
+	card.Status.ActiveTaskCount = int32(len(card.Status.Reservations))
+	return s.client.Status().Update(ctx, card)
+}
+
+// RemoveReservations drops the reservations matching release and reports whether any was
+// dropped; activeTaskCount follows the remaining reservations
+func RemoveReservations(card *agenticv1alpha1.AgentCard, release func(agenticv1alpha1.TaskReservation) bool) bool {
+	kept := card.Status.Reservations[:0]
+	for _, r := range card.Status.Reservations {
+		if !release(r) {
+			kept = append(kept, r)
+		}
+	}
+	removed := len(kept) != len(card.Status.Reservations)
+	if len(kept) == 0 {
+		kept = nil
+	}
+	card.Status.Reservations = kept
+	card.Status.ActiveTaskCount = int32(len(kept))
+	return removed
+}
+
+// HoldsReservation reports whether holder holds a task slot for skill on an AgentCard
+func HoldsReservation(card *agenticv1alpha1.AgentCard, holder, skill string) bool {
+	return reservation(card, holder, skill) != nil
+}
+
+func reservation(card *agenticv1alpha1.AgentCard, holder, skill string) *agenticv1alpha1.TaskReservation {
+	for i := range card.Status.Reservations {
+		if card.Status.Reservations[i].Holder == holder && card.Status.Reservations[i].Skill == skill {
+			return &card.Status.Reservations[i]
+		}
+	}
+	return nil
+}
+
+// SpareCapacity returns how many more tasks an AgentCard accepts
+func SpareCapacity(card *agenticv1alpha1.AgentCard) int32 {
+	limit := int32(DefaultMaxConcurrentTasks)
+	if card.Spec.MaxConcurrentTasks != nil {
+		limit = *card.Spec.MaxConcurrentTasks
+	}
+	return limit - card.Status.ActiveTaskCount
+}
+
+// SkillAvailable reports whether an AgentCard declares skill and its status reports it
+// available
+func SkillAvailable(card *agenticv1alpha1.AgentCard, skill string) bool {
+	for _, status := range card.Status.Skills {
+		if status.Name == skill {
+			return status.Available
+		}
+	}
+	return false
+}
+
+// Rank returns the Available cards serving skill with spare capacity, best first: most
+// spare capacity, then most recent heartbeat, then name
+func Rank(cards []agenticv1alpha1.AgentCard, skill string) []agenticv1alpha1.AgentCard {
+	var candidates []agenticv1alpha1.AgentCard
+	for _, card := range cards {
+		if card.Status.Phase == "Available" && SkillAvailable(&card, skill) && SpareCapacity(&card) > 0 {
+			candidates = append(candidates, card)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := &candidates[i], &candidates[j]
+		if spareA, spareB := SpareCapacity(a), SpareCapacity(b); spareA != spareB {
+			return spareA > spareB
+		}
+		heartbeatA, heartbeatB := a.Status.LastHeartbeat, b.Status.LastHeartbeat
+		switch {
+		case heartbeatA != nil && heartbeatB == nil:
+			return true
+		case heartbeatA == nil && heartbeatB != nil:
+			return false
+		case heartbeatA != nil && !heartbeatA.Equal(heartbeatB):
+			return heartbeatB.Before(heartbeatA)
+		}
+		return a.Name < b.Name
+	})
+	return candidates
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package a2a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+func newSchedulerCard(name, phase string, maxTasks, active int32, heartbeat time.Time, skills ...agenticv1alpha1.SkillStatus) *agenticv1alpha1.AgentCard {
+	card := &agenticv1alpha1.AgentCard{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agents"},
+		Spec: agenticv1alpha1.AgentCardSpec{
+			DisplayName:        name,
+			Skills:             []agenticv1alpha1.AgentSkill{{Name: "scrape"}},
+			Endpoint:           agenticv1alpha1.AgentEndpoint{Host: name},
+			MaxConcurrentTasks: &maxTasks,
+		},
+		Status: agenticv1alpha1.AgentCardStatus{Phase: phase, Skills: skills, ActiveTaskCount: active},
+	}
+	if !heartbeat.IsZero() {
+		card.Status.LastHeartbeat = &metav1.Time{Time: heartbeat}
+	}
+	for i := int32(0); i < active; i++ {
+		card.Status.Reservations = append(card.Status.Reservations, agenticv1alpha1.TaskReservation{Holder: fmt.Sprintf("existing-%d", i)})
+	}
+	return card
+}
+
+func newSchedulerClient(t *testing.T, cards ...*agenticv1alpha1.AgentCard) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := agenticv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&agenticv1alpha1.AgentCard{})
+	for _, card := range cards {
+		builder = builder.WithObjects(card)
+	}
+	return builder.Build()
+}
+
+var scrapeAvailable = agenticv1alpha1.SkillStatus{Name: "scrape", Available: true}
+
+func TestRank(t *testing.T) {
+	now := time.Now()
+	cards := []agenticv1alpha1.AgentCard{
+		*newSchedulerCard("busy", "Available", 5, 4, now, scrapeAvailable),
+		*newSchedulerCard("stale", "Available", 5, 1, now.Add(-time.Minute), scrapeAvailable),
+		*newSchedulerCard("fresh", "Available", 5, 1, now, scrapeAvailable),
+		*newSchedulerCard("full", "Available", 2, 2, now, scrapeAvailable),
+		*newSchedulerCard("degraded", "Degraded", 5, 0, now, scrapeAvailable),
+		*newSchedulerCard("skill-down", "Available", 5, 0, now, agenticv1alpha1.SkillStatus{Name: "scrape"}),
+		*newSchedulerCard("other-skill", "Available", 5, 0, now, agenticv1alpha1.SkillStatus{Name: "summarize", Available: true}),
+	}
+
+	ranked := Rank(cards, "scrape")
+	var names []string
+	for _, card := range ranked {
+		names = append(names, card.Name)
+	}
+	if fmt.Sprint(names) != "[fresh stale busy]" {
+		t.Fatalf("Rank() = %v, want [fresh stale busy]", names)
+	}
+}
+
+func TestSchedulerScheduleReservesAndReleases(t *testing.T) {
+	c := newSchedulerClient(t,
+		newSchedulerCard("small", "Available", 1, 0, time.Now(), scrapeAvailable),
+		newSchedulerCard("large", "Available", 3, 0, time.Now(), scrapeAvailable))
+	s := NewScheduler(c)
+	ctx := context.Background()
+
+	card, err := s.Schedule(ctx, "agents", "scrape", "workload-a")
+	if err != nil || card.Name != "large" {
+		t.Fatalf("Schedule() = %v, %v; want large", card, err)
+	}
+	// Scheduling again for the same holder keeps its reservation
+	if again, err := s.Schedule(ctx, "agents", "scrape", "workload-a"); err != nil || again.Name != "large" {
+		t.Fatalf("repeated Schedule() = %v, %v", again, err)
+	}
+
+	stored := &agenticv1alpha1.AgentCard{}
+	key := types.NamespacedName{Name: "large", Namespace: "agents"}
+	if err := c.Get(ctx, key, stored); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if stored.Status.ActiveTaskCount != 1 || len(stored.Status.Reservations) != 1 || stored.Status.Reservations[0].Holder != "workload-a" {
+		t.Fatalf("expected one reservation for workload-a, got %+v", stored.Status)
+	}
+
+	if err := s.Release(ctx, key, "scrape", "workload-a"); err != nil {
+		t.Fatalf("Release() returned error: %v", err)
+	}
+	if err := c.Get(ctx, key, stored); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if stored.Status.ActiveTaskCount != 0 || len(stored.Status.Reservations) != 0 {
+		t.Fatalf("expected reservation released, got %+v", stored.Status)
+	}
+
+	if _, err := s.Schedule(ctx, "agents", "translate", "workload-b"); !errors.Is(err, ErrNoAgentAvailable) {
+		t.Fatalf("expected ErrNoAgentAvailable for an unknown skill, got %v", err)
+	}
+}
+
+func TestSchedulerReservesOneSlotPerSkill(t *testing.T) {
+	summarizeAvailable := agenticv1alpha1.SkillStatus{Name: "summarize", Available: true}
+	c := newSchedulerClient(t, newSchedulerCard("multi", "Available", 3, 0, time.Now(), scrapeAvailable, summarizeAvailable))
+	s := NewScheduler(c)
+	ctx := context.Background()
+
+	for _, skill := range []string{"scrape", "summarize"} {
+		if _, err := s.Schedule(ctx, "agents", skill, "workload-a"); err != nil {
+			t.Fatalf("Schedule(%s) returned error: %v", skill, err)
+		}
+	}
+	stored := &agenticv1alpha1.AgentCard{}
+	key := types.NamespacedName{Name: "multi", Namespace: "agents"}
+	if err := c.Get(ctx, key, stored); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if stored.Status.ActiveTaskCount != 2 {
+		t.Fatalf("expected a slot per skill, got %+v", stored.Status.Reservations)
+	}
+
+	if err := s.Release(ctx, key, "scrape", "workload-a"); err != nil {
+		t.Fatalf("Release() returned error: %v", err)
+	}
+	if err := c.Get(ctx, key, stored); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if len(stored.Status.Reservations) != 1 || stored.Status.Reservations[0].Skill != "summarize" {
+		t.Fatalf("expected only the summarize slot to remain, got %+v", stored.Status.Reservations)
+	}
+}
+
+func TestSchedulerReserveAtCapacity(t *testing.T) {
+	c := newSchedulerClient(t, newSchedulerCard("small", "Available", 1, 1, time.Now(), scrapeAvailable))
+	err := NewScheduler(c).Reserve(context.Background(), types.NamespacedName{Name: "small", Namespace: "agents"}, "scrape", "workload-a")
+	if !errors.Is(err, ErrAgentAtCapacity) {
+		t.Fatalf("expected ErrAgentAtCapacity, got %v", err)
+	}
+}
+
+func TestSchedulerConcurrentReservationsDoNotOversubscribe(t *testing.T) {
+	c := newSchedulerClient(t, newSchedulerCard("agent", "Available", 2, 0, time.Now(), scrapeAvailable))
+	s := NewScheduler(c)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := s.Schedule(context.Background(), "agents", "scrape", fmt.Sprintf("workload-%d", i)); err == nil {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	card := &agenticv1alpha1.AgentCard{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "agent", Namespace: "agents"}, card); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	if reserved > 2 || card.Status.ActiveTaskCount != int32(reserved) || len(card.Status.Reservations) != reserved {
+		t.Fatalf("oversubscribed: %d reservations succeeded, card reports %d active and %d reservations",
+			reserved, card.Status.ActiveTaskCount, len(card.Status.Reservations))
+	}
+}
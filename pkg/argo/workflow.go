@@ -20,8 +20,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -57,8 +60,10 @@ func NewWorkflowManager(c client.Client, s *runtime.Scheme) *WorkflowManager {
 // These parameters are substituted into the WorkflowTemplate by Argo's parameter system
 type WorkflowParameters struct {
 	JobID          string   // Unique workflow identifier (from AgentWorkload.metadata.name)
-	TargetURLs     []string // URLs to scrape
-	MinioBucket    string   // S3 bucket for artifacts
+	TargetURLs     []string // URLs to scrape (from AgentWorkload.spec.targetUrls)
+	MinioBucket    string   // S3 bucket for artifacts (from spec.targetBucket)
+	TargetPrefix   string   // Artifact key prefix ending in "/" (from spec.targetPrefix), or empty
+	ScriptURL      string   // Agent script to execute (from spec.scriptUrl)
 	AgentImage     string   // Container image for Python agents
 	BrowserlessURL string   // WebSocket endpoint for Browserless
 	LiteLLMURL     string   // HTTP endpoint for LiteLLM proxy
@@ -73,10 +78,56 @@ type WorkflowStatus struct {
 	StartTime       *v1.Time
 	CompletionTime  *v1.Time
 	IsSuspended     bool
-	CurrentNode     string // Name of currently executing node (e.g., "scraper", "approve-gate")
+	SuspendedAt     *v1.Time // When the oldest running suspend node started waiting (nil if not suspended)
+	SuspendedNode   string   // Display name of that suspend node (e.g., "approval-gate")
+	CurrentNode     string   // Name of currently executing node (e.g., "scraper", "approve-gate")
 	SuccessfulNodes int32
 	FailedNodes     int32
 	TotalNodes      int32
+	Progress        string       // Completed/total steps as reported by Argo (e.g., "3/5")
+	Nodes           []NodeStatus // Step nodes ordered by start time; DAG/Steps group nodes are omitted
+}
+
+// NodeStatus represents the status of a single workflow step
+type NodeStatus struct {
+	ID         string
+	Name       string // Display name (e.g., "scraper")
+	Type       string // Pod, Suspend, Skipped, ...
+	Phase      string
+	Message    string
+	StartedAt  *v1.Time
+	FinishedAt *v1.Time
+	Artifacts  []ArtifactLocation // S3 output artifacts produced by the step
+}
+
+// ArtifactLocation is where a step stored an output artifact
+type ArtifactLocation struct {
+	Name   string
+	Bucket string // Empty when the artifact lives in the default artifact repository
+	Key    string
+}
+
+// URI returns the artifact location as s3://bucket/key, using defaultBucket when the
+// artifact does not name one
+func (a ArtifactLocation) URI(defaultBucket string) string {
+	bucket := a.Bucket
+	if bucket == "" {
+		bucket = defaultBucket
+	}
+	if bucket == "" {
+		return "s3://" + strings.TrimPrefix(a.Key, "/")
+	}
+	return "s3://" + bucket + "/" + strings.TrimPrefix(a.Key, "/")
+}
+
+// groupNodeTypes are Argo node types that only group other nodes and are not steps themselves
+var groupNodeTypes = map[string]bool{
+	"Workflow":  true,
+	"DAG":       true,
+	"Steps":     true,
+	"StepGroup": true,
+	"TaskGroup": true,
+	"Retry":     true,
 }
 
 const (
@@ -116,13 +167,29 @@ const (
 
 	// WorkflowRequeueInterval is how often to check workflow status
 	WorkflowRequeueInterval = 30 // seconds
+
+	// JobIDLabel links a Workflow to the AgentWorkload it was created for (value: workload name)
+	JobIDLabel = "agentic.io/job-id"
+
+	// WorkloadNamespaceLabel records the AgentWorkload namespace, since the Workflow
+	// usually lives in another namespace and cannot carry a usable ownerReference
+	WorkloadNamespaceLabel = "agentic.io/workload-namespace"
+
+	// ArchivedLabel marks Workflows kept as history after their AgentWorkload was deleted
+	ArchivedLabel = "agentic.io/archived"
+
+	// WorkflowRetentionDelete deletes the Workflow and its artifacts with the AgentWorkload
+	WorkflowRetentionDelete = "delete"
+
+	// WorkflowRetentionRetain keeps the Workflow and its artifacts for auditing
+	WorkflowRetentionRetain = "retain"
 )
 
 // CreateArgoWorkflow generates an Argo Workflow CR from an AgentWorkload CR
 //
 // This function:
 // 1. Builds WorkflowParameters from the AgentWorkload spec
-// 2. Creates an Argo Workflow manifest (using WorkflowTemplate)
+// 2. Creates an Argo Workflow manifest (referenced WorkflowTemplate, spec.resources as podSpecPatch)
 // 3. Sets ownerReference for cascade deletion
 // 4. Applies the Workflow CR to the cluster
 //
@@ -151,6 +218,10 @@ func (wm *WorkflowManager) CreateArgoWorkflow(
 
 	// Build workflow parameters from AgentWorkload spec
 	params := wm.buildWorkflowParameters(agentWorkload)
+	templateName, workflowNamespace, err := ResolveWorkflowTemplateRef(agentWorkload)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create Workflow object with initialized Object map (CRITICAL: prevents nil map panic)
 	// The Object map MUST be initialized before using SetNestedField
@@ -160,11 +231,12 @@ func (wm *WorkflowManager) CreateArgoWorkflow(
 	workflow.SetAPIVersion(WorkflowGroupVersion)
 	workflow.SetKind(WorkflowKind)
 	workflow.SetName(agentWorkload.Name)
-	workflow.SetNamespace(DefaultWorkflowNamespace)
+	workflow.SetNamespace(workflowNamespace)
 	workflowLabels := map[string]string{
 		"app.kubernetes.io/name":    "agentic-k8s-operator",
 		"app.kubernetes.io/part-of": "agentic-k8s-operator",
-		"agentic.io/job-id":         params.JobID,
+		JobIDLabel:                  params.JobID,
+		WorkloadNamespaceLabel:      agentWorkload.Namespace,
 		"agentic.io/source":         "agentworkload-controller",
 	}
 	if agentWorkload.Spec.Persona != nil && agentWorkload.Spec.Persona.Role != "" {
@@ -172,23 +244,26 @@ func (wm *WorkflowManager) CreateArgoWorkflow(
 	}
 	workflow.SetLabels(workflowLabels)
 
-	// Set ownerReference to AgentWorkload for cascade deletion
-	// When AgentWorkload is deleted, Kubernetes automatically deletes the Workflow
-	ownerRef := v1.OwnerReference{
-		APIVersion: agenticv1alpha1.GroupVersion.String(),
-		Kind:       "AgentWorkload",
-		Name:       agentWorkload.Name,
-		UID:        agentWorkload.UID,
-		Controller: ptr(true),
+	// Set ownerReference to AgentWorkload for cascade deletion when both live in the same
+	// namespace. Kubernetes ignores cross-namespace owners, so Workflows in another
+	// namespace are cleaned up by the AgentWorkload finalizer instead.
+	if workflow.GetNamespace() == agentWorkload.Namespace {
+		ownerRef := v1.OwnerReference{
+			APIVersion: agenticv1alpha1.GroupVersion.String(),
+			Kind:       "AgentWorkload",
+			Name:       agentWorkload.Name,
+			UID:        agentWorkload.UID,
+			Controller: ptr(true),
+		}
+		workflow.SetOwnerReferences([]v1.OwnerReference{ownerRef})
 	}
-	workflow.SetOwnerReferences([]v1.OwnerReference{ownerRef})
 
 	// Build workflow spec using WorkflowTemplate
 	// This uses Argo's parameter substitution: {{inputs.parameters.job_id}} etc.
 	// NOTE: We set fields individually to avoid unstructured deep copy issues with []map[string]string
 
-	// Set workflowTemplateRef
-	if err := unstructured.SetNestedField(workflow.Object, DefaultWorkflowTemplate, "spec", "workflowTemplateRef", "name"); err != nil {
+	// Set workflowTemplateRef (Argo resolves it in the workflow's own namespace)
+	if err := unstructured.SetNestedField(workflow.Object, templateName, "spec", "workflowTemplateRef", "name"); err != nil {
 		log.Error(err, "failed to set workflowTemplateRef.name")
 		return nil, err
 	}
@@ -203,15 +278,15 @@ func (wm *WorkflowManager) CreateArgoWorkflow(
 		map[string]interface{}{"name": "job_id", "value": params.JobID},
 		map[string]interface{}{"name": "target_urls", "value": targetURLsJSON},
 		map[string]interface{}{"name": "minio_bucket", "value": params.MinioBucket},
+		map[string]interface{}{"name": "target_prefix", "value": params.TargetPrefix},
+		map[string]interface{}{"name": "script_url", "value": params.ScriptURL},
 		map[string]interface{}{"name": "agent_image", "value": params.AgentImage},
 		map[string]interface{}{"name": "browserless_url", "value": params.BrowserlessURL},
 		map[string]interface{}{"name": "litellm_url", "value": params.LiteLLMURL},
 		map[string]interface{}{"name": "postgres_dsn", "value": params.PostgresDSN},
-		map[string]interface{}{"name": "PERSONA_ROLE", "value": personaRole(agentWorkload)},
-		map[string]interface{}{"name": "PERSONA_TONE", "value": personaTone(agentWorkload)},
-		map[string]interface{}{"name": "PERSONA_MEMORY_SCOPE", "value": personaMemoryScope(agentWorkload)},
-		map[string]interface{}{"name": "PERSONA_SYSTEM_PROMPT_APPEND", "value": personaSystemPromptAppend(agentWorkload)},
-		map[string]interface{}{"name": "PERSONA_TOOL_PROFILE", "value": personaToolProfileJSON(agentWorkload)},
+	}
+	for _, persona := range PersonaParameters(agentWorkload) {
+		parametersData = append(parametersData, map[string]interface{}{"name": persona.Name, "value": persona.Value})
 	}
 
 	if err := unstructured.SetNestedField(workflow.Object, parametersData, "spec", "arguments", "parameters"); err != nil {
@@ -219,6 +294,36 @@ func (wm *WorkflowManager) CreateArgoWorkflow(
 		return nil, err
 	}
 
+	// Apply spec.resources to every workflow pod's main container
+	podSpecPatch, err := buildPodSpecPatch(agentWorkload.Spec.Resources)
+	if err != nil {
+		log.Error(err, "failed to build podSpecPatch")
+		return nil, err
+	}
+	if podSpecPatch != "" {
+		if err := unstructured.SetNestedField(workflow.Object, podSpecPatch, "spec", "podSpecPatch"); err != nil {
+			log.Error(err, "failed to set podSpecPatch")
+			return nil, err
+		}
+	}
+
+	// Let Argo delete output artifacts together with the Workflow unless history is retained
+	if WorkflowRetention(agentWorkload) == WorkflowRetentionDelete {
+		if err := unstructured.SetNestedField(workflow.Object, "OnWorkflowDeletion", "spec", "artifactGC", "strategy"); err != nil {
+			log.Error(err, "failed to set artifactGC.strategy")
+			return nil, err
+		}
+	}
+
+	// Bound the workflow by the workload's execution timeout so Argo fails it on overrun.
+	// Argo counts suspended time against the deadline, so none is set without a timeout.
+	if deadline := ActiveDeadlineSeconds(agentWorkload); deadline > 0 {
+		if err := unstructured.SetNestedField(workflow.Object, deadline, "spec", "activeDeadlineSeconds"); err != nil {
+			log.Error(err, "failed to set activeDeadlineSeconds")
+			return nil, err
+		}
+	}
+
 	if agentWorkload.Spec.Persona != nil && agentWorkload.Spec.Persona.Role != "" {
 		podLabels := map[string]interface{}{
 			"agentworkload.clawdlinux.io/role": agentWorkload.Spec.Persona.Role,
@@ -303,8 +408,9 @@ func (wm *WorkflowManager) GetArgoWorkflowStatus(
 		}
 	}
 
-	// Check if suspended (status.conditions[] with type="Suspended")
-	status.IsSuspended = isWorkflowSuspended(workflow)
+	// Check if suspended (status.conditions[] with type="Suspended", or a suspend node still waiting)
+	status.SuspendedNode, status.SuspendedAt = findSuspendedNode(workflow)
+	status.IsSuspended = isWorkflowSuspended(workflow) || status.SuspendedAt != nil
 
 	// Get current node (status.currentNode)
 	if node, found, err := unstructured.NestedString(workflow.Object, "status", "currentNode"); err == nil && found {
@@ -316,18 +422,24 @@ func (wm *WorkflowManager) GetArgoWorkflowStatus(
 		status.SuccessfulNodes = int32(countNodesByPhase(nodes, "Succeeded"))
 		status.FailedNodes = int32(countNodesByPhase(nodes, "Failed"))
 		status.TotalNodes = int32(len(nodes))
+		status.Nodes = stepNodes(nodes)
+	}
+
+	// Get progress (status.progress, e.g. "3/5")
+	if progress, found, err := unstructured.NestedString(workflow.Object, "status", "progress"); err == nil && found {
+		status.Progress = progress
 	}
 
 	log.Info("Got Workflow status", "name", workflowName, "phase", status.Phase, "suspended", status.IsSuspended)
 	return status, nil
 }
 
-// ResumeArgoWorkflow resumes a suspended Argo Workflow by calling the resume endpoint
+// ResumeArgoWorkflow resumes a suspended Argo Workflow the way `argo resume` does
 //
 // This function:
 // 1. Fetches the Workflow CR
-// 2. Updates its status to remove suspension (PATCH /resume)
-// 3. Triggers Argo to continue from the suspend node
+// 2. Clears spec.suspend and marks every running Suspend node as Succeeded
+// 3. Argo's controller then continues from the suspend node
 //
 // Important: This operation is idempotent - calling it multiple times has the
 // same effect as calling it once. This is critical for fault tolerance.
@@ -342,7 +454,6 @@ func (wm *WorkflowManager) GetArgoWorkflowStatus(
 //
 // Error cases:
 //   - Workflow not found (returns error)
-//   - Workflow not suspended (returns error)
 //   - API patch error (returns error)
 func (wm *WorkflowManager) ResumeArgoWorkflow(
 	ctx context.Context,
@@ -350,52 +461,220 @@ func (wm *WorkflowManager) ResumeArgoWorkflow(
 	namespace string,
 ) error {
 	log := logf.FromContext(ctx)
+	log.Info("Resuming Argo Workflow", "name", workflowName, "namespace", namespace)
 
-	// Fetch the Workflow CR
+	if err := wm.updateSuspendedNodes(ctx, workflowName, namespace, "Succeeded", ""); err != nil {
+		log.Error(err, "failed to resume Workflow", "name", workflowName)
+		return err
+	}
+
+	log.Info("Argo Workflow resumed successfully", "name", workflowName)
+	return nil
+}
+
+// RejectArgoWorkflow rejects a suspended approval gate the way
+// `argo stop --node-field-selector` does: every running Suspend node is marked Failed
+// with the given message, so the workflow fails at the gate instead of continuing.
+//
+// Parameters:
+//   - ctx: Context for cancellation and deadlines
+//   - workflowName: Name of the workflow to reject
+//   - namespace: Namespace of the workflow (usually "argo-workflows")
+//   - message: Reason recorded on the failed node
+//
+// Returns:
+//   - Error if the workflow was not found or the patch failed
+func (wm *WorkflowManager) RejectArgoWorkflow(
+	ctx context.Context,
+	workflowName string,
+	namespace string,
+	message string,
+) error {
+	log := logf.FromContext(ctx)
+	log.Info("Rejecting Argo Workflow approval gate", "name", workflowName, "namespace", namespace)
+
+	if err := wm.updateSuspendedNodes(ctx, workflowName, namespace, "Failed", message); err != nil {
+		log.Error(err, "failed to reject Workflow", "name", workflowName)
+		return err
+	}
+
+	log.Info("Argo Workflow approval gate rejected", "name", workflowName)
+	return nil
+}
+
+// updateSuspendedNodes sets phase (and message) on every running Suspend node and clears
+// spec.suspend, mirroring Argo's own resume/stop node updates. Nodes live in the main
+// Workflow resource (Argo does not use a status subresource), so a regular patch is used.
+func (wm *WorkflowManager) updateSuspendedNodes(
+	ctx context.Context,
+	workflowName string,
+	namespace string,
+	phase string,
+	message string,
+) error {
 	workflow := &unstructured.Unstructured{}
 	workflow.SetAPIVersion(WorkflowGroupVersion)
 	workflow.SetKind(WorkflowKind)
 
 	if err := wm.client.Get(ctx, types.NamespacedName{Name: workflowName, Namespace: namespace}, workflow); err != nil {
-		log.Error(err, "failed to get Workflow for resume", "name", workflowName, "namespace", namespace)
 		return err
 	}
 
-	log.Info("Resuming Argo Workflow", "name", workflowName, "namespace", namespace)
+	before := workflow.DeepCopy()
+	updated := false
 
-	// Create a patch to update the workflow status
-	// This removes the suspend marker so Argo continues execution
-	// Format: {"op": "replace", "path": "/spec/resume", "value": null}
-	// But Argo uses status.conditions to track suspend state, so we patch that
+	if suspend, found, _ := unstructured.NestedBool(workflow.Object, "spec", "suspend"); found && suspend {
+		unstructured.RemoveNestedField(workflow.Object, "spec", "suspend")
+		updated = true
+	}
 
-	// Patch the workflow with JSONPatchType to resume
-	// The actual format depends on Argo version, but generally we remove suspend conditions
-	patch := client.RawPatch(types.MergePatchType, []byte(`{"status":{"conditions":[]}}`))
+	nodes, found, err := unstructured.NestedMap(workflow.Object, "status", "nodes")
+	if err != nil {
+		return fmt.Errorf("failed to read workflow nodes: %w", err)
+	}
+	if found {
+		finishedAt := time.Now().UTC().Format(time.RFC3339)
+		for id, node := range nodes {
+			nodeMap, ok := node.(map[string]interface{})
+			if !ok || nodeMap["type"] != "Suspend" || nodeMap["phase"] != "Running" {
+				continue
+			}
+			nodeMap["phase"] = phase
+			nodeMap["finishedAt"] = finishedAt
+			if message != "" {
+				nodeMap["message"] = message
+			}
+			nodes[id] = nodeMap
+			updated = true
+		}
+		if updated {
+			if err := unstructured.SetNestedMap(workflow.Object, nodes, "status", "nodes"); err != nil {
+				return fmt.Errorf("failed to set workflow nodes: %w", err)
+			}
+		}
+	}
+
+	// Nothing suspended: already resumed/rejected
+	if !updated {
+		return nil
+	}
+
+	return wm.client.Patch(ctx, workflow, client.MergeFrom(before))
+}
+
+// DeleteArgoWorkflow deletes a workflow; Argo's artifactGC removes its output artifacts.
+// A workflow that no longer exists is treated as already deleted.
+//
+// Parameters:
+//   - ctx: Context for cancellation and deadlines
+//   - workflowName: Name of the workflow to delete
+//   - namespace: Namespace of the workflow
+//
+// Returns:
+//   - Error if the delete call failed
+func (wm *WorkflowManager) DeleteArgoWorkflow(
+	ctx context.Context,
+	workflowName string,
+	namespace string,
+) error {
+	log := logf.FromContext(ctx)
+
+	workflow := &unstructured.Unstructured{}
+	workflow.SetAPIVersion(WorkflowGroupVersion)
+	workflow.SetKind(WorkflowKind)
+	workflow.SetName(workflowName)
+	workflow.SetNamespace(namespace)
 
-	if err := wm.client.Status().Patch(ctx, workflow, patch); err != nil {
-		log.Error(err, "failed to patch Workflow for resume", "name", workflowName)
+	if err := wm.client.Delete(ctx, workflow, client.PropagationPolicy(v1.DeletePropagationBackground)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		log.Error(err, "failed to delete Workflow", "name", workflowName, "namespace", namespace)
 		return err
 	}
 
-	log.Info("Argo Workflow resumed successfully", "name", workflowName)
+	log.Info("Argo Workflow deleted", "name", workflowName, "namespace", namespace)
+	return nil
+}
+
+// ArchiveArgoWorkflow keeps a workflow as history once its AgentWorkload is gone.
+// It drops ownerReferences (so garbage collection never removes it) and labels it
+// with ArchivedLabel. A workflow that no longer exists is a no-op.
+//
+// Parameters:
+//   - ctx: Context for cancellation and deadlines
+//   - workflowName: Name of the workflow to archive
+//   - namespace: Namespace of the workflow
+//
+// Returns:
+//   - Error if the workflow could not be fetched or patched
+func (wm *WorkflowManager) ArchiveArgoWorkflow(
+	ctx context.Context,
+	workflowName string,
+	namespace string,
+) error {
+	log := logf.FromContext(ctx)
+
+	workflow := &unstructured.Unstructured{}
+	workflow.SetAPIVersion(WorkflowGroupVersion)
+	workflow.SetKind(WorkflowKind)
+
+	if err := wm.client.Get(ctx, types.NamespacedName{Name: workflowName, Namespace: namespace}, workflow); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		log.Error(err, "failed to get Workflow for archive", "name", workflowName, "namespace", namespace)
+		return err
+	}
+
+	before := workflow.DeepCopy()
+	labels := workflow.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ArchivedLabel] = "true"
+	workflow.SetLabels(labels)
+	workflow.SetOwnerReferences(nil)
+
+	if err := wm.client.Patch(ctx, workflow, client.MergeFrom(before)); err != nil {
+		log.Error(err, "failed to patch Workflow for archive", "name", workflowName)
+		return err
+	}
+
+	log.Info("Argo Workflow archived", "name", workflowName, "namespace", namespace)
 	return nil
 }
 
 // buildWorkflowParameters constructs WorkflowParameters from an AgentWorkload
 // This includes applying defaults and validating inputs
 func (wm *WorkflowManager) buildWorkflowParameters(agentWorkload *agenticv1alpha1.AgentWorkload) WorkflowParameters {
+	return BuildWorkflowParameters(agentWorkload)
+}
+
+// BuildWorkflowParameters constructs the agent runtime parameters from an AgentWorkload.
+// Other orchestration engines use it to pass the runtime the same parameters as Argo.
+func BuildWorkflowParameters(agentWorkload *agenticv1alpha1.AgentWorkload) WorkflowParameters {
 	params := WorkflowParameters{
 		JobID:          agentWorkload.Name,
-		MinioBucket:    DefaultMinioBucket,
+		TargetURLs:     []string{},
+		MinioBucket:    ArtifactBucket(agentWorkload),
 		AgentImage:     DefaultAgentImage,
 		BrowserlessURL: DefaultBrowserlessURL,
 		LiteLLMURL:     DefaultLiteLLMURL,
 		PostgresDSN:    DefaultPostgresDSN,
 	}
 
-	// For now, targetURLs would come from extended AgentWorkloadSpec in future
-	// For this phase, we use a default set
-	params.TargetURLs = []string{"https://example.com"}
+	spec := agentWorkload.Spec
+	if len(spec.TargetURLs) > 0 {
+		params.TargetURLs = append(params.TargetURLs, spec.TargetURLs...)
+	}
+	if spec.TargetPrefix != nil && strings.Trim(*spec.TargetPrefix, "/") != "" {
+		// The template prepends the prefix to artifact keys
+		params.TargetPrefix = strings.Trim(*spec.TargetPrefix, "/") + "/"
+	}
+	if spec.ScriptUrl != nil {
+		params.ScriptURL = *spec.ScriptUrl
+	}
 
 	return params
 }
@@ -420,6 +699,214 @@ func isWorkflowSuspended(workflow *unstructured.Unstructured) bool {
 	return false
 }
 
+// ResolveWorkflowTemplateRef returns the WorkflowTemplate name and the namespace the
+// Workflow must be created in. Argo resolves workflowTemplateRef in the Workflow's own
+// namespace, so spec.orchestration.workflowTemplateRef.namespace selects both. Since the
+// operator creates the Workflow with its own permissions, the namespace must be the
+// workload's namespace or DefaultWorkflowNamespace.
+func ResolveWorkflowTemplateRef(workload *agenticv1alpha1.AgentWorkload) (string, string, error) {
+	name, namespace := DefaultWorkflowTemplate, DefaultWorkflowNamespace
+	if workload.Spec.Orchestration == nil || workload.Spec.Orchestration.WorkflowTemplateRef == nil {
+		return name, namespace, nil
+	}
+	ref := workload.Spec.Orchestration.WorkflowTemplateRef
+	if ref.Name != nil && *ref.Name != "" {
+		name = *ref.Name
+	}
+	if ref.Namespace != nil && *ref.Namespace != "" {
+		namespace = *ref.Namespace
+	}
+	if namespace != workload.Namespace && namespace != DefaultWorkflowNamespace {
+		return "", "", fmt.Errorf("workflowTemplateRef namespace %q must be the workload namespace %q or %q",
+			namespace, workload.Namespace, DefaultWorkflowNamespace)
+	}
+	return name, namespace, nil
+}
+
+// ArtifactBucket returns the S3 bucket a workload's artifacts are written to:
+// spec.targetBucket, or DefaultMinioBucket when unset
+func ArtifactBucket(workload *agenticv1alpha1.AgentWorkload) string {
+	if workload.Spec.TargetBucket != nil && *workload.Spec.TargetBucket != "" {
+		return *workload.Spec.TargetBucket
+	}
+	return DefaultMinioBucket
+}
+
+// WorkflowRetention returns spec.orchestration.workflowRetention, defaulting to "delete"
+func WorkflowRetention(workload *agenticv1alpha1.AgentWorkload) string {
+	if workload.Spec.Orchestration == nil || workload.Spec.Orchestration.WorkflowRetention == nil ||
+		*workload.Spec.Orchestration.WorkflowRetention == "" {
+		return WorkflowRetentionDelete
+	}
+	return *workload.Spec.Orchestration.WorkflowRetention
+}
+
+// buildPodSpecPatch renders spec.resources as an Argo podSpecPatch for the "main" container.
+// Returns an empty string when no resources are set.
+func buildPodSpecPatch(resources *agenticv1alpha1.ResourceSpec) (string, error) {
+	if resources == nil {
+		return "", nil
+	}
+
+	requirements := map[string]interface{}{}
+	if list := resourceList(resources.Requests); len(list) > 0 {
+		requirements["requests"] = list
+	}
+	if list := resourceList(resources.Limits); len(list) > 0 {
+		requirements["limits"] = list
+	}
+	if len(requirements) == 0 {
+		return "", nil
+	}
+
+	return toJSON(map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "main", "resources": requirements},
+		},
+	})
+}
+
+// resourceList converts ResourceRequirements to a cpu/memory map, skipping unset values
+func resourceList(req *agenticv1alpha1.ResourceRequirements) map[string]string {
+	list := map[string]string{}
+	if req == nil {
+		return list
+	}
+	if req.CPU != nil && *req.CPU != "" {
+		list["cpu"] = *req.CPU
+	}
+	if req.Memory != nil && *req.Memory != "" {
+		list["memory"] = *req.Memory
+	}
+	return list
+}
+
+// findSuspendedNode returns the display name and start time of the oldest Suspend node
+// that is still waiting for approval, or ("", nil) when nothing is suspended
+func findSuspendedNode(workflow *unstructured.Unstructured) (string, *v1.Time) {
+	nodes, found, err := unstructured.NestedMap(workflow.Object, "status", "nodes")
+	if err != nil || !found {
+		return "", nil
+	}
+
+	var (
+		oldestName string
+		oldest     *v1.Time
+	)
+	for id, node := range nodes {
+		nodeMap, ok := node.(map[string]interface{})
+		if !ok || nodeMap["type"] != "Suspend" || nodeMap["phase"] != "Running" {
+			continue
+		}
+		startedAt, ok := nodeMap["startedAt"].(string)
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, startedAt)
+		if err != nil {
+			continue
+		}
+		if oldest == nil || t.Before(oldest.Time) {
+			metaTime := v1.NewTime(t)
+			oldest = &metaTime
+			oldestName = id
+			if displayName, ok := nodeMap["displayName"].(string); ok && displayName != "" {
+				oldestName = displayName
+			}
+		}
+	}
+	return oldestName, oldest
+}
+
+// stepNodes extracts the step nodes from status.nodes, ordered by start time
+func stepNodes(nodes map[string]interface{}) []NodeStatus {
+	steps := make([]NodeStatus, 0, len(nodes))
+	for id, node := range nodes {
+		nodeMap, ok := node.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		nodeType, _ := nodeMap["type"].(string)
+		if groupNodeTypes[nodeType] {
+			continue
+		}
+
+		step := NodeStatus{ID: id, Type: nodeType}
+		step.Name, _ = nodeMap["displayName"].(string)
+		if step.Name == "" {
+			step.Name = id
+		}
+		step.Phase, _ = nodeMap["phase"].(string)
+		step.Message, _ = nodeMap["message"].(string)
+		step.StartedAt = nodeTime(nodeMap, "startedAt")
+		step.FinishedAt = nodeTime(nodeMap, "finishedAt")
+		step.Artifacts = outputArtifacts(nodeMap)
+		steps = append(steps, step)
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		a, b := steps[i].StartedAt, steps[j].StartedAt
+		switch {
+		case a == nil && b == nil:
+			return steps[i].ID < steps[j].ID
+		case a == nil || b == nil:
+			return b == nil
+		case a.Equal(b):
+			return steps[i].ID < steps[j].ID
+		default:
+			return a.Before(b)
+		}
+	})
+	return steps
+}
+
+// outputArtifacts returns the S3 artifacts listed under a node's outputs.artifacts
+func outputArtifacts(node map[string]interface{}) []ArtifactLocation {
+	artifacts, found, err := unstructured.NestedSlice(node, "outputs", "artifacts")
+	if err != nil || !found {
+		return nil
+	}
+
+	var locations []ArtifactLocation
+	for _, artifact := range artifacts {
+		artifactMap, ok := artifact.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key, found, err := unstructured.NestedString(artifactMap, "s3", "key")
+		if err != nil || !found || key == "" {
+			continue
+		}
+		bucket, _, _ := unstructured.NestedString(artifactMap, "s3", "bucket")
+		name, _ := artifactMap["name"].(string)
+		locations = append(locations, ArtifactLocation{Name: name, Bucket: bucket, Key: key})
+	}
+	return locations
+}
+
+// nodeTime parses an RFC3339 timestamp field of a node
+func nodeTime(node map[string]interface{}, field string) *v1.Time {
+	value, ok := node[field].(string)
+	if !ok || value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	metaTime := v1.NewTime(t)
+	return &metaTime
+}
+
+// ActiveDeadlineSeconds returns spec.timeouts.execution, or 0 when the workload sets no
+// execution timeout and runs without a deadline
+func ActiveDeadlineSeconds(workload *agenticv1alpha1.AgentWorkload) int64 {
+	if workload.Spec.Timeouts != nil && workload.Spec.Timeouts.Execution != nil && *workload.Spec.Timeouts.Execution > 0 {
+		return int64(*workload.Spec.Timeouts.Execution)
+	}
+	return 0
+}
+
 // countNodesByPhase counts nodes in a specific phase
 func countNodesByPhase(nodes map[string]interface{}, phase string) int {
 	count := 0
@@ -447,6 +934,23 @@ func ptr(b bool) *bool {
 	return &b
 }
 
+// Parameter is a named agent runtime parameter
+type Parameter struct {
+	Name  string
+	Value string
+}
+
+// PersonaParameters returns the PERSONA_* parameters read by the agent runtime, in a stable order
+func PersonaParameters(workload *agenticv1alpha1.AgentWorkload) []Parameter {
+	return []Parameter{
+		{Name: "PERSONA_ROLE", Value: personaRole(workload)},
+		{Name: "PERSONA_TONE", Value: personaTone(workload)},
+		{Name: "PERSONA_MEMORY_SCOPE", Value: personaMemoryScope(workload)},
+		{Name: "PERSONA_SYSTEM_PROMPT_APPEND", Value: personaSystemPromptAppend(workload)},
+		{Name: "PERSONA_TOOL_PROFILE", Value: personaToolProfileJSON(workload)},
+	}
+}
+
 func personaRole(workload *agenticv1alpha1.AgentWorkload) string {
 	if workload.Spec.Persona == nil {
 		return ""
@@ -486,20 +990,27 @@ func personaToolProfileJSON(workload *agenticv1alpha1.AgentWorkload) string {
 	return string(b)
 }
 
-// ValidateWorkflowTemplate checks if the WorkflowTemplate exists in the cluster
+// ValidateWorkflowTemplate checks if the default WorkflowTemplate exists in the cluster
 // Returns error if template not found or validation failed
 func (wm *WorkflowManager) ValidateWorkflowTemplate(ctx context.Context) error {
+	return wm.ValidateWorkflowTemplateRef(ctx, DefaultWorkflowTemplate, DefaultWorkflowNamespace)
+}
+
+// ValidateWorkflowTemplateRef checks that the named WorkflowTemplate exists in namespace.
+// Called before a Workflow is submitted so a bad reference fails fast instead of
+// producing a Workflow Argo cannot run.
+func (wm *WorkflowManager) ValidateWorkflowTemplateRef(ctx context.Context, name, namespace string) error {
 	log := logf.FromContext(ctx)
 
 	template := &unstructured.Unstructured{}
 	template.SetAPIVersion(WorkflowGroupVersion)
 	template.SetKind(WorkflowTemplateKind)
 
-	if err := wm.client.Get(ctx, types.NamespacedName{Name: DefaultWorkflowTemplate, Namespace: DefaultWorkflowNamespace}, template); err != nil {
-		log.Error(err, "WorkflowTemplate validation failed", "name", DefaultWorkflowTemplate, "namespace", DefaultWorkflowNamespace)
-		return fmt.Errorf("WorkflowTemplate %s not found in namespace %s: %w", DefaultWorkflowTemplate, DefaultWorkflowNamespace, err)
+	if err := wm.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, template); err != nil {
+		log.Error(err, "WorkflowTemplate validation failed", "name", name, "namespace", namespace)
+		return fmt.Errorf("WorkflowTemplate %s not found in namespace %s: %w", name, namespace, err)
 	}
 
-	log.Info("WorkflowTemplate validation passed", "name", DefaultWorkflowTemplate)
+	log.Info("WorkflowTemplate validation passed", "name", name, "namespace", namespace)
 	return nil
 }
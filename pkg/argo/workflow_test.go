@@ -18,13 +18,18 @@ package argo
 
 import (
 	"context"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
 
 	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
 )
@@ -96,22 +101,14 @@ func TestWorkflowManager_CreateArgoWorkflow(t *testing.T) {
 		t.Errorf("job-id label = %q, want %q", labels["agentic.io/job-id"], "test-job-001")
 	}
 
-	// Check ownerReference is set
-	owners := workflow.GetOwnerReferences()
-	if len(owners) != 1 {
-		t.Errorf("ownerReferences count = %d, want 1", len(owners))
+	if labels[WorkloadNamespaceLabel] != "default" {
+		t.Errorf("workload-namespace label = %q, want %q", labels[WorkloadNamespaceLabel], "default")
 	}
 
-	if len(owners) > 0 {
-		if owners[0].Kind != "AgentWorkload" {
-			t.Errorf("owner Kind = %q, want %q", owners[0].Kind, "AgentWorkload")
-		}
-		if owners[0].Name != "test-job-001" {
-			t.Errorf("owner Name = %q, want %q", owners[0].Name, "test-job-001")
-		}
-		if *owners[0].Controller != true {
-			t.Errorf("owner Controller = %v, want true", owners[0].Controller)
-		}
+	// Cross-namespace ownerReferences are ignored by Kubernetes GC, so none is set;
+	// the AgentWorkload finalizer cleans the Workflow up instead
+	if owners := workflow.GetOwnerReferences(); len(owners) != 0 {
+		t.Errorf("ownerReferences count = %d, want 0 for a workflow in another namespace", len(owners))
 	}
 
 	// Check spec
@@ -205,6 +202,122 @@ func TestWorkflowManager_BuildWorkflowParameters(t *testing.T) {
 	t.Log("✓ BuildWorkflowParameters test passed")
 }
 
+// TestWorkflowManager_BuildWorkflowParameters_FromSpec verifies spec fields override defaults
+func TestWorkflowManager_BuildWorkflowParameters_FromSpec(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+	client := fake.NewClientBuilder().WithScheme(s).Build()
+	wm := NewWorkflowManager(client, s)
+
+	bucket := "reports"
+	prefix := "acme/2026"
+	script := "https://scripts.example.com/analyse.py"
+	workload := &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "spec-params"},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			TargetURLs:   []string{"https://acme.example.com", "https://acme.example.com/pricing"},
+			TargetBucket: &bucket,
+			TargetPrefix: &prefix,
+			ScriptUrl:    &script,
+		},
+	}
+
+	params := wm.buildWorkflowParameters(workload)
+
+	if len(params.TargetURLs) != 2 || params.TargetURLs[0] != "https://acme.example.com" {
+		t.Errorf("TargetURLs = %v, want spec.targetUrls", params.TargetURLs)
+	}
+
+	if params.MinioBucket != bucket {
+		t.Errorf("MinioBucket = %q, want %q", params.MinioBucket, bucket)
+	}
+
+	if params.TargetPrefix != prefix+"/" {
+		t.Errorf("TargetPrefix = %q, want %q", params.TargetPrefix, prefix+"/")
+	}
+
+	if params.ScriptURL != script {
+		t.Errorf("ScriptURL = %q, want %q", params.ScriptURL, script)
+	}
+
+	// No target URLs must not fall back to a placeholder site
+	params = wm.buildWorkflowParameters(&agenticv1alpha1.AgentWorkload{ObjectMeta: metav1.ObjectMeta{Name: "empty"}})
+	if len(params.TargetURLs) != 0 {
+		t.Errorf("TargetURLs = %v, want empty", params.TargetURLs)
+	}
+}
+
+// TestWorkflowManager_CreateArgoWorkflow_TemplateRefAndResources verifies template reference and pod resources
+func TestWorkflowManager_CreateArgoWorkflow_TemplateRefAndResources(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+	_ = agenticv1alpha1.AddToScheme(s)
+
+	client := fake.NewClientBuilder().WithScheme(s).Build()
+	wm := NewWorkflowManager(client, s)
+
+	argoType := "argo"
+	templateName := "research-template"
+	templateNamespace := "team-a"
+	cpu := "500m"
+	memory := "1Gi"
+	workload := &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "ref-job", Namespace: templateNamespace, UID: "uid-ref"},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			TargetURLs: []string{"https://acme.example.com"},
+			Orchestration: &agenticv1alpha1.OrchestrationSpec{
+				Type: &argoType,
+				WorkflowTemplateRef: &agenticv1alpha1.WorkflowTemplateRef{
+					Name:      &templateName,
+					Namespace: &templateNamespace,
+				},
+			},
+			Resources: &agenticv1alpha1.ResourceSpec{
+				Requests: &agenticv1alpha1.ResourceRequirements{CPU: &cpu},
+				Limits:   &agenticv1alpha1.ResourceRequirements{Memory: &memory},
+			},
+		},
+	}
+
+	workflow, err := wm.CreateArgoWorkflow(context.Background(), workload)
+	if err != nil {
+		t.Fatalf("CreateArgoWorkflow failed: %v", err)
+	}
+
+	if workflow.GetNamespace() != templateNamespace {
+		t.Errorf("workflow namespace = %q, want %q", workflow.GetNamespace(), templateNamespace)
+	}
+
+	if ref, _, _ := unstructured.NestedString(workflow.Object, "spec", "workflowTemplateRef", "name"); ref != templateName {
+		t.Errorf("workflowTemplateRef.name = %q, want %q", ref, templateName)
+	}
+
+	patch, found, _ := unstructured.NestedString(workflow.Object, "spec", "podSpecPatch")
+	if !found {
+		t.Fatal("podSpecPatch not set")
+	}
+	want := `{"containers":[{"name":"main","resources":{"limits":{"memory":"1Gi"},"requests":{"cpu":"500m"}}}]}`
+	if patch != want {
+		t.Errorf("podSpecPatch = %s, want %s", patch, want)
+	}
+
+	params, _, _ := unstructured.NestedSlice(workflow.Object, "spec", "arguments", "parameters")
+	values := map[string]interface{}{}
+	for _, p := range params {
+		if pm, ok := p.(map[string]interface{}); ok {
+			values[pm["name"].(string)] = pm["value"]
+		}
+	}
+	if values["target_urls"] != `["https://acme.example.com"]` {
+		t.Errorf("target_urls = %v, want spec.targetUrls", values["target_urls"])
+	}
+	for _, name := range []string{"target_prefix", "script_url"} {
+		if _, ok := values[name]; !ok {
+			t.Errorf("parameter %q not found", name)
+		}
+	}
+}
+
 func TestWorkflowManager_CreateArgoWorkflow_WithPersonaRoleLabel(t *testing.T) {
 	s := runtime.NewScheme()
 	_ = scheme.AddToScheme(s)
@@ -382,6 +495,373 @@ func TestWorkflowManager_IsWorkflowSuspended(t *testing.T) {
 	t.Log("✓ IsWorkflowSuspended tests passed")
 }
 
+// TestWorkflowManager_CreateArgoWorkflow_ActiveDeadline verifies the execution timeout is applied
+func TestWorkflowManager_CreateArgoWorkflow_ActiveDeadline(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+	_ = agenticv1alpha1.AddToScheme(s)
+
+	execution := int32(900)
+	tests := []struct {
+		name     string
+		timeouts *agenticv1alpha1.TimeoutSpec
+		expected int64
+	}{
+		{name: "no timeout", timeouts: nil, expected: 0},
+		{name: "workload execution timeout", timeouts: &agenticv1alpha1.TimeoutSpec{Execution: &execution}, expected: 900},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(s).Build()
+			wm := NewWorkflowManager(client, s)
+			workload := &agenticv1alpha1.AgentWorkload{
+				ObjectMeta: metav1.ObjectMeta{Name: "deadline-job", Namespace: "default", UID: "uid-deadline"},
+				Spec:       agenticv1alpha1.AgentWorkloadSpec{Timeouts: tt.timeouts},
+			}
+
+			workflow, err := wm.CreateArgoWorkflow(context.Background(), workload)
+			if err != nil {
+				t.Fatalf("CreateArgoWorkflow failed: %v", err)
+			}
+
+			deadline, found, err := unstructured.NestedInt64(workflow.Object, "spec", "activeDeadlineSeconds")
+			if err != nil || found != (tt.expected > 0) {
+				t.Fatalf("activeDeadlineSeconds: found=%v err=%v, want set only with a timeout", found, err)
+			}
+			if deadline != tt.expected {
+				t.Errorf("activeDeadlineSeconds = %d, want %d", deadline, tt.expected)
+			}
+		})
+	}
+}
+
+// TestWorkflowManager_GetArgoWorkflowStatus_SuspendNode verifies suspend gate detection from nodes
+func TestWorkflowManager_GetArgoWorkflowStatus_SuspendNode(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+
+	workflow := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": WorkflowGroupVersion,
+			"kind":       WorkflowKind,
+			"metadata": map[string]interface{}{
+				"name":      "suspended-workflow",
+				"namespace": "argo-workflows",
+			},
+			"status": map[string]interface{}{
+				"phase": "Running",
+				"nodes": map[string]interface{}{
+					"scraper": map[string]interface{}{
+						"type":      "Pod",
+						"phase":     "Succeeded",
+						"startedAt": "2026-02-24T00:00:00Z",
+					},
+					"approve-gate": map[string]interface{}{
+						"type":      "Suspend",
+						"phase":     "Running",
+						"startedAt": "2026-02-24T00:02:00Z",
+					},
+				},
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(s).WithObjects(workflow).Build()
+	wm := NewWorkflowManager(client, s)
+
+	status, err := wm.GetArgoWorkflowStatus(context.Background(), "suspended-workflow", "argo-workflows")
+	if err != nil {
+		t.Fatalf("GetArgoWorkflowStatus failed: %v", err)
+	}
+
+	if !status.IsSuspended {
+		t.Error("isSuspended = false, want true")
+	}
+
+	if status.SuspendedAt == nil || status.SuspendedAt.UTC().Format(time.RFC3339) != "2026-02-24T00:02:00Z" {
+		t.Errorf("suspendedAt = %v, want 2026-02-24T00:02:00Z", status.SuspendedAt)
+	}
+}
+
+// TestWorkflowManager_GetArgoWorkflowStatus_Nodes verifies per-step status and output artifacts
+func TestWorkflowManager_GetArgoWorkflowStatus_Nodes(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+
+	workflow := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": WorkflowGroupVersion,
+			"kind":       WorkflowKind,
+			"metadata": map[string]interface{}{
+				"name":      "failed-workflow",
+				"namespace": "argo-workflows",
+			},
+			"status": map[string]interface{}{
+				"phase":    "Failed",
+				"message":  "child 'failed-workflow-2' failed",
+				"progress": "1/2",
+				"nodes": map[string]interface{}{
+					"failed-workflow": map[string]interface{}{
+						"displayName": "failed-workflow",
+						"type":        "DAG",
+						"phase":       "Failed",
+						"startedAt":   "2026-02-24T00:00:00Z",
+					},
+					"failed-workflow-1": map[string]interface{}{
+						"displayName": "scraper",
+						"type":        "Pod",
+						"phase":       "Succeeded",
+						"startedAt":   "2026-02-24T00:00:01Z",
+						"finishedAt":  "2026-02-24T00:01:00Z",
+						"outputs": map[string]interface{}{
+							"artifacts": []interface{}{
+								map[string]interface{}{
+									"name": "raw-html",
+									"s3":   map[string]interface{}{"bucket": "research", "key": "job-1/raw_html.json"},
+								},
+								map[string]interface{}{
+									"name": "main-logs",
+									"s3":   map[string]interface{}{"key": "job-1/scraper/main.log"},
+								},
+							},
+						},
+					},
+					"failed-workflow-2": map[string]interface{}{
+						"displayName": "analyzer",
+						"type":        "Pod",
+						"phase":       "Failed",
+						"message":     "Error (exit code 1)",
+						"startedAt":   "2026-02-24T00:01:05Z",
+						"finishedAt":  "2026-02-24T00:01:30Z",
+					},
+				},
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(s).WithObjects(workflow).Build()
+	wm := NewWorkflowManager(client, s)
+
+	status, err := wm.GetArgoWorkflowStatus(context.Background(), "failed-workflow", "argo-workflows")
+	if err != nil {
+		t.Fatalf("GetArgoWorkflowStatus failed: %v", err)
+	}
+
+	if status.Progress != "1/2" {
+		t.Errorf("progress = %q, want 1/2", status.Progress)
+	}
+	if len(status.Nodes) != 2 {
+		t.Fatalf("nodes = %d, want 2 (DAG node omitted)", len(status.Nodes))
+	}
+	if status.Nodes[0].Name != "scraper" || status.Nodes[1].Name != "analyzer" {
+		t.Errorf("nodes = [%s %s], want [scraper analyzer]", status.Nodes[0].Name, status.Nodes[1].Name)
+	}
+	if status.Nodes[1].Message != "Error (exit code 1)" || status.Nodes[1].FinishedAt == nil {
+		t.Errorf("analyzer node = %+v, want message and finishedAt", status.Nodes[1])
+	}
+
+	artifacts := status.Nodes[0].Artifacts
+	if len(artifacts) != 2 {
+		t.Fatalf("artifacts = %d, want 2", len(artifacts))
+	}
+	if got := artifacts[0].URI("artifacts"); got != "s3://research/job-1/raw_html.json" {
+		t.Errorf("artifact URI = %q, want s3://research/job-1/raw_html.json", got)
+	}
+	if got := artifacts[1].URI("artifacts"); got != "s3://artifacts/job-1/scraper/main.log" {
+		t.Errorf("artifact URI = %q, want default bucket s3://artifacts/job-1/scraper/main.log", got)
+	}
+}
+
+// TestWorkflowManager_ResumeAndRejectArgoWorkflow verifies suspend nodes are resolved like the argo CLI
+func TestWorkflowManager_ResumeAndRejectArgoWorkflow(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+
+	newWorkflow := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": WorkflowGroupVersion,
+				"kind":       WorkflowKind,
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": "argo-workflows",
+				},
+				"spec": map[string]interface{}{"suspend": true},
+				"status": map[string]interface{}{
+					"phase": "Running",
+					"nodes": map[string]interface{}{
+						"scraper": map[string]interface{}{"type": "Pod", "phase": "Succeeded"},
+						"gate": map[string]interface{}{
+							"displayName": "approval-gate",
+							"type":        "Suspend",
+							"phase":       "Running",
+							"startedAt":   "2026-02-24T00:02:00Z",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	client := fake.NewClientBuilder().WithScheme(s).WithObjects(newWorkflow("resume-me"), newWorkflow("reject-me")).Build()
+	wm := NewWorkflowManager(client, s)
+	ctx := context.Background()
+
+	status, err := wm.GetArgoWorkflowStatus(ctx, "resume-me", "argo-workflows")
+	if err != nil {
+		t.Fatalf("GetArgoWorkflowStatus failed: %v", err)
+	}
+	if status.SuspendedNode != "approval-gate" {
+		t.Errorf("suspendedNode = %q, want %q", status.SuspendedNode, "approval-gate")
+	}
+
+	if err := wm.ResumeArgoWorkflow(ctx, "resume-me", "argo-workflows"); err != nil {
+		t.Fatalf("ResumeArgoWorkflow failed: %v", err)
+	}
+	// Idempotent: nothing left to resume
+	if err := wm.ResumeArgoWorkflow(ctx, "resume-me", "argo-workflows"); err != nil {
+		t.Fatalf("second ResumeArgoWorkflow failed: %v", err)
+	}
+	if err := wm.RejectArgoWorkflow(ctx, "reject-me", "argo-workflows", "Rejected by bob"); err != nil {
+		t.Fatalf("RejectArgoWorkflow failed: %v", err)
+	}
+
+	get := func(name string) *unstructured.Unstructured {
+		got := &unstructured.Unstructured{}
+		got.SetAPIVersion(WorkflowGroupVersion)
+		got.SetKind(WorkflowKind)
+		if err := client.Get(ctx, types.NamespacedName{Name: name, Namespace: "argo-workflows"}, got); err != nil {
+			t.Fatalf("failed to get workflow %s: %v", name, err)
+		}
+		return got
+	}
+
+	resumed := get("resume-me")
+	if phase, _, _ := unstructured.NestedString(resumed.Object, "status", "nodes", "gate", "phase"); phase != "Succeeded" {
+		t.Errorf("resumed gate phase = %q, want Succeeded", phase)
+	}
+	if _, found, _ := unstructured.NestedString(resumed.Object, "status", "nodes", "gate", "finishedAt"); !found {
+		t.Error("resumed gate finishedAt not set")
+	}
+	if _, found, _ := unstructured.NestedBool(resumed.Object, "spec", "suspend"); found {
+		t.Error("spec.suspend should be cleared on resume")
+	}
+	if phase, _, _ := unstructured.NestedString(resumed.Object, "status", "nodes", "scraper", "phase"); phase != "Succeeded" {
+		t.Errorf("non-suspend node phase = %q, want unchanged Succeeded", phase)
+	}
+
+	rejected := get("reject-me")
+	if phase, _, _ := unstructured.NestedString(rejected.Object, "status", "nodes", "gate", "phase"); phase != "Failed" {
+		t.Errorf("rejected gate phase = %q, want Failed", phase)
+	}
+	if message, _, _ := unstructured.NestedString(rejected.Object, "status", "nodes", "gate", "message"); message != "Rejected by bob" {
+		t.Errorf("rejected gate message = %q, want %q", message, "Rejected by bob")
+	}
+}
+
+// TestWorkflowManager_CreateArgoWorkflow_SameNamespaceOwner verifies ownerReference and artifact GC
+func TestWorkflowManager_CreateArgoWorkflow_SameNamespaceOwner(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+	_ = agenticv1alpha1.AddToScheme(s)
+
+	client := fake.NewClientBuilder().WithScheme(s).Build()
+	wm := NewWorkflowManager(client, s)
+
+	templateName := DefaultWorkflowTemplate
+	namespace := "team-a"
+	workload := &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "owned-job", Namespace: namespace, UID: "uid-owned"},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			Orchestration: &agenticv1alpha1.OrchestrationSpec{
+				WorkflowTemplateRef: &agenticv1alpha1.WorkflowTemplateRef{Name: &templateName, Namespace: &namespace},
+			},
+		},
+	}
+
+	workflow, err := wm.CreateArgoWorkflow(context.Background(), workload)
+	if err != nil {
+		t.Fatalf("CreateArgoWorkflow failed: %v", err)
+	}
+
+	owners := workflow.GetOwnerReferences()
+	if len(owners) != 1 {
+		t.Fatalf("ownerReferences count = %d, want 1", len(owners))
+	}
+	if owners[0].Kind != "AgentWorkload" || owners[0].Name != "owned-job" || !*owners[0].Controller {
+		t.Errorf("unexpected owner %+v", owners[0])
+	}
+
+	if strategy, _, _ := unstructured.NestedString(workflow.Object, "spec", "artifactGC", "strategy"); strategy != "OnWorkflowDeletion" {
+		t.Errorf("artifactGC.strategy = %q, want %q", strategy, "OnWorkflowDeletion")
+	}
+
+	retain := WorkflowRetentionRetain
+	workload.Name = "retained-job"
+	workload.Spec.Orchestration.WorkflowRetention = &retain
+	workflow, err = wm.CreateArgoWorkflow(context.Background(), workload)
+	if err != nil {
+		t.Fatalf("CreateArgoWorkflow failed: %v", err)
+	}
+	if _, found, _ := unstructured.NestedString(workflow.Object, "spec", "artifactGC", "strategy"); found {
+		t.Error("artifactGC must not be set when workflow history is retained")
+	}
+}
+
+// TestWorkflowManager_DeleteAndArchiveArgoWorkflow verifies finalizer cleanup helpers
+func TestWorkflowManager_DeleteAndArchiveArgoWorkflow(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+
+	newWorkflow := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": WorkflowGroupVersion,
+				"kind":       WorkflowKind,
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": "argo-workflows",
+					"ownerReferences": []interface{}{
+						map[string]interface{}{"apiVersion": "agentic.clawdlinux.org/v1alpha1", "kind": "AgentWorkload", "name": name, "uid": "uid"},
+					},
+				},
+			},
+		}
+	}
+
+	client := fake.NewClientBuilder().WithScheme(s).WithObjects(newWorkflow("to-delete"), newWorkflow("to-archive")).Build()
+	wm := NewWorkflowManager(client, s)
+	ctx := context.Background()
+
+	if err := wm.DeleteArgoWorkflow(ctx, "to-delete", "argo-workflows"); err != nil {
+		t.Fatalf("DeleteArgoWorkflow failed: %v", err)
+	}
+	if err := wm.DeleteArgoWorkflow(ctx, "to-delete", "argo-workflows"); err != nil {
+		t.Errorf("DeleteArgoWorkflow should ignore a missing workflow: %v", err)
+	}
+
+	if err := wm.ArchiveArgoWorkflow(ctx, "to-archive", "argo-workflows"); err != nil {
+		t.Fatalf("ArchiveArgoWorkflow failed: %v", err)
+	}
+
+	got := &unstructured.Unstructured{}
+	got.SetAPIVersion(WorkflowGroupVersion)
+	got.SetKind(WorkflowKind)
+	if err := client.Get(ctx, types.NamespacedName{Name: "to-delete", Namespace: "argo-workflows"}, got); err == nil {
+		t.Error("expected deleted workflow to be gone")
+	}
+	if err := client.Get(ctx, types.NamespacedName{Name: "to-archive", Namespace: "argo-workflows"}, got); err != nil {
+		t.Fatalf("archived workflow not found: %v", err)
+	}
+	if got.GetLabels()[ArchivedLabel] != "true" {
+		t.Errorf("archived label = %q, want %q", got.GetLabels()[ArchivedLabel], "true")
+	}
+	if len(got.GetOwnerReferences()) != 0 {
+		t.Errorf("ownerReferences count = %d, want 0 after archive", len(got.GetOwnerReferences()))
+	}
+}
+
 // TestWorkflowManager_ValidateWorkflowTemplate verifies template validation
 func TestWorkflowManager_ValidateWorkflowTemplate(t *testing.T) {
 	s := runtime.NewScheme()
@@ -431,6 +911,35 @@ func TestWorkflowManager_ValidateWorkflowTemplateMissing(t *testing.T) {
 	t.Log("✓ ValidateWorkflowTemplateMissing test passed")
 }
 
+// TestWorkflowManager_ValidateWorkflowTemplateRef verifies validation of a referenced template
+func TestWorkflowManager_ValidateWorkflowTemplateRef(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+
+	template := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": WorkflowGroupVersion,
+			"kind":       WorkflowTemplateKind,
+			"metadata": map[string]interface{}{
+				"name":      "research-template",
+				"namespace": "team-a",
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(s).WithObjects(template).Build()
+	wm := NewWorkflowManager(client, s)
+
+	ctx := context.Background()
+	if err := wm.ValidateWorkflowTemplateRef(ctx, "research-template", "team-a"); err != nil {
+		t.Errorf("ValidateWorkflowTemplateRef failed: %v", err)
+	}
+
+	if err := wm.ValidateWorkflowTemplateRef(ctx, "research-template", DefaultWorkflowNamespace); err == nil {
+		t.Error("ValidateWorkflowTemplateRef should fail for a template in another namespace")
+	}
+}
+
 // TestToJSON verifies JSON marshaling
 func TestToJSON(t *testing.T) {
 	tests := []struct {
@@ -504,3 +1013,80 @@ func BenchmarkWorkflowManager_CreateArgoWorkflow(b *testing.B) {
 		_, _ = wm.CreateArgoWorkflow(ctx, workload)
 	}
 }
+
+// TestResolveWorkflowTemplateRef_RestrictsNamespace verifies Workflows cannot be created in
+// namespaces other than the workload's own or the Argo namespace
+func TestResolveWorkflowTemplateRef_RestrictsNamespace(t *testing.T) {
+	for _, tt := range []struct {
+		namespace string
+		allowed   bool
+	}{
+		{namespace: "", allowed: true},
+		{namespace: "team-a", allowed: true},
+		{namespace: DefaultWorkflowNamespace, allowed: true},
+		{namespace: "kube-system", allowed: false},
+	} {
+		namespace := tt.namespace
+		workload := &agenticv1alpha1.AgentWorkload{
+			ObjectMeta: metav1.ObjectMeta{Name: "ref", Namespace: "team-a"},
+			Spec: agenticv1alpha1.AgentWorkloadSpec{Orchestration: &agenticv1alpha1.OrchestrationSpec{
+				WorkflowTemplateRef: &agenticv1alpha1.WorkflowTemplateRef{Namespace: &namespace},
+			}},
+		}
+		_, _, err := ResolveWorkflowTemplateRef(workload)
+		if (err == nil) != tt.allowed {
+			t.Errorf("namespace %q: err = %v, want allowed=%v", tt.namespace, err, tt.allowed)
+		}
+	}
+}
+
+// TestDefaultWorkflowTemplate_UsesSpecParameters verifies every container step of the
+// default template receives target_prefix and script_url and prefixes its artifact keys
+func TestDefaultWorkflowTemplate_UsesSpecParameters(t *testing.T) {
+	data, err := os.ReadFile("../../config/argo/workflowtemplate.yaml")
+	if err != nil {
+		t.Fatalf("failed to read template: %v", err)
+	}
+	template := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(data, &template.Object); err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+
+	templates, _, _ := unstructured.NestedSlice(template.Object, "spec", "templates")
+	containers := 0
+	for _, tmpl := range templates {
+		tmplMap := tmpl.(map[string]interface{})
+		container, found, _ := unstructured.NestedMap(tmplMap, "container")
+		if !found {
+			continue
+		}
+		containers++
+		name := tmplMap["name"]
+
+		env := map[string]string{}
+		envList, _, _ := unstructured.NestedSlice(container, "env")
+		for _, e := range envList {
+			envMap := e.(map[string]interface{})
+			value, _ := envMap["value"].(string)
+			env[envMap["name"].(string)] = value
+		}
+		if env["TARGET_PREFIX"] != "{{inputs.parameters.target_prefix}}" || env["SCRIPT_URL"] != "{{inputs.parameters.script_url}}" {
+			t.Errorf("template %v does not pass target_prefix and script_url: %v", name, env)
+		}
+
+		artifacts, _, _ := unstructured.NestedSlice(tmplMap, "outputs", "artifacts")
+		for _, artifact := range artifacts {
+			s3, _, _ := unstructured.NestedMap(artifact.(map[string]interface{}), "s3")
+			key, _ := s3["key"].(string)
+			if key == "" {
+				key, _ = s3["keyPrefix"].(string)
+			}
+			if !strings.HasPrefix(key, "{{inputs.parameters.target_prefix}}") {
+				t.Errorf("template %v artifact key %q does not start with target_prefix", name, key)
+			}
+		}
+	}
+	if containers == 0 {
+		t.Fatal("expected container templates")
+	}
+}
@@ -0,0 +1,414 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jobs runs AgentWorkloads as native Kubernetes Jobs, for clusters without Argo Workflows.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/argo"
+)
+
+const (
+	// StepIndexLabel records the position of a Job in the workload's step sequence
+	StepIndexLabel = "agentic.io/step-index"
+
+	// StepNameLabel records the step a Job runs
+	StepNameLabel = "agentic.io/step"
+
+	// DefaultStepName names the single step run when spec.orchestration.job.steps is empty
+	DefaultStepName = "agent"
+
+	// DefaultBackoffLimit is the number of retries of a step's Job
+	DefaultBackoffLimit = 1
+
+	// ContainerName is the name of the agent container, matching the Argo templates
+	ContainerName = "main"
+
+	// Sequence phases, named after the Argo Workflow phases they correspond to
+	PhasePending   = "Pending"
+	PhaseRunning   = "Running"
+	PhaseSucceeded = "Succeeded"
+	PhaseFailed    = "Failed"
+)
+
+// JobManager creates and tracks the Jobs that run an AgentWorkload
+type JobManager struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+// NewJobManager creates a new JobManager
+func NewJobManager(c client.Client, s *runtime.Scheme) *JobManager {
+	return &JobManager{
+		client: c,
+		scheme: s,
+	}
+}
+
+// StepStatus represents the status of a single step's Job
+type StepStatus struct {
+	Name       string
+	JobName    string
+	Phase      string // Pending, Running, Succeeded, Failed
+	Message    string
+	StartedAt  *v1.Time
+	FinishedAt *v1.Time
+}
+
+// SequenceStatus represents the progress of a workload's step sequence
+type SequenceStatus struct {
+	Phase     string // Pending, Running, Succeeded, Failed
+	Message   string
+	Steps     []StepStatus // One entry per step that has a Job
+	Completed int          // Number of steps that succeeded
+	Total     int          // Number of steps in the sequence
+}
+
+// Steps returns the workload's step sequence: spec.orchestration.job.steps, or a single
+// step running the image entrypoint
+func Steps(workload *agenticv1alpha1.AgentWorkload) []agenticv1alpha1.JobStep {
+	if spec := jobSpec(workload); spec != nil && len(spec.Steps) > 0 {
+		return spec.Steps
+	}
+	return []agenticv1alpha1.JobStep{{Name: DefaultStepName}}
+}
+
+// JobName returns the name of the Job running step index of the workload. A single-step
+// sequence uses the workload name, like the Argo Workflow does.
+func JobName(workload *agenticv1alpha1.AgentWorkload, index int, step agenticv1alpha1.JobStep) string {
+	suffix := ""
+	if len(Steps(workload)) > 1 {
+		suffix = fmt.Sprintf("-%d-%s", index, step.Name)
+	}
+	name := workload.Name
+	// Job names become pod labels, which are limited to 63 characters. A hash of the full
+	// workload name keeps workloads that share a long prefix from mapping to the same Job.
+	if len(name)+len(suffix) > 63 {
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(workload.Name))
+		short := fmt.Sprintf("-%08x", hash.Sum32())
+		name = strings.TrimRight(name[:63-len(suffix)-len(short)], "-.") + short
+	}
+	return name + suffix
+}
+
+// ReconcileJobSequence advances the workload's step sequence and reports its progress
+//
+// This function:
+// 1. Fetches the Job of each step in order
+// 2. Creates the Job of the first step that has none, once every earlier step succeeded
+// 3. Stops at the first step that is still running or has failed
+//
+// Important: This operation is idempotent. Jobs are identified by name, so a
+// reconcile that races with an earlier one finds the existing Job.
+func (jm *JobManager) ReconcileJobSequence(
+	ctx context.Context,
+	workload *agenticv1alpha1.AgentWorkload,
+) (*SequenceStatus, error) {
+	log := logf.FromContext(ctx)
+
+	steps := Steps(workload)
+	status := &SequenceStatus{Phase: PhaseSucceeded, Total: len(steps)}
+
+	for i, step := range steps {
+		name := JobName(workload, i, step)
+		job := &batchv1.Job{}
+		err := jm.client.Get(ctx, client.ObjectKey{Name: name, Namespace: workload.Namespace}, job)
+		if apierrors.IsNotFound(err) {
+			job, err = jm.createStepJob(ctx, workload, i, step)
+			if err != nil {
+				return nil, err
+			}
+			log.Info("Created step Job", "job", name, "step", step.Name, "index", i)
+		} else if err != nil {
+			log.Error(err, "failed to get step Job", "job", name)
+			return nil, err
+		} else if !v1.IsControlledBy(job, workload) {
+			return nil, fmt.Errorf("job %s/%s exists but is not owned by AgentWorkload %s", workload.Namespace, name, workload.Name)
+		}
+
+		stepStatus := jobStepStatus(step.Name, job)
+		status.Steps = append(status.Steps, stepStatus)
+		switch stepStatus.Phase {
+		case PhaseSucceeded:
+			status.Completed++
+			continue
+		case PhaseFailed:
+			status.Phase = PhaseFailed
+			status.Message = fmt.Sprintf("step %q failed", step.Name)
+			if stepStatus.Message != "" {
+				status.Message += ": " + stepStatus.Message
+			}
+		default:
+			status.Phase = stepStatus.Phase
+		}
+		return status, nil
+	}
+	return status, nil
+}
+
+// createStepJob creates the Job for one step; an existing Job of the same name is returned as
+// is when this workload owns it
+func (jm *JobManager) createStepJob(
+	ctx context.Context,
+	workload *agenticv1alpha1.AgentWorkload,
+	index int,
+	step agenticv1alpha1.JobStep,
+) (*batchv1.Job, error) {
+	job, err := BuildJob(workload, index, step)
+	if err != nil {
+		return nil, err
+	}
+	// Jobs live in the workload namespace, so ownerReference garbage collection applies
+	if err := controllerutil.SetControllerReference(workload, job, jm.scheme); err != nil {
+		return nil, err
+	}
+	if err := jm.client.Create(ctx, job); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		existing := &batchv1.Job{}
+		if err := jm.client.Get(ctx, client.ObjectKeyFromObject(job), existing); err != nil {
+			return nil, err
+		}
+		if !v1.IsControlledBy(existing, workload) {
+			return nil, fmt.Errorf("job %s/%s exists but is not owned by AgentWorkload %s", job.Namespace, job.Name, workload.Name)
+		}
+		job = existing
+	}
+	return job, nil
+}
+
+// BuildJob builds the Job that runs one step of the workload with the agent runtime image.
+// The container receives the same parameters as the Argo templates, as environment variables.
+func BuildJob(workload *agenticv1alpha1.AgentWorkload, index int, step agenticv1alpha1.JobStep) (*batchv1.Job, error) {
+	params := argo.BuildWorkflowParameters(workload)
+	targetURLs, err := jsonString(params.TargetURLs)
+	if err != nil {
+		return nil, err
+	}
+
+	image := params.AgentImage
+	backoffLimit := int32(DefaultBackoffLimit)
+	if spec := jobSpec(workload); spec != nil {
+		if spec.Image != nil && *spec.Image != "" {
+			image = *spec.Image
+		}
+		if spec.BackoffLimit != nil {
+			backoffLimit = *spec.BackoffLimit
+		}
+	}
+
+	resources, err := resourceRequirements(workload.Spec.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "JOB_ID", Value: params.JobID},
+		{Name: "TARGET_URLS", Value: targetURLs},
+		{Name: "MINIO_BUCKET", Value: params.MinioBucket},
+		{Name: "TARGET_PREFIX", Value: params.TargetPrefix},
+		{Name: "SCRIPT_URL", Value: params.ScriptURL},
+		{Name: "BROWSERLESS_URL", Value: params.BrowserlessURL},
+		{Name: "LITELLM_URL", Value: params.LiteLLMURL},
+		{Name: "POSTGRES_DSN", Value: params.PostgresDSN},
+		{Name: "STEP_NAME", Value: step.Name},
+		{Name: "STEP_INDEX", Value: strconv.Itoa(index)},
+		{Name: "LOG_LEVEL", Value: "INFO"},
+	}
+	for _, persona := range argo.PersonaParameters(workload) {
+		env = append(env, corev1.EnvVar{Name: persona.Name, Value: persona.Value})
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":    "agentic-k8s-operator",
+		"app.kubernetes.io/part-of": "agentic-k8s-operator",
+		argo.JobIDLabel:             params.JobID,
+		StepNameLabel:               step.Name,
+		StepIndexLabel:              strconv.Itoa(index),
+		"agentic.io/source":         "agentworkload-controller",
+	}
+	if workload.Spec.Persona != nil && workload.Spec.Persona.Role != "" {
+		labels["agentworkload.clawdlinux.io/role"] = workload.Spec.Persona.Role
+	}
+
+	optional := true
+	job := &batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      JobName(workload, index, step),
+			Namespace: workload.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: ptr(true),
+						RunAsUser:    ptr(int64(1000)),
+					},
+					Containers: []corev1.Container{{
+						Name:            ContainerName,
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         step.Command,
+						Args:            step.Args,
+						Env:             env,
+						Resources:       resources,
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr(false),
+							ReadOnlyRootFilesystem:   ptr(true),
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "minio-credentials", MountPath: "/etc/secrets/minio", ReadOnly: true},
+							{Name: "tmp", MountPath: "/tmp"},
+							{Name: "home", MountPath: "/home/agent"},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{Name: "minio-credentials", VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{SecretName: "minio-credentials", Optional: &optional},
+						}},
+						{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+						{Name: "home", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					},
+				},
+			},
+		},
+	}
+	if deadline := argo.ActiveDeadlineSeconds(workload); deadline > 0 {
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	return job, nil
+}
+
+// jobStepStatus derives a step's phase from its Job's conditions
+func jobStepStatus(stepName string, job *batchv1.Job) StepStatus {
+	status := StepStatus{
+		Name:       stepName,
+		JobName:    job.Name,
+		Phase:      PhasePending,
+		StartedAt:  job.Status.StartTime,
+		FinishedAt: job.Status.CompletionTime,
+	}
+	if job.Status.Active > 0 {
+		status.Phase = PhaseRunning
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			status.Phase = PhaseSucceeded
+			status.Message = cond.Message
+		case batchv1.JobFailed:
+			status.Phase = PhaseFailed
+			status.Message = cond.Reason
+			if cond.Message != "" {
+				status.Message = cond.Reason + ": " + cond.Message
+			}
+			if status.FinishedAt == nil {
+				finishedAt := cond.LastTransitionTime
+				status.FinishedAt = &finishedAt
+			}
+		}
+	}
+	return status
+}
+
+// resourceRequirements converts spec.resources to container resource requirements
+func resourceRequirements(resources *agenticv1alpha1.ResourceSpec) (corev1.ResourceRequirements, error) {
+	var requirements corev1.ResourceRequirements
+	if resources == nil {
+		return requirements, nil
+	}
+	var err error
+	if requirements.Requests, err = resourceList(resources.Requests); err != nil {
+		return requirements, fmt.Errorf("invalid resources.requests: %w", err)
+	}
+	if requirements.Limits, err = resourceList(resources.Limits); err != nil {
+		return requirements, fmt.Errorf("invalid resources.limits: %w", err)
+	}
+	return requirements, nil
+}
+
+func resourceList(req *agenticv1alpha1.ResourceRequirements) (corev1.ResourceList, error) {
+	if req == nil {
+		return nil, nil
+	}
+	list := corev1.ResourceList{}
+	if req.CPU != nil && *req.CPU != "" {
+		q, err := resource.ParseQuantity(*req.CPU)
+		if err != nil {
+			return nil, fmt.Errorf("cpu: %w", err)
+		}
+		list[corev1.ResourceCPU] = q
+	}
+	if req.Memory != nil && *req.Memory != "" {
+		q, err := resource.ParseQuantity(*req.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+		list[corev1.ResourceMemory] = q
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list, nil
+}
+
+// jsonString encodes a value as JSON, as the Argo target_urls parameter does
+func jsonString(value interface{}) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal to JSON: %w", err)
+	}
+	return string(b), nil
+}
+
+func jobSpec(workload *agenticv1alpha1.AgentWorkload) *agenticv1alpha1.JobOrchestrationSpec {
+	if workload.Spec.Orchestration == nil {
+		return nil
+	}
+	return workload.Spec.Orchestration.Job
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/argo"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatalf("failed adding core scheme: %v", err)
+	}
+	if err := agenticv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("failed adding agentic scheme: %v", err)
+	}
+	return s
+}
+
+func newJobWorkload(steps ...agenticv1alpha1.JobStep) *agenticv1alpha1.AgentWorkload {
+	jobType := "job"
+	cpu := "250m"
+	return &agenticv1alpha1.AgentWorkload{
+		ObjectMeta: metav1.ObjectMeta{Name: "research", Namespace: "team-a", UID: types.UID("research-uid")},
+		Spec: agenticv1alpha1.AgentWorkloadSpec{
+			TargetURLs: []string{"https://acme.example.com"},
+			Persona:    &agenticv1alpha1.AgentPersona{Role: "researcher", Tone: "concise"},
+			Resources:  &agenticv1alpha1.ResourceSpec{Requests: &agenticv1alpha1.ResourceRequirements{CPU: &cpu}},
+			Orchestration: &agenticv1alpha1.OrchestrationSpec{
+				Type: &jobType,
+				Job:  &agenticv1alpha1.JobOrchestrationSpec{Steps: steps},
+			},
+		},
+	}
+}
+
+func envValue(container corev1.Container, name string) string {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+// TestBuildJob verifies the Job passes the Argo parameters and persona to the runtime
+func TestBuildJob(t *testing.T) {
+	workload := newJobWorkload()
+
+	job, err := BuildJob(workload, 0, Steps(workload)[0])
+	if err != nil {
+		t.Fatalf("BuildJob failed: %v", err)
+	}
+
+	if job.Name != "research" || job.Namespace != "team-a" {
+		t.Errorf("job = %s/%s, want team-a/research", job.Namespace, job.Name)
+	}
+	if job.Labels[argo.JobIDLabel] != "research" {
+		t.Errorf("job-id label = %q, want research", job.Labels[argo.JobIDLabel])
+	}
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		t.Errorf("activeDeadlineSeconds = %d, want unset without spec.timeouts.execution", *job.Spec.ActiveDeadlineSeconds)
+	}
+
+	container := job.Spec.Template.Spec.Containers[0]
+	if container.Image != argo.DefaultAgentImage || container.Command != nil {
+		t.Errorf("container runs %s %v, want the default image entrypoint", container.Image, container.Command)
+	}
+	for name, want := range map[string]string{
+		"JOB_ID":       "research",
+		"TARGET_URLS":  `["https://acme.example.com"]`,
+		"MINIO_BUCKET": argo.DefaultMinioBucket,
+		"PERSONA_ROLE": "researcher",
+		"PERSONA_TONE": "concise",
+		"STEP_NAME":    DefaultStepName,
+	} {
+		if got := envValue(container, name); got != want {
+			t.Errorf("env %s = %q, want %q", name, got, want)
+		}
+	}
+	if got := container.Resources.Requests.Cpu().String(); got != "250m" {
+		t.Errorf("cpu request = %s, want 250m", got)
+	}
+}
+
+// TestJobName verifies step Job naming and truncation
+func TestJobName(t *testing.T) {
+	workload := newJobWorkload(agenticv1alpha1.JobStep{Name: "scraper"}, agenticv1alpha1.JobStep{Name: "synthesis"})
+	if got := JobName(workload, 1, workload.Spec.Orchestration.Job.Steps[1]); got != "research-1-synthesis" {
+		t.Errorf("JobName = %q, want research-1-synthesis", got)
+	}
+
+	workload.Name = strings.Repeat("a", 70)
+	first := JobName(workload, 0, workload.Spec.Orchestration.Job.Steps[0])
+	if len(first) != 63 || !strings.HasSuffix(first, "-0-scraper") {
+		t.Errorf("JobName = %q (%d chars), want 63 chars ending in -0-scraper", first, len(first))
+	}
+
+	workload.Name = strings.Repeat("a", 70) + "b"
+	if got := JobName(workload, 0, workload.Spec.Orchestration.Job.Steps[0]); got == first {
+		t.Errorf("JobName = %q for two workloads sharing a long prefix", got)
+	}
+
+	single := newJobWorkload()
+	single.Name = strings.Repeat("a", 70)
+	if got := JobName(single, 0, Steps(single)[0]); len(got) != 63 {
+		t.Errorf("JobName = %q (%d chars), want a single-step name truncated to 63", got, len(got))
+	}
+}
+
+// TestJobManager_ReconcileJobSequence_ForeignJob verifies a Job owned by another workload is not adopted
+func TestJobManager_ReconcileJobSequence_ForeignJob(t *testing.T) {
+	s := newTestScheme(t)
+	workload := newJobWorkload()
+	foreign := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: workload.Name, Namespace: workload.Namespace}}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(foreign).Build()
+
+	if _, err := NewJobManager(c, s).ReconcileJobSequence(context.Background(), workload); err == nil {
+		t.Fatal("expected an error for a Job not owned by the workload")
+	}
+}
+
+func setJobCondition(t *testing.T, c client.Client, name string, condType batchv1.JobConditionType, reason string) {
+	t.Helper()
+	job := &batchv1.Job{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "team-a"}, job); err != nil {
+		t.Fatalf("failed to get job %s: %v", name, err)
+	}
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type: condType, Status: corev1.ConditionTrue, Reason: reason,
+	})
+	if err := c.Status().Update(context.Background(), job); err != nil {
+		t.Fatalf("failed to update job %s: %v", name, err)
+	}
+}
+
+// TestJobManager_ReconcileJobSequence verifies steps run one after another
+func TestJobManager_ReconcileJobSequence(t *testing.T) {
+	s := newTestScheme(t)
+	workload := newJobWorkload(
+		agenticv1alpha1.JobStep{Name: "scraper", Command: []string{"python", "-m", "agents.scraper"}},
+		agenticv1alpha1.JobStep{Name: "synthesis"},
+	)
+	c := fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(&batchv1.Job{}).Build()
+	jm := NewJobManager(c, s)
+	ctx := context.Background()
+
+	status, err := jm.ReconcileJobSequence(ctx, workload)
+	if err != nil {
+		t.Fatalf("ReconcileJobSequence failed: %v", err)
+	}
+	if status.Phase != PhasePending || len(status.Steps) != 1 {
+		t.Fatalf("status = %+v, want first step pending", status)
+	}
+	job := &batchv1.Job{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "research-0-scraper", Namespace: "team-a"}, job); err != nil {
+		t.Fatalf("expected first step Job: %v", err)
+	}
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].Name != "research" {
+		t.Errorf("ownerReferences = %+v, want the AgentWorkload", job.OwnerReferences)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "research-1-synthesis", Namespace: "team-a"}, &batchv1.Job{}); err == nil {
+		t.Fatal("second step must not start before the first succeeded")
+	}
+
+	setJobCondition(t, c, "research-0-scraper", batchv1.JobComplete, "")
+	status, err = jm.ReconcileJobSequence(ctx, workload)
+	if err != nil {
+		t.Fatalf("ReconcileJobSequence failed: %v", err)
+	}
+	if status.Completed != 1 || len(status.Steps) != 2 || status.Steps[1].Name != "synthesis" {
+		t.Fatalf("status = %+v, want synthesis started after scraper", status)
+	}
+
+	setJobCondition(t, c, "research-1-synthesis", batchv1.JobFailed, "BackoffLimitExceeded")
+	status, err = jm.ReconcileJobSequence(ctx, workload)
+	if err != nil {
+		t.Fatalf("ReconcileJobSequence failed: %v", err)
+	}
+	if status.Phase != PhaseFailed || !strings.Contains(status.Message, `step "synthesis" failed: BackoffLimitExceeded`) {
+		t.Fatalf("status = %+v, want synthesis failure", status)
+	}
+}
@@ -0,0 +1,364 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultAnthropicEndpoint is the API base URL of Anthropic providers without an endpoint
+	DefaultAnthropicEndpoint = "https://api.anthropic.com/v1"
+
+	// DefaultAnthropicVersion is the anthropic-version header sent by default
+	DefaultAnthropicVersion = "2023-06-01"
+)
+
+// AnthropicProvider implements the Provider interface for Anthropic's native Messages API
+type AnthropicProvider struct {
+	name     string
+	endpoint string
+	apiKey   string
+	version  string
+}
+
+// NewAnthropicProvider creates a new Anthropic Messages API provider. An empty endpoint
+// or version uses DefaultAnthropicEndpoint or DefaultAnthropicVersion.
+func NewAnthropicProvider(name, endpoint, apiKey, version string) *AnthropicProvider {
+	if endpoint == "" {
+		endpoint = DefaultAnthropicEndpoint
+	}
+	if version == "" {
+		version = DefaultAnthropicVersion
+	}
+	return &AnthropicProvider{
+		name:     name,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		apiKey:   apiKey,
+		version:  version,
+	}
+}
+
+// Name returns the provider name
+func (p *AnthropicProvider) Name() string {
+	return p.name
+}
+
+// Type returns the provider type
+func (p *AnthropicProvider) Type() string {
+	return "anthropic"
+}
+
+// anthropicMessage is a message of a Messages API request. Content is a string, or a list
+// of anthropicContentBlock for tool use and tool results.
+type anthropicMessage struct {
+	Role    string      `json:"role"`
+	Content interface{} `json:"content"`
+}
+
+// anthropicContentBlock is a text, tool_use or tool_result content block
+type anthropicContentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+}
+
+// anthropicTool is a tool of a Messages API request
+type anthropicTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// anthropicToolChoice is the tool_choice of a Messages API request
+type anthropicToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+// anthropicRequest is the body of a Messages API request
+type anthropicRequest struct {
+	Model         string               `json:"model"`
+	System        string               `json:"system,omitempty"`
+	Messages      []anthropicMessage   `json:"messages"`
+	MaxTokens     int                  `json:"max_tokens"`
+	Temperature   float64              `json:"temperature"`
+	StopSequences []string             `json:"stop_sequences,omitempty"`
+	Tools         []anthropicTool      `json:"tools,omitempty"`
+	ToolChoice    *anthropicToolChoice `json:"tool_choice,omitempty"`
+	Stream        bool                 `json:"stream,omitempty"`
+}
+
+// anthropicJSONInstruction is appended to the system prompt of JSON requests, since the
+// Messages API has no response format setting
+const anthropicJSONInstruction = "Respond with a single valid JSON object and no other text."
+
+// anthropicResponse is the body of a Messages API response
+type anthropicResponse struct {
+	ID         string                  `json:"id"`
+	Model      string                  `json:"model"`
+	StopReason string                  `json:"stop_reason"`
+	Content    []anthropicContentBlock `json:"content"`
+	Usage      anthropicUsage          `json:"usage"`
+}
+
+// anthropicUsage is the token usage of a Messages API response
+type anthropicUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// totalInputTokens returns all input tokens: prompt caching reports cached input separately
+func (u anthropicUsage) totalInputTokens() int {
+	return u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+}
+
+// anthropicError is the body of a Messages API error response
+type anthropicError struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CallModel sends a request to the Anthropic Messages API
+func (p *AnthropicProvider) CallModel(ctx context.Context, model string, prompt string) (*ModelResponse, error) {
+	return p.Chat(ctx, model, NewPromptRequest(prompt))
+}
+
+// Chat sends a chat request to the Anthropic Messages API
+func (p *AnthropicProvider) Chat(ctx context.Context, model string, chatReq *ChatRequest) (*ModelResponse, error) {
+	resp, err := p.send(ctx, callClient, p.buildRequest(model, chatReq))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return p.parseResponse(model, resp.Body)
+}
+
+// anthropicStreamEvent is a server-sent event of a streaming Messages API response
+type anthropicStreamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Index        int                   `json:"index"`
+	ContentBlock anthropicContentBlock `json:"content_block"`
+	Delta        struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+	} `json:"delta"`
+	Usage *anthropicUsage `json:"usage"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ChatStream streams a chat request from the Anthropic Messages API
+func (p *AnthropicProvider) ChatStream(ctx context.Context, model string, chatReq *ChatRequest, handler StreamHandler) (*ModelResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reqBody := p.buildRequest(model, chatReq)
+	reqBody.Stream = true
+
+	resp, err := p.send(ctx, streamClient, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	acc := newStreamAccumulator(handler, estimateTokens(chatReq.Prompt()))
+	err = readSSE(ctx, resp.Body, func(_, data string) error {
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("failed to parse stream event: %w", err)
+		}
+		switch event.Type {
+		case "message_start":
+			acc.reportInput(event.Message.Usage.totalInputTokens())
+		case "content_block_start":
+			if event.ContentBlock.Type == "tool_use" {
+				acc.toolCallDelta(event.Index, event.ContentBlock.ID, event.ContentBlock.Name, "")
+			}
+		case "content_block_delta":
+			switch event.Delta.Type {
+			case "text_delta":
+				return acc.text(event.Delta.Text)
+			case "input_json_delta":
+				acc.toolCallDelta(event.Index, "", "", event.Delta.PartialJSON)
+			}
+		case "message_delta":
+			// Output usage is cumulative
+			if event.Usage != nil {
+				acc.reportOutput(event.Usage.OutputTokens)
+			}
+		case "message_stop":
+			return errStreamDone
+		case "error":
+			return fmt.Errorf("stream error: %s: %s", event.Error.Type, event.Error.Message)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acc.response(model, p.name)
+}
+
+// buildRequest maps a ChatRequest onto a Messages API request body
+func (p *AnthropicProvider) buildRequest(model string, chatReq *ChatRequest) *anthropicRequest {
+	reqBody := &anthropicRequest{
+		Model:         model,
+		System:        chatReq.SystemPrompt(),
+		MaxTokens:     chatReq.GetMaxTokens(),
+		Temperature:   chatReq.GetTemperature(),
+		StopSequences: chatReq.StopSequences,
+	}
+	for _, msg := range chatReq.ConversationMessages() {
+		reqBody.Messages = appendAnthropicMessage(reqBody.Messages, msg)
+	}
+	if chatReq.ResponseFormat == ResponseFormatJSON {
+		reqBody.System = strings.TrimSpace(reqBody.System + "\n\n" + anthropicJSONInstruction)
+	}
+	for _, tool := range chatReq.Tools {
+		reqBody.Tools = append(reqBody.Tools, anthropicTool{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.parameterSchema(),
+		})
+	}
+	if len(chatReq.Tools) > 0 {
+		reqBody.ToolChoice = newAnthropicToolChoice(chatReq.ToolChoice)
+	}
+	return reqBody
+}
+
+// appendAnthropicMessage appends msg in Messages API form. Tool calls become tool_use blocks
+// of the assistant message; tool results become tool_result blocks of a user message, and
+// consecutive results share one message as the API requires.
+func appendAnthropicMessage(messages []anthropicMessage, msg Message) []anthropicMessage {
+	switch {
+	case msg.Role == RoleTool:
+		result := anthropicContentBlock{Type: "tool_result", ToolUseID: msg.ToolCallID, Content: msg.Content}
+		if n := len(messages); n > 0 && messages[n-1].Role == RoleUser {
+			if blocks, ok := messages[n-1].Content.([]anthropicContentBlock); ok {
+				messages[n-1].Content = append(blocks, result)
+				return messages
+			}
+		}
+		return append(messages, anthropicMessage{Role: RoleUser, Content: []anthropicContentBlock{result}})
+	case len(msg.ToolCalls) > 0:
+		var blocks []anthropicContentBlock
+		if msg.Content != "" {
+			blocks = append(blocks, anthropicContentBlock{Type: "text", Text: msg.Content})
+		}
+		for _, call := range msg.ToolCalls {
+			input, _ := json.Marshal(call.Arguments)
+			if call.Arguments == nil {
+				input = []byte("{}")
+			}
+			blocks = append(blocks, anthropicContentBlock{Type: "tool_use", ID: call.ID, Name: call.Name, Input: input})
+		}
+		return append(messages, anthropicMessage{Role: msg.Role, Content: blocks})
+	default:
+		return append(messages, anthropicMessage{Role: msg.Role, Content: msg.Content})
+	}
+}
+
+// newAnthropicToolChoice maps a ToolChoice onto the Messages API tool_choice
+func newAnthropicToolChoice(choice string) *anthropicToolChoice {
+	switch choice {
+	case "":
+		return nil
+	case ToolChoiceAuto, ToolChoiceNone:
+		return &anthropicToolChoice{Type: choice}
+	case ToolChoiceRequired:
+		return &anthropicToolChoice{Type: "any"}
+	default:
+		return &anthropicToolChoice{Type: "tool", Name: choice}
+	}
+}
+
+// send posts reqBody to the Messages API and returns the successful response
+func (p *AnthropicProvider) send(ctx context.Context, client *http.Client, reqBody *anthropicRequest) (*http.Response, error) {
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/messages", p.endpoint)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("anthropic-version", p.version)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Anthropic API: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		var apiErr anthropicError
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
+			return nil, fmt.Errorf("API returned status %d: %s: %s", resp.StatusCode, apiErr.Error.Type, apiErr.Error.Message)
+		}
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return resp, nil
+}
+
+// parseResponse decodes a complete Messages API response
+func (p *AnthropicProvider) parseResponse(model string, body io.Reader) (*ModelResponse, error) {
+	var respData anthropicResponse
+	if err := json.NewDecoder(body).Decode(&respData); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// The reply is the concatenation of its text blocks
+	var content strings.Builder
+	var toolCalls []ToolCall
+	for _, block := range respData.Content {
+		switch block.Type {
+		case "text":
+			content.WriteString(block.Text)
+		case "tool_use":
+			args, err := decodeToolArguments(block.Name, string(block.Input))
+			if err != nil {
+				return nil, err
+			}
+			toolCalls = append(toolCalls, ToolCall{ID: block.ID, Name: block.Name, Arguments: args})
+		}
+	}
+	if len(respData.Content) == 0 {
+		return nil, fmt.Errorf("no content in response")
+	}
+
+	return &ModelResponse{
+		Content:      content.String(),
+		InputTokens:  respData.Usage.totalInputTokens(),
+		OutputTokens: respData.Usage.OutputTokens,
+		Model:        model,
+		Provider:     p.name,
+		Raw:          map[string]interface{}{"response": respData},
+		ToolCalls:    toolCalls,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestAnthropicProviderCallModel tests the Messages API request and response mapping
+func TestAnthropicProviderCallModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" {
+			t.Errorf("expected path /v1/messages, got %s", r.URL.Path)
+		}
+		if got := r.Header.Get("x-api-key"); got != "test-key" {
+			t.Errorf("expected x-api-key test-key, got %q", got)
+		}
+		if got := r.Header.Get("anthropic-version"); got != DefaultAnthropicVersion {
+			t.Errorf("expected anthropic-version %s, got %q", DefaultAnthropicVersion, got)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("expected no Authorization header")
+		}
+
+		var req anthropicRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Model != "claude-sonnet-4-5" || len(req.Messages) != 1 || req.Messages[0].Content != "Explain the outage" {
+			t.Errorf("unexpected request body: %+v", req)
+		}
+		if req.MaxTokens != DefaultMaxOutputTokens {
+			t.Errorf("expected max_tokens %d, got %d", DefaultMaxOutputTokens, req.MaxTokens)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "msg_1",
+			"model": "claude-sonnet-4-5",
+			"stop_reason": "end_turn",
+			"content": [{"type": "text", "text": "Root cause: "}, {"type": "text", "text": "disk pressure"}],
+			"usage": {"input_tokens": 10, "output_tokens": 7, "cache_read_input_tokens": 5}
+		}`))
+	}))
+	defer server.Close()
+
+	provider := NewAnthropicProvider("claude", server.URL+"/v1/", "test-key", "")
+	resp, err := provider.CallModel(context.Background(), "claude-sonnet-4-5", "Explain the outage")
+	if err != nil {
+		t.Fatalf("CallModel failed: %v", err)
+	}
+
+	if resp.Content != "Root cause: disk pressure" {
+		t.Errorf("expected concatenated text blocks, got %q", resp.Content)
+	}
+	if resp.InputTokens != 15 {
+		t.Errorf("expected 15 input tokens including cache reads, got %d", resp.InputTokens)
+	}
+	if resp.OutputTokens != 7 {
+		t.Errorf("expected 7 output tokens, got %d", resp.OutputTokens)
+	}
+	if resp.Provider != "claude" {
+		t.Errorf("expected provider claude, got %s", resp.Provider)
+	}
+}
+
+// TestAnthropicProviderError tests that Messages API errors surface their type and message
+func TestAnthropicProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"type": "error", "error": {"type": "authentication_error", "message": "invalid x-api-key"}}`))
+	}))
+	defer server.Close()
+
+	provider := NewAnthropicProvider("claude", server.URL, "bad-key", "")
+	_, err := provider.CallModel(context.Background(), "claude-sonnet-4-5", "hello")
+	if err == nil {
+		t.Fatal("expected error for 401 response")
+	}
+	if !strings.Contains(err.Error(), "authentication_error") || !strings.Contains(err.Error(), "invalid x-api-key") {
+		t.Errorf("expected error type and message in error, got %v", err)
+	}
+}
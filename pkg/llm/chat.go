@@ -0,0 +1,191 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// Message roles
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+)
+
+// Response formats
+const (
+	// ResponseFormatText asks for free-form text (the default)
+	ResponseFormatText = "text"
+
+	// ResponseFormatJSON asks for a single JSON object
+	ResponseFormatJSON = "json"
+)
+
+// DefaultTemperature is the sampling temperature of requests that do not set one
+const DefaultTemperature = 0.7
+
+// DefaultSystemPrompt is the operator's base system prompt; persona instructions are
+// appended to it and never replace it
+const DefaultSystemPrompt = "You are an autonomous agent managed by the agentic operator. " +
+	"Work only toward the stated objective, use only the data and tools you are given, " +
+	"and do not take destructive or irreversible actions without explicit approval."
+
+// toneInstructions are the system prompt instructions for each AgentPersona tone
+var toneInstructions = map[string]string{
+	"formal":      "Respond in a formal, professional tone.",
+	"casual":      "Respond in a casual, conversational tone.",
+	"technical":   "Respond in a precise, technical tone and include relevant details.",
+	"empathetic":  "Respond in an empathetic, supportive tone.",
+	"adversarial": "Respond as a critical reviewer: challenge assumptions and point out weaknesses.",
+}
+
+// Message is a role-tagged message of a chat request
+type Message struct {
+	// Role is RoleUser, RoleAssistant or RoleTool; RoleSystem messages are merged into the
+	// system prompt
+	Role string `json:"role"`
+
+	// Content is the message text, or the result of a RoleTool message
+	Content string `json:"content"`
+
+	// ToolCalls are the tool calls of a RoleAssistant message
+	ToolCalls []ToolCall `json:"toolCalls,omitempty"`
+
+	// ToolCallID is the call a RoleTool message answers
+	ToolCallID string `json:"toolCallId,omitempty"`
+}
+
+// ChatRequest is a model call with a system prompt, a conversation and sampling settings
+type ChatRequest struct {
+	// System is the system prompt
+	System string
+
+	// Messages is the conversation, oldest first
+	Messages []Message
+
+	// Temperature is the sampling temperature; nil uses DefaultTemperature
+	Temperature *float64
+
+	// MaxTokens caps the output; zero uses DefaultMaxOutputTokens
+	MaxTokens int
+
+	// StopSequences end generation when produced
+	StopSequences []string
+
+	// ResponseFormat is ResponseFormatText (default) or ResponseFormatJSON
+	ResponseFormat string
+
+	// Tools are the tools the model may call
+	Tools []Tool
+
+	// ToolChoice is ToolChoiceAuto (default), ToolChoiceNone, ToolChoiceRequired or the name
+	// of a tool the model must call
+	ToolChoice string
+}
+
+// NewPromptRequest returns a request with prompt as its only user message
+func NewPromptRequest(prompt string) *ChatRequest {
+	return &ChatRequest{Messages: []Message{{Role: RoleUser, Content: prompt}}}
+}
+
+// GetTemperature returns the request temperature or DefaultTemperature
+func (r *ChatRequest) GetTemperature() float64 {
+	if r.Temperature == nil {
+		return DefaultTemperature
+	}
+	return *r.Temperature
+}
+
+// GetMaxTokens returns the request output cap or DefaultMaxOutputTokens
+func (r *ChatRequest) GetMaxTokens() int {
+	if r.MaxTokens <= 0 {
+		return DefaultMaxOutputTokens
+	}
+	return r.MaxTokens
+}
+
+// SystemPrompt returns System followed by the content of any RoleSystem messages
+func (r *ChatRequest) SystemPrompt() string {
+	parts := []string{}
+	if r.System != "" {
+		parts = append(parts, r.System)
+	}
+	for _, msg := range r.Messages {
+		if msg.Role == RoleSystem && msg.Content != "" {
+			parts = append(parts, msg.Content)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ConversationMessages returns the messages other than RoleSystem ones
+func (r *ChatRequest) ConversationMessages() []Message {
+	msgs := make([]Message, 0, len(r.Messages))
+	for _, msg := range r.Messages {
+		if msg.Role != RoleSystem {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// Prompt flattens the request into a single prompt for providers that only take one.
+// A lone user message without a system prompt is returned unchanged.
+func (r *ChatRequest) Prompt() string {
+	system := r.SystemPrompt()
+	msgs := r.ConversationMessages()
+	if system == "" && len(msgs) == 1 && msgs[0].Role == RoleUser {
+		return msgs[0].Content
+	}
+
+	var b strings.Builder
+	if system != "" {
+		b.WriteString(system)
+	}
+	for _, msg := range msgs {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "%s: %s", msg.Role, msg.Content)
+	}
+	return b.String()
+}
+
+// ChatProvider is a Provider that accepts chat requests natively
+type ChatProvider interface {
+	Provider
+
+	// Chat sends a chat request to the model and returns the response
+	Chat(ctx context.Context, model string, req *ChatRequest) (*ModelResponse, error)
+}
+
+// Chat sends req to provider. Providers that only implement CallModel receive the request
+// flattened into a single prompt, so sampling settings other than the prompt are dropped.
+func Chat(ctx context.Context, provider Provider, model string, req *ChatRequest) (*ModelResponse, error) {
+	if chatProvider, ok := provider.(ChatProvider); ok {
+		return chatProvider.Chat(ctx, model, req)
+	}
+	return provider.CallModel(ctx, model, req.Prompt())
+}
+
+// BuildSystemPrompt returns base followed by the persona's tone instruction and
+// SystemPromptAppend, which always come last so they cannot displace the base prompt
+func BuildSystemPrompt(base string, persona *v1alpha1.AgentPersona) string {
+	parts := []string{}
+	if base != "" {
+		parts = append(parts, strings.TrimSpace(base))
+	}
+	if persona != nil {
+		if instruction, ok := toneInstructions[persona.Tone]; ok {
+			parts = append(parts, instruction)
+		}
+		if appended := strings.TrimSpace(persona.SystemPromptAppend); appended != "" {
+			parts = append(parts, appended)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,142 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// promptOnlyProvider implements only CallModel, like providers written before ChatProvider
+type promptOnlyProvider struct {
+	prompt string
+}
+
+func (p *promptOnlyProvider) Name() string { return "prompt-only" }
+func (p *promptOnlyProvider) Type() string { return "custom" }
+func (p *promptOnlyProvider) CallModel(ctx context.Context, model string, prompt string) (*ModelResponse, error) {
+	p.prompt = prompt
+	return &ModelResponse{Content: "ok", Model: model, Provider: p.Name()}, nil
+}
+
+// TestBuildSystemPrompt tests that persona instructions follow the base prompt
+func TestBuildSystemPrompt(t *testing.T) {
+	persona := &v1alpha1.AgentPersona{Tone: "technical", SystemPromptAppend: "  Cite sources.  "}
+	got := BuildSystemPrompt("Base prompt.", persona)
+	expected := "Base prompt.\n\n" + toneInstructions["technical"] + "\n\nCite sources."
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	if got := BuildSystemPrompt("Base prompt.", nil); got != "Base prompt." {
+		t.Errorf("expected base prompt without persona, got %q", got)
+	}
+	if got := BuildSystemPrompt("Base prompt.", &v1alpha1.AgentPersona{Tone: "unknown"}); got != "Base prompt." {
+		t.Errorf("expected unknown tone to be ignored, got %q", got)
+	}
+}
+
+// TestChatAdapterFlattensRequest tests that CallModel-only providers get a single prompt
+func TestChatAdapterFlattensRequest(t *testing.T) {
+	provider := &promptOnlyProvider{}
+
+	if _, err := Chat(context.Background(), provider, "m", NewPromptRequest("hello")); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if provider.prompt != "hello" {
+		t.Errorf("expected a lone user message to pass through, got %q", provider.prompt)
+	}
+
+	req := &ChatRequest{
+		System: "Be brief.",
+		Messages: []Message{
+			{Role: RoleUser, Content: "hi"},
+			{Role: RoleAssistant, Content: "hello"},
+			{Role: RoleUser, Content: "status?"},
+		},
+	}
+	if _, err := Chat(context.Background(), provider, "m", req); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	expected := "Be brief.\n\nuser: hi\n\nassistant: hello\n\nuser: status?"
+	if provider.prompt != expected {
+		t.Errorf("expected %q, got %q", expected, provider.prompt)
+	}
+}
+
+// TestOpenAICompatibleProviderChat tests the chat completions request built from a ChatRequest
+func TestOpenAICompatibleProviderChat(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "{}"}}], "usage": {"prompt_tokens": 1, "completion_tokens": 1}}`))
+	}))
+	defer server.Close()
+
+	temperature := 0.0
+	provider := NewOpenAICompatibleProvider("openai", server.URL, "key")
+	_, err := provider.Chat(context.Background(), "gpt-4", &ChatRequest{
+		System:         "Base.",
+		Messages:       []Message{{Role: RoleSystem, Content: "Extra."}, {Role: RoleUser, Content: "hi"}},
+		Temperature:    &temperature,
+		MaxTokens:      128,
+		StopSequences:  []string{"END"},
+		ResponseFormat: ResponseFormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	messages := body["messages"].([]interface{})
+	if len(messages) != 2 {
+		t.Fatalf("expected system and user messages, got %v", messages)
+	}
+	system := messages[0].(map[string]interface{})
+	if system["role"] != RoleSystem || system["content"] != "Base.\n\nExtra." {
+		t.Errorf("unexpected system message: %v", system)
+	}
+	if body["temperature"] != 0.0 || body["max_tokens"] != 128.0 {
+		t.Errorf("unexpected sampling settings: temperature=%v max_tokens=%v", body["temperature"], body["max_tokens"])
+	}
+	if stop := body["stop"].([]interface{}); len(stop) != 1 || stop[0] != "END" {
+		t.Errorf("unexpected stop sequences: %v", stop)
+	}
+	if format := body["response_format"].(map[string]interface{}); format["type"] != "json_object" {
+		t.Errorf("unexpected response format: %v", format)
+	}
+}
+
+// TestAnthropicProviderChat tests that the system prompt goes in the system field
+func TestAnthropicProviderChat(t *testing.T) {
+	var req anthropicRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_, _ = w.Write([]byte(`{"content": [{"type": "text", "text": "{}"}], "usage": {"input_tokens": 1, "output_tokens": 1}}`))
+	}))
+	defer server.Close()
+
+	provider := NewAnthropicProvider("claude", server.URL, "key", "")
+	_, err := provider.Chat(context.Background(), "claude-sonnet-4-5", &ChatRequest{
+		System:         "Base.",
+		Messages:       []Message{{Role: RoleUser, Content: "hi"}},
+		StopSequences:  []string{"END"},
+		ResponseFormat: ResponseFormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	if !strings.HasPrefix(req.System, "Base.") || !strings.HasSuffix(req.System, anthropicJSONInstruction) {
+		t.Errorf("unexpected system prompt: %q", req.System)
+	}
+	if len(req.Messages) != 1 || req.Messages[0].Role != RoleUser {
+		t.Errorf("expected only the user message, got %+v", req.Messages)
+	}
+	if len(req.StopSequences) != 1 || req.StopSequences[0] != "END" {
+		t.Errorf("unexpected stop sequences: %v", req.StopSequences)
+	}
+}
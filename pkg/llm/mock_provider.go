@@ -10,6 +10,7 @@ type MockOpenAIProvider struct {
 	name      string
 	responses map[string]string // Mock responses by prompt
 	callCount map[string]int    // Track number of calls per model
+	lastChat  *ChatRequest      // Most recent chat request
 }
 
 // NewMockOpenAIProvider creates a new mock OpenAI provider for testing
@@ -78,6 +79,24 @@ func (p *MockOpenAIProvider) CallModel(ctx context.Context, model string, prompt
 	}, nil
 }
 
+// Chat records the request and answers its last user message like CallModel
+func (p *MockOpenAIProvider) Chat(ctx context.Context, model string, req *ChatRequest) (*ModelResponse, error) {
+	p.lastChat = req
+
+	prompt := ""
+	for _, msg := range req.Messages {
+		if msg.Role == RoleUser {
+			prompt = msg.Content
+		}
+	}
+	return p.CallModel(ctx, model, prompt)
+}
+
+// LastChatRequest returns the most recent chat request, or nil if Chat was not called
+func (p *MockOpenAIProvider) LastChatRequest() *ChatRequest {
+	return p.lastChat
+}
+
 // GetCallCount returns the number of times a specific model was called
 func (p *MockOpenAIProvider) GetCallCount(model string) int {
 	return p.callCount[model]
@@ -0,0 +1,239 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// PluginProtocolHTTP is a plugin served as JSON over HTTP at POST {endpoint}/complete
+	PluginProtocolHTTP = "http"
+
+	// PluginProtocolGRPC is a plugin served over gRPC with the JSON codec at PluginGRPCMethod
+	PluginProtocolGRPC = "grpc"
+
+	// PluginGRPCMethod is the full gRPC method a plugin serves model calls on
+	PluginGRPCMethod = "/agentic.llm.v1.ProviderPlugin/CallModel"
+)
+
+// PluginRequest is the message a provider plugin receives for a model call
+type PluginRequest struct {
+	Model string `json:"model"`
+
+	// Prompt is the whole request flattened, for plugins that only take a single prompt
+	Prompt string `json:"prompt"`
+
+	System         string    `json:"system,omitempty"`
+	Messages       []Message `json:"messages,omitempty"`
+	MaxTokens      int       `json:"maxTokens"`
+	Temperature    float64   `json:"temperature"`
+	StopSequences  []string  `json:"stopSequences,omitempty"`
+	ResponseFormat string    `json:"responseFormat,omitempty"`
+	Tools          []Tool    `json:"tools,omitempty"`
+	ToolChoice     string    `json:"toolChoice,omitempty"`
+}
+
+// PluginResponse is the message a provider plugin returns for a model call
+type PluginResponse struct {
+	Content      string `json:"content"`
+	InputTokens  int    `json:"inputTokens"`
+	OutputTokens int    `json:"outputTokens"`
+
+	// ToolCalls are the tool calls the model requested
+	ToolCalls []ToolCall `json:"toolCalls,omitempty"`
+
+	// Error reports a failed call; it takes precedence over Content
+	Error string `json:"error,omitempty"`
+}
+
+// PluginConfig describes how to reach a provider plugin
+type PluginConfig struct {
+	// Protocol is PluginProtocolHTTP or PluginProtocolGRPC
+	Protocol string
+
+	// Endpoint is the base URL of an HTTP plugin
+	Endpoint string
+
+	// Address is the host:port of a gRPC plugin
+	Address string
+
+	// TLS dials a gRPC plugin over TLS instead of plaintext
+	TLS bool
+
+	// APIKey is sent as a bearer token when set
+	APIKey string
+}
+
+// PluginProvider implements the Provider interface for "custom" providers by forwarding
+// calls to an external adapter the user runs and describes in customConfig
+type PluginProvider struct {
+	name   string
+	config PluginConfig
+}
+
+// NewPluginProvider creates a provider for the plugin described by config
+func NewPluginProvider(name string, config PluginConfig) (*PluginProvider, error) {
+	switch config.Protocol {
+	case PluginProtocolHTTP:
+		if config.Endpoint == "" {
+			return nil, fmt.Errorf("endpoint required for http plugin")
+		}
+		config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	case PluginProtocolGRPC:
+		if config.Address == "" {
+			return nil, fmt.Errorf("address required for grpc plugin")
+		}
+	default:
+		return nil, fmt.Errorf("unknown plugin protocol: %s", config.Protocol)
+	}
+	return &PluginProvider{name: name, config: config}, nil
+}
+
+// Name returns the provider name
+func (p *PluginProvider) Name() string {
+	return p.name
+}
+
+// Type returns the provider type
+func (p *PluginProvider) Type() string {
+	return "custom"
+}
+
+// CallModel forwards the call to the plugin
+func (p *PluginProvider) CallModel(ctx context.Context, model string, prompt string) (*ModelResponse, error) {
+	return p.Chat(ctx, model, NewPromptRequest(prompt))
+}
+
+// Chat forwards the chat request to the plugin
+func (p *PluginProvider) Chat(ctx context.Context, model string, chatReq *ChatRequest) (*ModelResponse, error) {
+	req := &PluginRequest{
+		Model:          model,
+		Prompt:         chatReq.Prompt(),
+		System:         chatReq.SystemPrompt(),
+		Messages:       chatReq.ConversationMessages(),
+		MaxTokens:      chatReq.GetMaxTokens(),
+		Temperature:    chatReq.GetTemperature(),
+		StopSequences:  chatReq.StopSequences,
+		ResponseFormat: chatReq.ResponseFormat,
+		Tools:          chatReq.Tools,
+		ToolChoice:     chatReq.ToolChoice,
+	}
+
+	var resp *PluginResponse
+	var err error
+	if p.config.Protocol == PluginProtocolGRPC {
+		resp, err = p.callGRPC(ctx, req)
+	} else {
+		resp, err = p.callHTTP(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin returned error: %s", resp.Error)
+	}
+
+	return &ModelResponse{
+		Content:      resp.Content,
+		InputTokens:  resp.InputTokens,
+		OutputTokens: resp.OutputTokens,
+		Model:        model,
+		Provider:     p.name,
+		Raw:          map[string]interface{}{"response": resp},
+		ToolCalls:    resp.ToolCalls,
+	}, nil
+}
+
+// callHTTP posts req to an HTTP plugin
+func (p *PluginProvider) callHTTP(ctx context.Context, req *PluginRequest) (*PluginResponse, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/complete", p.config.Endpoint)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.config.APIKey != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.config.APIKey))
+	}
+
+	httpResp, err := callClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call plugin: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(httpResp.Body)
+		return nil, fmt.Errorf("plugin returned status %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	var resp PluginResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp, nil
+}
+
+// callGRPC invokes PluginGRPCMethod on a gRPC plugin. Messages are JSON-encoded so plugins
+// need no generated stubs from this repository. A call without a deadline gives up after
+// DefaultCallTimeout, so a hung plugin cannot block its caller.
+func (p *PluginProvider) callGRPC(ctx context.Context, req *PluginRequest) (*PluginResponse, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, grpcCallTimeout)
+		defer cancel()
+	}
+	creds := insecure.NewCredentials()
+	if p.config.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(p.config.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial plugin: %w", err)
+	}
+	defer conn.Close()
+
+	if p.config.APIKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+p.config.APIKey)
+	}
+
+	var resp PluginResponse
+	if err := conn.Invoke(ctx, PluginGRPCMethod, req, &resp, grpc.ForceCodec(jsonCodec{})); err != nil {
+		return nil, fmt.Errorf("failed to call plugin: %w", err)
+	}
+	return &resp, nil
+}
+
+// jsonCodec is a gRPC codec that encodes messages as JSON
+type jsonCodec struct{}
+
+// Marshal encodes v as JSON
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON data into v
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Name returns the codec's content subtype
+func (jsonCodec) Name() string {
+	return "json"
+}
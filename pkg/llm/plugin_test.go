@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TestPluginProviderHTTP tests calls forwarded to an HTTP plugin
+func TestPluginProviderHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/complete" {
+			t.Errorf("expected path /complete, got %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer plugin-key" {
+			t.Errorf("expected bearer token, got %q", got)
+		}
+		var req PluginRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "in-house-7b" || req.Prompt != "hello" {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		_ = json.NewEncoder(w).Encode(PluginResponse{Content: "hi", InputTokens: 2, OutputTokens: 1})
+	}))
+	defer server.Close()
+
+	provider, err := NewPluginProvider("in-house", PluginConfig{
+		Protocol: PluginProtocolHTTP,
+		Endpoint: server.URL + "/",
+		APIKey:   "plugin-key",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := provider.CallModel(context.Background(), "in-house-7b", "hello")
+	if err != nil {
+		t.Fatalf("CallModel failed: %v", err)
+	}
+	if resp.Content != "hi" || resp.InputTokens != 2 || resp.OutputTokens != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+// TestPluginProviderGRPC tests calls forwarded to a gRPC plugin using the JSON codec
+func TestPluginProviderGRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.ForceServerCodec(jsonCodec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "agentic.llm.v1.ProviderPlugin",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "CallModel",
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				var req PluginRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				md, _ := metadata.FromIncomingContext(ctx)
+				if auth := md.Get("authorization"); len(auth) == 0 || auth[0] != "Bearer plugin-key" {
+					return &PluginResponse{Error: "unauthorized"}, nil
+				}
+				return &PluginResponse{Content: "echo: " + req.Prompt, InputTokens: 1, OutputTokens: 2}, nil
+			},
+		}},
+	}, struct{}{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	provider, err := NewPluginProvider("in-house", PluginConfig{
+		Protocol: PluginProtocolGRPC,
+		Address:  listener.Addr().String(),
+		APIKey:   "plugin-key",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := provider.CallModel(context.Background(), "in-house-7b", "hello")
+	if err != nil {
+		t.Fatalf("CallModel failed: %v", err)
+	}
+	if resp.Content != "echo: hello" || resp.OutputTokens != 2 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	provider.config.APIKey = ""
+	if _, err := provider.CallModel(context.Background(), "in-house-7b", "hello"); err == nil ||
+		!strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("expected plugin error to surface, got %v", err)
+	}
+}
+
+// TestPluginProviderGRPCTimeout tests that a call to a hung gRPC plugin gives up
+func TestPluginProviderGRPCTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { grpcCallTimeout = timeout }(grpcCallTimeout)
+	grpcCallTimeout = 50 * time.Millisecond
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.ForceServerCodec(jsonCodec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "agentic.llm.v1.ProviderPlugin",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "CallModel",
+			Handler: func(_ interface{}, ctx context.Context, _ func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		}},
+	}, struct{}{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	provider, err := NewPluginProvider("in-house", PluginConfig{Protocol: PluginProtocolGRPC, Address: listener.Addr().String()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	start := time.Now()
+	_, err = provider.CallModel(context.Background(), "in-house-7b", "hello")
+	if err == nil || !strings.Contains(err.Error(), "DeadlineExceeded") {
+		t.Fatalf("expected the call to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the call to give up after the timeout, took %v", elapsed)
+	}
+}
+
+// TestNewPluginProviderValidation tests that plugins must describe how to reach them
+func TestNewPluginProviderValidation(t *testing.T) {
+	invalid := map[string]PluginConfig{
+		"http without endpoint": {Protocol: PluginProtocolHTTP},
+		"grpc without address":  {Protocol: PluginProtocolGRPC},
+		"unknown protocol":      {Protocol: "thrift", Endpoint: "http://plugin"},
+	}
+	for name, config := range invalid {
+		if _, err := NewPluginProvider("in-house", config); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
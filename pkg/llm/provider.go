@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,6 +27,51 @@ type Provider interface {
 	Type() string
 }
 
+// DefaultMaxOutputTokens caps the output of a model call
+const DefaultMaxOutputTokens = 2048
+
+// DefaultCallTimeout bounds a blocking model call, and the wait for a streamed response to start
+const DefaultCallTimeout = 5 * time.Minute
+
+// DefaultStreamIdleTimeout bounds the wait for the next line of a streamed response.
+// Providers send keep-alives while the model works, so a silent stream has stalled.
+const DefaultStreamIdleTimeout = time.Minute
+
+var (
+	// callClient is shared by blocking provider calls
+	callClient = &http.Client{Timeout: DefaultCallTimeout}
+
+	// streamClient is shared by streaming provider calls. A stream runs until the model
+	// finishes or its context is cancelled, so only the wait for response headers is
+	// bounded here; readSSE cuts off a stream that goes silent.
+	streamClient = newStreamClient()
+
+	// streamIdleTimeout is the idle timeout readSSE applies
+	streamIdleTimeout = DefaultStreamIdleTimeout
+
+	// grpcCallTimeout bounds gRPC plugin calls whose context has no deadline, as callClient
+	// bounds HTTP calls
+	grpcCallTimeout = DefaultCallTimeout
+)
+
+// newStreamClient returns an HTTP client without an overall timeout for streaming calls
+func newStreamClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = DefaultCallTimeout
+	return &http.Client{Transport: transport}
+}
+
+// EstimateCallTokens returns an upper estimate of the tokens a call with prompt uses: about
+// four characters per input token, plus the full output allowance
+func EstimateCallTokens(prompt string) int64 {
+	return int64(estimateTokens(prompt)) + DefaultMaxOutputTokens
+}
+
+// estimateTokens estimates the tokens of text at about four characters per token
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
 // ModelResponse represents the response from an LLM API call
 type ModelResponse struct {
 	// Content is the generated text response
@@ -45,6 +91,12 @@ type ModelResponse struct {
 
 	// Raw contains the raw response (useful for debugging)
 	Raw map[string]interface{}
+
+	// ToolCalls are the tool calls the model requested
+	ToolCalls []ToolCall
+
+	// TimeToFirstToken is the delay before the first content arrived; zero unless streamed
+	TimeToFirstToken time.Duration
 }
 
 // OpenAICompatibleProvider implements the Provider interface for OpenAI-compatible APIs
@@ -75,21 +127,232 @@ func (p *OpenAICompatibleProvider) Type() string {
 
 // CallModel sends a request to the OpenAI-compatible API
 func (p *OpenAICompatibleProvider) CallModel(ctx context.Context, model string, prompt string) (*ModelResponse, error) {
-	// Cloudflare Workers AI requires model names prefixed with "@cf/"
-	// If provider is Cloudflare and model doesn't already have the prefix, add it.
-	if (strings.Contains(p.name, "cloudflare") || strings.Contains(p.name, "workers-ai")) &&
-		!strings.HasPrefix(model, "@cf/") {
-		model = "@cf/meta/" + model
+	return p.Chat(ctx, model, NewPromptRequest(prompt))
+}
+
+// openAIMessage is a message of a chat completions request
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    string           `json:"content"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+// openAITool is a function tool of a chat completions request
+type openAITool struct {
+	Type     string             `json:"type"`
+	Function openAIToolFunction `json:"function"`
+}
+
+// openAIToolFunction is the function definition of an openAITool
+type openAIToolFunction struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+// openAIToolCall is a function call of a chat completions message. Arguments are a JSON
+// document encoded as a string.
+type openAIToolCall struct {
+	Index    *int   `json:"index,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Function struct {
+		Name      string `json:"name,omitempty"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// openAIResponseFormat is the response_format of a chat completions request
+type openAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+// openAIRequest is the body of a chat completions request
+type openAIRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openAIMessage       `json:"messages"`
+	MaxTokens      int                   `json:"max_tokens"`
+	Temperature    float64               `json:"temperature"`
+	Stop           []string              `json:"stop,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+	Tools          []openAITool          `json:"tools,omitempty"`
+	ToolChoice     interface{}           `json:"tool_choice,omitempty"`
+	Stream         bool                  `json:"stream,omitempty"`
+	StreamOptions  *openAIStreamOptions  `json:"stream_options,omitempty"`
+}
+
+// openAIStreamOptions are the stream_options of a streaming chat completions request
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// openAIResponse is the body of a chat completions response
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content   string           `json:"content"`
+			ToolCalls []openAIToolCall `json:"tool_calls"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// Chat sends a chat request to the OpenAI-compatible API
+func (p *OpenAICompatibleProvider) Chat(ctx context.Context, model string, chatReq *ChatRequest) (*ModelResponse, error) {
+	resp, err := p.send(ctx, callClient, p.buildRequest(model, chatReq))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return p.parseResponse(model, resp.Body)
+}
+
+// openAIStreamChunk is a server-sent event of a streaming chat completions response
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content   string           `json:"content"`
+			ToolCalls []openAIToolCall `json:"tool_calls"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ChatStream streams a chat request from the OpenAI-compatible API
+func (p *OpenAICompatibleProvider) ChatStream(ctx context.Context, model string, chatReq *ChatRequest, handler StreamHandler) (*ModelResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reqBody := p.buildRequest(model, chatReq)
+	reqBody.Stream = true
+	reqBody.StreamOptions = &openAIStreamOptions{IncludeUsage: true}
+
+	resp, err := p.send(ctx, streamClient, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if !isEventStream(resp) {
+		response, err := p.parseResponse(model, resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		chunk := StreamChunk{Delta: response.Content, InputTokens: response.InputTokens, OutputTokens: response.OutputTokens}
+		if handler != nil {
+			if err := handler(chunk); err != nil {
+				return nil, err
+			}
+		}
+		return response, nil
+	}
+
+	acc := newStreamAccumulator(handler, estimateTokens(chatReq.Prompt()))
+	err = readSSE(ctx, resp.Body, func(_, data string) error {
+		if data == "[DONE]" {
+			return errStreamDone
+		}
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("stream error: %s", chunk.Error.Message)
+		}
+		// The usage chunk requested by include_usage has no choices
+		if chunk.Usage != nil {
+			acc.reportInput(chunk.Usage.PromptTokens)
+			acc.reportOutput(chunk.Usage.CompletionTokens)
+		}
+		if len(chunk.Choices) == 0 {
+			return nil
+		}
+		// Tool calls arrive as fragments keyed by index; only the first carries ID and name
+		for i, call := range chunk.Choices[0].Delta.ToolCalls {
+			index := i
+			if call.Index != nil {
+				index = *call.Index
+			}
+			acc.toolCallDelta(index, call.ID, call.Function.Name, call.Function.Arguments)
+		}
+		return acc.text(chunk.Choices[0].Delta.Content)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acc.response(model, p.name)
+}
+
+// buildRequest maps a ChatRequest onto a chat completions request body
+func (p *OpenAICompatibleProvider) buildRequest(model string, chatReq *ChatRequest) *openAIRequest {
+	reqBody := &openAIRequest{
+		Model:       model,
+		MaxTokens:   chatReq.GetMaxTokens(),
+		Temperature: chatReq.GetTemperature(),
+		Stop:        chatReq.StopSequences,
+	}
+	if system := chatReq.SystemPrompt(); system != "" {
+		reqBody.Messages = append(reqBody.Messages, openAIMessage{Role: RoleSystem, Content: system})
+	}
+	for _, msg := range chatReq.ConversationMessages() {
+		message := openAIMessage{Role: msg.Role, Content: msg.Content, ToolCallID: msg.ToolCallID}
+		for _, call := range msg.ToolCalls {
+			message.ToolCalls = append(message.ToolCalls, newOpenAIToolCall(call))
+		}
+		reqBody.Messages = append(reqBody.Messages, message)
+	}
+	if chatReq.ResponseFormat == ResponseFormatJSON {
+		reqBody.ResponseFormat = &openAIResponseFormat{Type: "json_object"}
 	}
+	for _, tool := range chatReq.Tools {
+		reqBody.Tools = append(reqBody.Tools, openAITool{
+			Type: "function",
+			Function: openAIToolFunction{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.parameterSchema(),
+			},
+		})
+	}
+	if len(chatReq.Tools) > 0 {
+		reqBody.ToolChoice = openAIToolChoice(chatReq.ToolChoice)
+	}
+	return reqBody
+}
 
-	// Prepare request body
-	reqBody := map[string]interface{}{
-		"model":       model,
-		"messages":    []map[string]string{{"role": "user", "content": prompt}},
-		"max_tokens":  2048,
-		"temperature": 0.7,
+// newOpenAIToolCall encodes a tool call of an assistant message
+func newOpenAIToolCall(call ToolCall) openAIToolCall {
+	arguments, _ := json.Marshal(call.Arguments)
+	wireCall := openAIToolCall{ID: call.ID, Type: "function"}
+	wireCall.Function.Name = call.Name
+	wireCall.Function.Arguments = string(arguments)
+	return wireCall
+}
+
+// openAIToolChoice maps a ToolChoice onto the chat completions tool_choice
+func openAIToolChoice(choice string) interface{} {
+	switch choice {
+	case "":
+		return nil
+	case ToolChoiceAuto, ToolChoiceNone, ToolChoiceRequired:
+		return choice
+	default:
+		return map[string]interface{}{"type": "function", "function": map[string]string{"name": choice}}
 	}
+}
 
+// send posts reqBody to the chat completions endpoint and returns the successful response
+func (p *OpenAICompatibleProvider) send(ctx context.Context, client *http.Client, reqBody *openAIRequest) (*http.Response, error) {
 	bodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -106,32 +369,23 @@ func (p *OpenAICompatibleProvider) CallModel(ctx context.Context, model string,
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.apiKey))
 
 	// Send request
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call OpenAI API: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
 	}
+	return resp, nil
+}
 
-	// Parse response
-	var respData struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
-		Usage struct {
-			PromptTokens     int `json:"prompt_tokens"`
-			CompletionTokens int `json:"completion_tokens"`
-		} `json:"usage"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&respData); err != nil {
+// parseResponse decodes a complete chat completions response
+func (p *OpenAICompatibleProvider) parseResponse(model string, body io.Reader) (*ModelResponse, error) {
+	var respData openAIResponse
+	if err := json.NewDecoder(body).Decode(&respData); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -139,6 +393,15 @@ func (p *OpenAICompatibleProvider) CallModel(ctx context.Context, model string,
 		return nil, fmt.Errorf("no choices in response")
 	}
 
+	var toolCalls []ToolCall
+	for _, call := range respData.Choices[0].Message.ToolCalls {
+		args, err := decodeToolArguments(call.Function.Name, call.Function.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		toolCalls = append(toolCalls, ToolCall{ID: call.ID, Name: call.Function.Name, Arguments: args})
+	}
+
 	return &ModelResponse{
 		Content:      respData.Choices[0].Message.Content,
 		InputTokens:  respData.Usage.PromptTokens,
@@ -146,6 +409,7 @@ func (p *OpenAICompatibleProvider) CallModel(ctx context.Context, model string,
 		Model:        model,
 		Provider:     p.name,
 		Raw:          map[string]interface{}{"response": respData},
+		ToolCalls:    toolCalls,
 	}, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 
@@ -14,18 +15,25 @@ import (
 
 // ModelRouter handles task-based model routing
 type ModelRouter struct {
-	registry   *ProviderRegistry
-	classifier *routing.TaskClassifier
+	registry     *ProviderRegistry
+	classifier   *routing.TaskClassifier
+	systemPrompt string
 }
 
 // NewModelRouter creates a new model router
 func NewModelRouter(registry *ProviderRegistry, classifier *routing.TaskClassifier) *ModelRouter {
 	return &ModelRouter{
-		registry:   registry,
-		classifier: classifier,
+		registry:     registry,
+		classifier:   classifier,
+		systemPrompt: DefaultSystemPrompt,
 	}
 }
 
+// SetSystemPrompt replaces the base system prompt that persona instructions are appended to
+func (mr *ModelRouter) SetSystemPrompt(prompt string) {
+	mr.systemPrompt = prompt
+}
+
 // RouteAndCall classifies a task and routes it to the appropriate model
 // Includes OpenTelemetry tracing for full observability
 func (mr *ModelRouter) RouteAndCall(
@@ -34,6 +42,32 @@ func (mr *ModelRouter) RouteAndCall(
 	namespace string,
 	spec *v1alpha1.AgentWorkloadSpec,
 	instructions string,
+) (*ModelResponse, *RoutingInfo, error) {
+	return mr.route(ctx, c, namespace, spec, instructions, false, nil)
+}
+
+// RouteAndStream routes a task like RouteAndCall but streams the model response, passing
+// each chunk to handler (which may be nil) and recording the time to first token
+func (mr *ModelRouter) RouteAndStream(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	spec *v1alpha1.AgentWorkloadSpec,
+	instructions string,
+	handler StreamHandler,
+) (*ModelResponse, *RoutingInfo, error) {
+	return mr.route(ctx, c, namespace, spec, instructions, true, handler)
+}
+
+// route classifies, resolves and calls the model, streaming the call when stream is set
+func (mr *ModelRouter) route(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	spec *v1alpha1.AgentWorkloadSpec,
+	instructions string,
+	stream bool,
+	handler StreamHandler,
 ) (*ModelResponse, *RoutingInfo, error) {
 	routingInfo := &RoutingInfo{}
 
@@ -130,9 +164,28 @@ func (mr *ModelRouter) RouteAndCall(
 	// Register the provider in the registry
 	mr.registry.Register(provider)
 
-	// Call the model with tracing
+	// Call the model with tracing; the persona extends the base system prompt
+	request := &ChatRequest{
+		System:   BuildSystemPrompt(mr.systemPrompt, spec.Persona),
+		Messages: []Message{{Role: RoleUser, Content: instructions}},
+	}
 	callCtx, callSpan := StartModelCallSpan(ctx, providerName, modelName)
-	response, err := provider.CallModel(callCtx, modelName, instructions)
+	var response *ModelResponse
+	if stream {
+		firstToken := false
+		response, err = ChatStream(callCtx, provider, modelName, request, func(chunk StreamChunk) error {
+			if !firstToken && chunk.Delta != "" {
+				firstToken = true
+				AddSpanEvent(callSpan, "first_token")
+			}
+			if handler == nil {
+				return nil
+			}
+			return handler(chunk)
+		})
+	} else {
+		response, err = Chat(callCtx, provider, modelName, request)
+	}
 	if err != nil {
 		SetModelCallAttributes(callSpan, 0, 0, false)
 		callSpan.End()
@@ -143,10 +196,14 @@ func (mr *ModelRouter) RouteAndCall(
 		return nil, routingInfo, fmt.Errorf("failed to call model: %w", err)
 	}
 	SetModelCallAttributes(callSpan, response.InputTokens, response.OutputTokens, true)
+	if stream {
+		SetTimeToFirstTokenAttribute(callSpan, response.TimeToFirstToken)
+	}
 	callSpan.End()
 
 	routingInfo.InputTokens = response.InputTokens
 	routingInfo.OutputTokens = response.OutputTokens
+	routingInfo.TimeToFirstToken = response.TimeToFirstToken
 
 	// Record final attributes in root span
 	RecordRoutingAttributes(rootSpan, &TracingAttributes{
@@ -177,12 +234,12 @@ func (mr *ModelRouter) initializeProvider(
 	switch config.Type {
 	case "openai-compatible":
 		return mr.initOpenAICompatible(ctx, c, namespace, config)
+	case "anthropic":
+		return mr.initAnthropic(ctx, c, namespace, config)
 	case "workers-ai":
-		// Workers AI support would be added here
-		return nil, fmt.Errorf("workers-ai provider not yet implemented")
+		return mr.initWorkersAI(ctx, c, namespace, config)
 	case "custom":
-		// Custom provider support would be added here
-		return nil, fmt.Errorf("custom provider not yet implemented")
+		return mr.initPlugin(ctx, c, namespace, config)
 	default:
 		return nil, fmt.Errorf("unknown provider type: %s", config.Type)
 	}
@@ -211,6 +268,83 @@ func (mr *ModelRouter) initOpenAICompatible(
 	return NewOpenAICompatibleProvider(config.Name, *config.Endpoint, apiKey), nil
 }
 
+// initAnthropic initializes an Anthropic Messages API provider. The endpoint defaults to
+// DefaultAnthropicEndpoint; customConfig "anthropicVersion" overrides the API version.
+func (mr *ModelRouter) initAnthropic(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	config *v1alpha1.LLMProvider,
+) (Provider, error) {
+	if config.APIKeySecret == nil {
+		return nil, fmt.Errorf("apiKeySecret required for anthropic provider")
+	}
+	apiKey, err := ResolveAPIKey(ctx, c, namespace, config.APIKeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve API key: %w", err)
+	}
+
+	endpoint := ""
+	if config.Endpoint != nil {
+		endpoint = *config.Endpoint
+	}
+	return NewAnthropicProvider(config.Name, endpoint, apiKey, config.CustomConfig["anthropicVersion"]), nil
+}
+
+// initWorkersAI initializes a Cloudflare Workers AI provider from customConfig "accountId"
+// and optional "gateway" (an AI Gateway name). An explicit endpoint overrides both.
+func (mr *ModelRouter) initWorkersAI(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	config *v1alpha1.LLMProvider,
+) (Provider, error) {
+	if config.APIKeySecret == nil {
+		return nil, fmt.Errorf("apiKeySecret required for workers-ai provider")
+	}
+	apiToken, err := ResolveAPIKey(ctx, c, namespace, config.APIKeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve API key: %w", err)
+	}
+
+	endpoint := ""
+	if config.Endpoint != nil {
+		endpoint = *config.Endpoint
+	}
+	return NewWorkersAIProvider(config.Name, config.CustomConfig["accountId"],
+		config.CustomConfig["gateway"], endpoint, apiToken)
+}
+
+// initPlugin initializes a custom provider backed by an external plugin. customConfig
+// "protocol" selects "http" (default, served at the endpoint) or "grpc" (served at
+// customConfig "address", over TLS when "tls" is "true").
+func (mr *ModelRouter) initPlugin(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	config *v1alpha1.LLMProvider,
+) (Provider, error) {
+	pluginConfig := PluginConfig{
+		Protocol: config.CustomConfig["protocol"],
+		Address:  config.CustomConfig["address"],
+		TLS:      config.CustomConfig["tls"] == "true",
+	}
+	if pluginConfig.Protocol == "" {
+		pluginConfig.Protocol = PluginProtocolHTTP
+	}
+	if config.Endpoint != nil {
+		pluginConfig.Endpoint = *config.Endpoint
+	}
+	if config.APIKeySecret != nil {
+		apiKey, err := ResolveAPIKey(ctx, c, namespace, config.APIKeySecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve API key: %w", err)
+		}
+		pluginConfig.APIKey = apiKey
+	}
+	return NewPluginProvider(config.Name, pluginConfig)
+}
+
 // RoutingInfo contains metadata about the routing decision
 type RoutingInfo struct {
 	// TaskCategory is the classified task type (validation, analysis, reasoning)
@@ -227,4 +361,7 @@ type RoutingInfo struct {
 
 	// OutputTokens is the number of output tokens used
 	OutputTokens int
+
+	// TimeToFirstToken is the delay before the first content arrived; zero unless streamed
+	TimeToFirstToken time.Duration
 }
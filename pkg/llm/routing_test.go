@@ -2,6 +2,9 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -220,6 +223,49 @@ func TestModelRouterMissingModelMapping(t *testing.T) {
 	t.Logf("✓ Error handling correct: %v", err)
 }
 
+// TestModelRouterInjectsPersona tests that the persona extends the base system prompt
+func TestModelRouterInjectsPersona(t *testing.T) {
+	var messages []openAIMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		messages = req.Messages
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "ok"}}], "usage": {"prompt_tokens": 1, "completion_tokens": 1}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	router := NewModelRouter(NewProviderRegistry(), routing.NewDefaultClassifier())
+	router.SetSystemPrompt("Operator base prompt.")
+
+	objective := "Parse JSON"
+	endpoint := server.URL
+	spec := &v1alpha1.AgentWorkloadSpec{
+		Objective: &objective,
+		Providers: []v1alpha1.LLMProvider{
+			{Name: "local", Type: "openai-compatible", Endpoint: &endpoint},
+		},
+		ModelMapping: map[string]string{"validation": "local/gpt-3.5-turbo"},
+		Persona: &v1alpha1.AgentPersona{
+			Tone:               "formal",
+			SystemPromptAppend: "Never speculate.",
+		},
+	}
+
+	if _, _, err := router.RouteAndCall(ctx, client, "default", spec, objective); err != nil {
+		t.Fatalf("RouteAndCall failed: %v", err)
+	}
+
+	if len(messages) != 2 || messages[0].Role != RoleSystem || messages[1].Content != objective {
+		t.Fatalf("expected system and user messages, got %+v", messages)
+	}
+	expected := "Operator base prompt.\n\n" + toneInstructions["formal"] + "\n\nNever speculate."
+	if messages[0].Content != expected {
+		t.Errorf("expected system prompt %q, got %q", expected, messages[0].Content)
+	}
+}
+
 // TestTaskCategoryMapping tests that different task types map to correct models
 func TestTaskCategoryMapping(t *testing.T) {
 	testCases := []struct {
@@ -0,0 +1,255 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// maxSSELineSize caps a single server-sent event line
+const maxSSELineSize = 1024 * 1024
+
+// errStreamDone stops reading a stream once its terminal event arrives
+var errStreamDone = errors.New("stream done")
+
+// errStreamIdle is returned when a stream sends nothing for streamIdleTimeout
+var errStreamIdle = errors.New("stream stalled")
+
+// StreamChunk is an increment of a streamed model response
+type StreamChunk struct {
+	// Delta is the text generated since the previous chunk
+	Delta string
+
+	// InputTokens is the input token count so far; estimated until the provider reports it
+	InputTokens int
+
+	// OutputTokens is the output token count so far; estimated until the provider reports it
+	OutputTokens int
+}
+
+// StreamHandler receives the chunks of a streamed response in order. Returning an error
+// cancels the stream and the call returns that error.
+type StreamHandler func(chunk StreamChunk) error
+
+// StreamingProvider is a ChatProvider that can stream responses as server-sent events
+type StreamingProvider interface {
+	ChatProvider
+
+	// ChatStream sends a chat request, passes each chunk to handler as it arrives and
+	// returns the complete response once the stream ends
+	ChatStream(ctx context.Context, model string, req *ChatRequest, handler StreamHandler) (*ModelResponse, error)
+}
+
+// ChatStream streams req from provider and measures the time to first token. Providers that
+// cannot stream answer in one blocking call delivered to handler as a single chunk. A nil
+// handler only collects the response.
+func ChatStream(ctx context.Context, provider Provider, model string, req *ChatRequest, handler StreamHandler) (*ModelResponse, error) {
+	start := time.Now()
+	var timeToFirstToken time.Duration
+	timed := func(chunk StreamChunk) error {
+		if timeToFirstToken == 0 && chunk.Delta != "" {
+			timeToFirstToken = time.Since(start)
+		}
+		if handler == nil {
+			return nil
+		}
+		return handler(chunk)
+	}
+
+	var response *ModelResponse
+	var err error
+	if streamingProvider, ok := provider.(StreamingProvider); ok {
+		response, err = streamingProvider.ChatStream(ctx, model, req, timed)
+	} else {
+		response, err = Chat(ctx, provider, model, req)
+		if err == nil {
+			err = timed(StreamChunk{
+				Delta:        response.Content,
+				InputTokens:  response.InputTokens,
+				OutputTokens: response.OutputTokens,
+			})
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response.TimeToFirstToken = timeToFirstToken
+	return response, nil
+}
+
+// isEventStream reports whether resp carries server-sent events. Some OpenAI-compatible
+// servers ignore the stream flag and answer with a single JSON body.
+func isEventStream(resp *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mediaType == "text/event-stream"
+}
+
+// readSSE calls fn with the event name and data of each server-sent event in body until the
+// body ends, fn returns an error, ctx is cancelled, or no line arrives for streamIdleTimeout.
+// errStreamDone from fn ends the read without error.
+func readSSE(ctx context.Context, body io.ReadCloser, fn func(event, data string) error) error {
+	// A stalled stream is cut off by closing the body under the scanner
+	var idle atomic.Bool
+	timer := time.AfterFunc(streamIdleTimeout, func() {
+		idle.Store(true)
+		_ = body.Close()
+	})
+	defer timer.Stop()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineSize)
+
+	var event string
+	var data []string
+	dispatch := func() error {
+		if len(data) == 0 {
+			event = ""
+			return nil
+		}
+		err := fn(event, strings.Join(data, "\n"))
+		event, data = "", nil
+		return err
+	}
+
+	for scanner.Scan() {
+		timer.Reset(streamIdleTimeout)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if err := dispatch(); err != nil {
+				return ignoreStreamDone(err)
+			}
+		case strings.HasPrefix(line, ":"):
+			// Comment, used by servers as a keep-alive
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		// Cancelling the request closes the body under the scanner
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if idle.Load() {
+			return fmt.Errorf("%w: no data for %s", errStreamIdle, streamIdleTimeout)
+		}
+		return err
+	}
+	if idle.Load() {
+		return fmt.Errorf("%w: no data for %s", errStreamIdle, streamIdleTimeout)
+	}
+	return ignoreStreamDone(dispatch())
+}
+
+// ignoreStreamDone maps errStreamDone to nil
+func ignoreStreamDone(err error) error {
+	if errors.Is(err, errStreamDone) {
+		return nil
+	}
+	return err
+}
+
+// streamAccumulator collects a streamed response and keeps its running token counts
+type streamAccumulator struct {
+	handler        StreamHandler
+	content        strings.Builder
+	inputTokens    int
+	outputTokens   int
+	outputReported bool
+	toolCalls      []*streamedToolCall
+	toolIndexes    map[int]*streamedToolCall
+}
+
+// streamedToolCall is a tool call whose JSON arguments arrive in fragments
+type streamedToolCall struct {
+	id        string
+	name      string
+	arguments strings.Builder
+}
+
+// newStreamAccumulator returns an accumulator whose input count starts at estimatedInput
+func newStreamAccumulator(handler StreamHandler, estimatedInput int) *streamAccumulator {
+	return &streamAccumulator{handler: handler, inputTokens: estimatedInput}
+}
+
+// text appends delta and passes it to the handler with the running token counts
+func (a *streamAccumulator) text(delta string) error {
+	if delta == "" {
+		return nil
+	}
+	a.content.WriteString(delta)
+	if !a.outputReported {
+		a.outputTokens = estimateTokens(a.content.String())
+	}
+	if a.handler == nil {
+		return nil
+	}
+	return a.handler(StreamChunk{Delta: delta, InputTokens: a.inputTokens, OutputTokens: a.outputTokens})
+}
+
+// toolCallDelta adds a fragment of the tool call at index; the first fragment of a call
+// carries its ID and name
+func (a *streamAccumulator) toolCallDelta(index int, id, name, arguments string) {
+	if a.toolIndexes == nil {
+		a.toolIndexes = map[int]*streamedToolCall{}
+	}
+	call, ok := a.toolIndexes[index]
+	if !ok {
+		call = &streamedToolCall{}
+		a.toolIndexes[index] = call
+		a.toolCalls = append(a.toolCalls, call)
+	}
+	if id != "" {
+		call.id = id
+	}
+	if name != "" {
+		call.name = name
+	}
+	call.arguments.WriteString(arguments)
+}
+
+// reportInput replaces the input estimate with the provider's count
+func (a *streamAccumulator) reportInput(tokens int) {
+	a.inputTokens = tokens
+}
+
+// reportOutput replaces the output estimate with the provider's cumulative count
+func (a *streamAccumulator) reportOutput(tokens int) {
+	a.outputTokens = tokens
+	a.outputReported = true
+}
+
+// response returns the collected response
+func (a *streamAccumulator) response(model, provider string) (*ModelResponse, error) {
+	var toolCalls []ToolCall
+	for _, call := range a.toolCalls {
+		args, err := decodeToolArguments(call.name, call.arguments.String())
+		if err != nil {
+			return nil, err
+		}
+		toolCalls = append(toolCalls, ToolCall{ID: call.id, Name: call.name, Arguments: args})
+	}
+
+	return &ModelResponse{
+		Content:      a.content.String(),
+		InputTokens:  a.inputTokens,
+		OutputTokens: a.outputTokens,
+		Model:        model,
+		Provider:     provider,
+		Raw:          map[string]interface{}{"streamed": true},
+		ToolCalls:    toolCalls,
+	}, nil
+}
@@ -0,0 +1,186 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newSSEServer serves events as a server-sent event stream, flushing after each one
+func newSSEServer(t *testing.T, events []string, delay time.Duration) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		for _, event := range events {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(delay):
+			}
+			fmt.Fprintf(w, "%s\n\n", event)
+			flusher.Flush()
+		}
+	}))
+}
+
+// TestOpenAICompatibleProviderChatStream tests deltas, token accounting and time to first token
+func TestOpenAICompatibleProviderChatStream(t *testing.T) {
+	server := newSSEServer(t, []string{
+		": keep-alive",
+		`data: {"choices": [{"delta": {"content": "Hello"}}]}`,
+		`data: {"choices": [{"delta": {"content": ", world"}}]}`,
+		`data: {"choices": [], "usage": {"prompt_tokens": 12, "completion_tokens": 3}}`,
+		"data: [DONE]",
+	}, 5*time.Millisecond)
+	defer server.Close()
+
+	var chunks []StreamChunk
+	provider := NewOpenAICompatibleProvider("openai", server.URL, "key")
+	resp, err := ChatStream(context.Background(), provider, "gpt-4", NewPromptRequest("Say hello"), func(chunk StreamChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	if len(chunks) != 2 || chunks[0].Delta != "Hello" || chunks[1].Delta != ", world" {
+		t.Fatalf("unexpected chunks: %+v", chunks)
+	}
+	if chunks[1].OutputTokens < chunks[0].OutputTokens || chunks[0].OutputTokens == 0 {
+		t.Errorf("expected growing output token estimates, got %+v", chunks)
+	}
+	if resp.Content != "Hello, world" {
+		t.Errorf("expected accumulated content, got %q", resp.Content)
+	}
+	if resp.InputTokens != 12 || resp.OutputTokens != 3 {
+		t.Errorf("expected reported usage 12/3, got %d/%d", resp.InputTokens, resp.OutputTokens)
+	}
+	if resp.TimeToFirstToken <= 0 {
+		t.Errorf("expected a time to first token, got %v", resp.TimeToFirstToken)
+	}
+}
+
+// TestAnthropicProviderChatStream tests Messages API stream events
+func TestAnthropicProviderChatStream(t *testing.T) {
+	server := newSSEServer(t, []string{
+		"event: message_start\ndata: {\"type\": \"message_start\", \"message\": {\"usage\": {\"input_tokens\": 20, \"cache_read_input_tokens\": 5, \"output_tokens\": 1}}}",
+		"event: ping\ndata: {\"type\": \"ping\"}",
+		"event: content_block_delta\ndata: {\"type\": \"content_block_delta\", \"delta\": {\"type\": \"text_delta\", \"text\": \"Disk \"}}",
+		"event: content_block_delta\ndata: {\"type\": \"content_block_delta\", \"delta\": {\"type\": \"text_delta\", \"text\": \"pressure\"}}",
+		"event: message_delta\ndata: {\"type\": \"message_delta\", \"usage\": {\"output_tokens\": 4}}",
+		"event: message_stop\ndata: {\"type\": \"message_stop\"}",
+	}, time.Millisecond)
+	defer server.Close()
+
+	var chunks []StreamChunk
+	provider := NewAnthropicProvider("claude", server.URL, "key", "")
+	resp, err := provider.ChatStream(context.Background(), "claude-sonnet-4-5", NewPromptRequest("Why?"), func(chunk StreamChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	if len(chunks) != 2 || chunks[0].InputTokens != 25 {
+		t.Errorf("expected two chunks with 25 input tokens, got %+v", chunks)
+	}
+	if resp.Content != "Disk pressure" || resp.InputTokens != 25 || resp.OutputTokens != 4 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+// TestChatStreamCancellation tests that handler errors and context cancellation stop a stream
+func TestChatStreamCancellation(t *testing.T) {
+	events := []string{}
+	for i := 0; i < 50; i++ {
+		events = append(events, `data: {"choices": [{"delta": {"content": "token "}}]}`)
+	}
+	server := newSSEServer(t, events, 10*time.Millisecond)
+	defer server.Close()
+	provider := NewOpenAICompatibleProvider("openai", server.URL, "key")
+
+	errStop := errors.New("stop")
+	_, err := ChatStream(context.Background(), provider, "gpt-4", NewPromptRequest("count"), func(chunk StreamChunk) error {
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("expected handler error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = ChatStream(ctx, provider, "gpt-4", NewPromptRequest("count"), nil)
+	if err == nil {
+		t.Fatal("expected error after context cancellation")
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("expected the stream to stop on cancellation, took %v", elapsed)
+	}
+}
+
+// TestChatStreamIdleTimeout tests that a stream that goes silent after it started is cut off
+func TestChatStreamIdleTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { streamIdleTimeout = timeout }(streamIdleTimeout)
+	streamIdleTimeout = 50 * time.Millisecond
+
+	stalled := make(chan struct{})
+	defer close(stalled)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\": [{\"delta\": {\"content\": \"Hello\"}}]}\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-stalled:
+		}
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	provider := NewOpenAICompatibleProvider("openai", server.URL, "key")
+	_, err := ChatStream(context.Background(), provider, "gpt-4", NewPromptRequest("Say hello"), nil)
+	if !errors.Is(err, errStreamIdle) {
+		t.Fatalf("expected a stalled stream error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the stalled stream cut off after the idle timeout, took %v", elapsed)
+	}
+}
+
+// TestChatStreamFallback tests providers that cannot stream and servers that ignore the flag
+func TestChatStreamFallback(t *testing.T) {
+	var chunks []StreamChunk
+	resp, err := ChatStream(context.Background(), &promptOnlyProvider{}, "m", NewPromptRequest("hi"), func(chunk StreamChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+	if len(chunks) != 1 || chunks[0].Delta != "ok" || resp.TimeToFirstToken <= 0 {
+		t.Errorf("expected one chunk with the whole response, got %+v (ttft %v)", chunks, resp.TimeToFirstToken)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "whole"}}], "usage": {"prompt_tokens": 2, "completion_tokens": 1}}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenAICompatibleProvider("openai", server.URL, "key")
+	resp, err = ChatStream(context.Background(), provider, "gpt-4", NewPromptRequest("hi"), nil)
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+	if resp.Content != "whole" || resp.OutputTokens != 1 {
+		t.Errorf("expected the JSON response to be used, got %+v", resp)
+	}
+}
@@ -0,0 +1,120 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/mcp"
+)
+
+// Tool choices
+const (
+	// ToolChoiceAuto lets the model decide whether to call a tool (the default)
+	ToolChoiceAuto = "auto"
+
+	// ToolChoiceNone forbids tool calls
+	ToolChoiceNone = "none"
+
+	// ToolChoiceRequired makes the model call at least one tool
+	ToolChoiceRequired = "required"
+)
+
+// Tool is a function the model may call
+type Tool struct {
+	// Name is the tool name the model calls it by
+	Name string `json:"name"`
+
+	// Description tells the model what the tool does
+	Description string `json:"description,omitempty"`
+
+	// Parameters is the JSON Schema of the tool arguments; nil means no arguments
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// ToolCall is a tool invocation requested by the model
+type ToolCall struct {
+	// ID identifies the call; the tool result message refers to it
+	ID string `json:"id"`
+
+	// Name is the called tool
+	Name string `json:"name"`
+
+	// Arguments are the decoded call arguments
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// emptyToolParameters is the schema of a tool without arguments
+var emptyToolParameters = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+
+// parameterSchema returns the tool's argument schema, defaulting to an empty object schema
+func (t Tool) parameterSchema() map[string]interface{} {
+	if t.Parameters == nil {
+		return emptyToolParameters
+	}
+	return t.Parameters
+}
+
+// decodeToolArguments decodes the JSON arguments of a tool call; empty arguments decode to
+// an empty map
+func decodeToolArguments(name, arguments string) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	if strings.TrimSpace(arguments) == "" {
+		return args, nil
+	}
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments for tool call %s: %w", name, err)
+	}
+	return args, nil
+}
+
+// ToolsFromMCP converts MCP tool definitions into model tools
+func ToolsFromMCP(definitions []mcp.ToolDefinition) []Tool {
+	tools := make([]Tool, 0, len(definitions))
+	for _, definition := range definitions {
+		tools = append(tools, Tool{
+			Name:        definition.Name,
+			Description: definition.Description,
+			Parameters:  definition.InputSchema,
+		})
+	}
+	return tools
+}
+
+// AllowedTools returns the tools the persona's ToolProfile allows, in their original order.
+// Without a persona or with an empty profile the workload-level tool policy applies, so all
+// tools are returned.
+func AllowedTools(tools []Tool, persona *v1alpha1.AgentPersona) []Tool {
+	if persona == nil || len(persona.ToolProfile) == 0 {
+		return tools
+	}
+
+	allowed := make(map[string]bool, len(persona.ToolProfile))
+	for _, name := range persona.ToolProfile {
+		if name = strings.TrimSpace(name); name != "" {
+			allowed[name] = true
+		}
+	}
+	if len(allowed) == 0 {
+		return tools
+	}
+
+	filtered := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if allowed[tool.Name] {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// WorkloadTools lists the tools of the workload's MCP server and returns those its persona
+// ToolProfile allows
+func WorkloadTools(client *mcp.MCPClient, spec *v1alpha1.AgentWorkloadSpec) ([]Tool, error) {
+	definitions, err := client.ListToolDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	return AllowedTools(ToolsFromMCP(definitions), spec.Persona), nil
+}
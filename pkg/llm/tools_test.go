@@ -0,0 +1,199 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shreyansh/agentic-operator/api/v1alpha1"
+	"github.com/shreyansh/agentic-operator/pkg/mcp"
+)
+
+var restartPodTool = Tool{
+	Name:        "restart_pod",
+	Description: "Restart a pod",
+	Parameters: map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+		"required":   []interface{}{"name"},
+	},
+}
+
+// TestAllowedTools tests filtering tools by the persona ToolProfile
+func TestAllowedTools(t *testing.T) {
+	tools := []Tool{{Name: "get_status"}, restartPodTool, {Name: "delete_pvc"}}
+
+	if got := AllowedTools(tools, nil); len(got) != 3 {
+		t.Errorf("expected all tools without a persona, got %d", len(got))
+	}
+	if got := AllowedTools(tools, &v1alpha1.AgentPersona{}); len(got) != 3 {
+		t.Errorf("expected all tools with an empty profile, got %d", len(got))
+	}
+
+	got := AllowedTools(tools, &v1alpha1.AgentPersona{ToolProfile: []string{" restart_pod ", "get_status", "unknown"}})
+	if len(got) != 2 || got[0].Name != "get_status" || got[1].Name != "restart_pod" {
+		t.Errorf("expected get_status and restart_pod in original order, got %+v", got)
+	}
+}
+
+// TestWorkloadTools tests listing MCP tools for a workload persona
+func TestWorkloadTools(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tools": ["get_status", {"name": "restart_pod", "description": "Restart a pod", "inputSchema": {"type": "object"}}]}`))
+	}))
+	defer server.Close()
+
+	spec := &v1alpha1.AgentWorkloadSpec{Persona: &v1alpha1.AgentPersona{ToolProfile: []string{"restart_pod"}}}
+	tools, err := WorkloadTools(mcp.NewMCPClient(server.URL), spec)
+	if err != nil {
+		t.Fatalf("WorkloadTools failed: %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name != "restart_pod" || tools[0].Description != "Restart a pod" || tools[0].Parameters["type"] != "object" {
+		t.Errorf("unexpected tools: %+v", tools)
+	}
+}
+
+// TestOpenAICompatibleProviderToolCalls tests the OpenAI tool format in both directions
+func TestOpenAICompatibleProviderToolCalls(t *testing.T) {
+	var req openAIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "", "tool_calls": [
+			{"id": "call_1", "type": "function", "function": {"name": "restart_pod", "arguments": "{\"name\": \"web-0\"}"}}
+		]}}], "usage": {"prompt_tokens": 10, "completion_tokens": 5}}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenAICompatibleProvider("openai", server.URL, "key")
+	resp, err := provider.Chat(context.Background(), "gpt-4", &ChatRequest{
+		Messages: []Message{
+			{Role: RoleUser, Content: "web-0 is stuck"},
+			{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "call_0", Name: "get_status", Arguments: map[string]interface{}{}}}},
+			{Role: RoleTool, ToolCallID: "call_0", Content: `{"status": "CrashLoopBackOff"}`},
+		},
+		Tools:      []Tool{restartPodTool, {Name: "get_status"}},
+		ToolChoice: "restart_pod",
+	})
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	if len(req.Tools) != 2 || req.Tools[0].Type != "function" || req.Tools[0].Function.Name != "restart_pod" {
+		t.Errorf("unexpected tools: %+v", req.Tools)
+	}
+	if req.Tools[1].Function.Parameters["type"] != "object" {
+		t.Errorf("expected an empty object schema for a tool without parameters, got %v", req.Tools[1].Function.Parameters)
+	}
+	choice, _ := req.ToolChoice.(map[string]interface{})
+	if choice["type"] != "function" {
+		t.Errorf("expected a named function tool choice, got %v", req.ToolChoice)
+	}
+	if len(req.Messages) != 3 || req.Messages[1].ToolCalls[0].Function.Name != "get_status" || req.Messages[2].ToolCallID != "call_0" {
+		t.Errorf("unexpected tool conversation: %+v", req.Messages)
+	}
+
+	if len(resp.ToolCalls) != 1 {
+		t.Fatalf("expected one tool call, got %+v", resp.ToolCalls)
+	}
+	call := resp.ToolCalls[0]
+	if call.ID != "call_1" || call.Name != "restart_pod" || call.Arguments["name"] != "web-0" {
+		t.Errorf("unexpected tool call: %+v", call)
+	}
+}
+
+// TestAnthropicProviderToolCalls tests the Anthropic tool format in both directions
+func TestAnthropicProviderToolCalls(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"content": [
+			{"type": "text", "text": "Restarting."},
+			{"type": "tool_use", "id": "toolu_1", "name": "restart_pod", "input": {"name": "web-0"}}
+		], "usage": {"input_tokens": 10, "output_tokens": 5}}`))
+	}))
+	defer server.Close()
+
+	provider := NewAnthropicProvider("claude", server.URL, "key", "")
+	resp, err := provider.Chat(context.Background(), "claude-sonnet-4-5", &ChatRequest{
+		Messages: []Message{
+			{Role: RoleUser, Content: "web-0 and web-1 are stuck"},
+			{Role: RoleAssistant, ToolCalls: []ToolCall{
+				{ID: "toolu_a", Name: "get_status", Arguments: map[string]interface{}{"name": "web-0"}},
+				{ID: "toolu_b", Name: "get_status", Arguments: map[string]interface{}{"name": "web-1"}},
+			}},
+			{Role: RoleTool, ToolCallID: "toolu_a", Content: "CrashLoopBackOff"},
+			{Role: RoleTool, ToolCallID: "toolu_b", Content: "Running"},
+		},
+		Tools:      []Tool{restartPodTool},
+		ToolChoice: ToolChoiceRequired,
+	})
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	tools := body["tools"].([]interface{})
+	if tool := tools[0].(map[string]interface{}); tool["name"] != "restart_pod" || tool["input_schema"] == nil {
+		t.Errorf("unexpected tool: %v", tool)
+	}
+	if choice := body["tool_choice"].(map[string]interface{}); choice["type"] != "any" {
+		t.Errorf("expected tool choice any, got %v", choice)
+	}
+	messages := body["messages"].([]interface{})
+	if len(messages) != 3 {
+		t.Fatalf("expected tool results merged into one user message, got %v", messages)
+	}
+	toolUse := messages[1].(map[string]interface{})["content"].([]interface{})
+	if len(toolUse) != 2 || toolUse[0].(map[string]interface{})["type"] != "tool_use" {
+		t.Errorf("unexpected tool_use blocks: %v", toolUse)
+	}
+	results := messages[2].(map[string]interface{})
+	blocks := results["content"].([]interface{})
+	if results["role"] != RoleUser || len(blocks) != 2 || blocks[1].(map[string]interface{})["tool_use_id"] != "toolu_b" {
+		t.Errorf("unexpected tool results: %v", results)
+	}
+
+	if resp.Content != "Restarting." || len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Arguments["name"] != "web-0" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+// TestChatStreamToolCalls tests tool calls assembled from streamed fragments
+func TestChatStreamToolCalls(t *testing.T) {
+	openAIServer := newSSEServer(t, []string{
+		`data: {"choices": [{"delta": {"tool_calls": [{"index": 0, "id": "call_1", "type": "function", "function": {"name": "restart_pod", "arguments": ""}}]}}]}`,
+		`data: {"choices": [{"delta": {"tool_calls": [{"index": 0, "function": {"arguments": "{\"name\": "}}]}}]}`,
+		`data: {"choices": [{"delta": {"tool_calls": [{"index": 0, "function": {"arguments": "\"web-0\"}"}}]}}]}`,
+		"data: [DONE]",
+	}, time.Millisecond)
+	defer openAIServer.Close()
+
+	resp, err := NewOpenAICompatibleProvider("openai", openAIServer.URL, "key").
+		ChatStream(context.Background(), "gpt-4", &ChatRequest{Tools: []Tool{restartPodTool}}, nil)
+	if err != nil {
+		t.Fatalf("OpenAI ChatStream failed: %v", err)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].ID != "call_1" || resp.ToolCalls[0].Arguments["name"] != "web-0" {
+		t.Errorf("unexpected OpenAI tool calls: %+v", resp.ToolCalls)
+	}
+
+	anthropicServer := newSSEServer(t, []string{
+		`data: {"type": "message_start", "message": {"usage": {"input_tokens": 10}}}`,
+		`data: {"type": "content_block_start", "index": 0, "content_block": {"type": "tool_use", "id": "toolu_1", "name": "restart_pod", "input": {}}}`,
+		`data: {"type": "content_block_delta", "index": 0, "delta": {"type": "input_json_delta", "partial_json": "{\"name\": \"we"}}`,
+		`data: {"type": "content_block_delta", "index": 0, "delta": {"type": "input_json_delta", "partial_json": "b-0\"}"}}`,
+		`data: {"type": "message_stop"}`,
+	}, time.Millisecond)
+	defer anthropicServer.Close()
+
+	resp, err = NewAnthropicProvider("claude", anthropicServer.URL, "key", "").
+		ChatStream(context.Background(), "claude-sonnet-4-5", &ChatRequest{Tools: []Tool{restartPodTool}}, nil)
+	if err != nil {
+		t.Fatalf("Anthropic ChatStream failed: %v", err)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].ID != "toolu_1" || resp.ToolCalls[0].Arguments["name"] != "web-0" {
+		t.Errorf("unexpected Anthropic tool calls: %+v", resp.ToolCalls)
+	}
+}
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -130,6 +131,16 @@ func SetModelCallAttributes(span trace.Span, inputTokens, outputTokens int, succ
 	}
 }
 
+// SetTimeToFirstTokenAttribute records the time to first token of a streamed call
+func SetTimeToFirstTokenAttribute(span trace.Span, timeToFirstToken time.Duration) {
+	if span != nil {
+		span.SetAttributes(
+			attribute.Bool("call.streamed", true),
+			attribute.Int64("time_to_first_token.ms", timeToFirstToken.Milliseconds()),
+		)
+	}
+}
+
 // RecordRoutingAttributes records all routing decision attributes in the top-level span
 func RecordRoutingAttributes(span trace.Span, attrs *TracingAttributes) {
 	if span != nil && attrs != nil {
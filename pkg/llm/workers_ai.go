@@ -0,0 +1,83 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultWorkersAIModelPrefix is prepended to Workers AI model names without a catalog prefix
+	DefaultWorkersAIModelPrefix = "@cf/meta/"
+
+	// workersAIEndpoint is the OpenAI-compatible Workers AI endpoint of an account
+	workersAIEndpoint = "https://api.cloudflare.com/client/v4/accounts/%s/ai/v1"
+
+	// workersAIGatewayEndpoint is the Workers AI endpoint of an account reached through AI Gateway
+	workersAIGatewayEndpoint = "https://gateway.ai.cloudflare.com/v1/%s/%s/workers-ai/v1"
+)
+
+// WorkersAIProvider implements the Provider interface for Cloudflare Workers AI. Workers AI
+// serves the OpenAI chat completions protocol, so calls are delegated to an
+// OpenAICompatibleProvider once the model name is in catalog form.
+type WorkersAIProvider struct {
+	name string
+	chat *OpenAICompatibleProvider
+}
+
+// NewWorkersAIProvider creates a Workers AI provider for accountID. A non-empty gateway routes
+// calls through that AI Gateway; a non-empty endpoint overrides both.
+func NewWorkersAIProvider(name, accountID, gateway, endpoint, apiToken string) (*WorkersAIProvider, error) {
+	if endpoint == "" {
+		if accountID == "" {
+			return nil, fmt.Errorf("accountId required when no endpoint is set")
+		}
+		endpoint = fmt.Sprintf(workersAIEndpoint, accountID)
+		if gateway != "" {
+			endpoint = fmt.Sprintf(workersAIGatewayEndpoint, accountID, gateway)
+		}
+	}
+	return &WorkersAIProvider{
+		name: name,
+		chat: NewOpenAICompatibleProvider(name, strings.TrimSuffix(endpoint, "/"), apiToken),
+	}, nil
+}
+
+// Name returns the provider name
+func (p *WorkersAIProvider) Name() string {
+	return p.name
+}
+
+// Type returns the provider type
+func (p *WorkersAIProvider) Type() string {
+	return "workers-ai"
+}
+
+// Endpoint returns the resolved API base URL
+func (p *WorkersAIProvider) Endpoint() string {
+	return p.chat.endpoint
+}
+
+// CallModel sends a request to Workers AI
+func (p *WorkersAIProvider) CallModel(ctx context.Context, model string, prompt string) (*ModelResponse, error) {
+	return p.Chat(ctx, model, NewPromptRequest(prompt))
+}
+
+// Chat sends a chat request to Workers AI
+func (p *WorkersAIProvider) Chat(ctx context.Context, model string, req *ChatRequest) (*ModelResponse, error) {
+	return p.chat.Chat(ctx, WorkersAIModelName(model), req)
+}
+
+// ChatStream streams a chat request from Workers AI
+func (p *WorkersAIProvider) ChatStream(ctx context.Context, model string, req *ChatRequest, handler StreamHandler) (*ModelResponse, error) {
+	return p.chat.ChatStream(ctx, WorkersAIModelName(model), req, handler)
+}
+
+// WorkersAIModelName returns model in Workers AI catalog form: names that already carry a
+// catalog prefix ("@cf/", "@hf/") are kept, bare names get DefaultWorkersAIModelPrefix
+func WorkersAIModelName(model string) string {
+	if strings.HasPrefix(model, "@") {
+		return model
+	}
+	return DefaultWorkersAIModelPrefix + model
+}
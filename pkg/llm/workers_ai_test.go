@@ -0,0 +1,73 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestWorkersAIProviderEndpoint tests endpoint derivation from account, gateway and override
+func TestWorkersAIProviderEndpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		accountID string
+		gateway   string
+		endpoint  string
+		expected  string
+	}{
+		{"account", "acct", "", "", "https://api.cloudflare.com/client/v4/accounts/acct/ai/v1"},
+		{"gateway", "acct", "prod", "", "https://gateway.ai.cloudflare.com/v1/acct/prod/workers-ai/v1"},
+		{"override", "acct", "prod", "https://proxy.internal/ai/v1/", "https://proxy.internal/ai/v1"},
+	}
+	for _, tt := range tests {
+		provider, err := NewWorkersAIProvider("cf", tt.accountID, tt.gateway, tt.endpoint, "token")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if provider.Endpoint() != tt.expected {
+			t.Errorf("%s: expected endpoint %s, got %s", tt.name, tt.expected, provider.Endpoint())
+		}
+	}
+
+	if _, err := NewWorkersAIProvider("cf", "", "", "", "token"); err == nil {
+		t.Error("expected error without accountId or endpoint")
+	}
+}
+
+// TestWorkersAIProviderCallModel tests that bare model names are sent in catalog form
+func TestWorkersAIProviderCallModel(t *testing.T) {
+	var models []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer cf-token" {
+			t.Errorf("expected bearer token, got %q", got)
+		}
+		var req struct {
+			Model string `json:"model"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		models = append(models, req.Model)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "ok"}}], "usage": {"prompt_tokens": 3, "completion_tokens": 1}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewWorkersAIProvider("cf", "", "", server.URL, "cf-token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, model := range []string{"llama-2-7b-chat-int8", "@cf/mistral/mistral-7b-instruct-v0.1"} {
+		if _, err := provider.CallModel(context.Background(), model, "hello"); err != nil {
+			t.Fatalf("CallModel(%s) failed: %v", model, err)
+		}
+	}
+
+	expected := []string{"@cf/meta/llama-2-7b-chat-int8", "@cf/mistral/mistral-7b-instruct-v0.1"}
+	for i := range expected {
+		if models[i] != expected[i] {
+			t.Errorf("expected model %s, got %s", expected[i], models[i])
+		}
+	}
+}
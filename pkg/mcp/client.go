@@ -18,6 +18,7 @@ package mcp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -50,6 +51,19 @@ type ToolListResponse struct {
 	Tools []string `json:"tools"`
 }
 
+// ToolDefinition describes a tool and the JSON Schema of its parameters
+type ToolDefinition struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"inputSchema,omitempty"`
+}
+
+// toolDefinitionListResponse is the tool list of servers that describe their tools; entries
+// may also be plain tool names
+type toolDefinitionListResponse struct {
+	Tools []json.RawMessage `json:"tools"`
+}
+
 // NewMCPClient creates a new MCP client for the given endpoint
 func NewMCPClient(endpoint string) *MCPClient {
 	return &MCPClient{
@@ -81,8 +95,49 @@ func (c *MCPClient) ListTools() ([]string, error) {
 	return toolResp.Tools, nil
 }
 
+// ListToolDefinitions queries the MCP server for available tools with their descriptions
+// and parameter schemas. Tools the server lists by name only have just a Name.
+func (c *MCPClient) ListToolDefinitions() ([]ToolDefinition, error) {
+	resp, err := c.client.Get(fmt.Sprintf("%s/tools", c.endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("MCP server returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var toolResp toolDefinitionListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&toolResp); err != nil {
+		return nil, fmt.Errorf("failed to decode tool list: %w", err)
+	}
+
+	definitions := make([]ToolDefinition, 0, len(toolResp.Tools))
+	for _, raw := range toolResp.Tools {
+		var name string
+		if err := json.Unmarshal(raw, &name); err == nil {
+			definitions = append(definitions, ToolDefinition{Name: name})
+			continue
+		}
+		var definition ToolDefinition
+		if err := json.Unmarshal(raw, &definition); err != nil {
+			return nil, fmt.Errorf("failed to decode tool definition: %w", err)
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
 // CallTool calls a specific tool on the MCP server with the given parameters
 func (c *MCPClient) CallTool(toolName string, params map[string]interface{}) (map[string]interface{}, error) {
+	return c.CallToolContext(context.Background(), toolName, params)
+}
+
+// CallToolContext calls a tool like CallTool, aborting the request when ctx is
+// cancelled or its deadline (e.g. the workload execution timeout) expires
+func (c *MCPClient) CallToolContext(ctx context.Context, toolName string, params map[string]interface{}) (map[string]interface{}, error) {
 	req := ToolRequest{
 		Tool:   toolName,
 		Params: params,
@@ -93,11 +148,13 @@ func (c *MCPClient) CallTool(toolName string, params map[string]interface{}) (ma
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.client.Post(
-		fmt.Sprintf("%s/call_tool", c.endpoint),
-		"application/json",
-		bytes.NewReader(payload),
-	)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/call_tool", c.endpoint), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call tool: %w", err)
 	}
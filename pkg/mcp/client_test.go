@@ -17,7 +17,11 @@ limitations under the License.
 package mcp
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -52,6 +56,31 @@ func TestMCPClient_ListTools(t *testing.T) {
 	t.Logf("Successfully listed %d tools", len(tools))
 }
 
+func TestMCPClient_ListToolDefinitions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tools": [
+			"get_status",
+			{"name": "restart_pod", "description": "Restart a pod", "inputSchema": {"type": "object", "required": ["name"]}}
+		]}`))
+	}))
+	defer server.Close()
+
+	definitions, err := NewMCPClient(server.URL).ListToolDefinitions()
+	if err != nil {
+		t.Fatalf("ListToolDefinitions failed: %v", err)
+	}
+
+	if len(definitions) != 2 {
+		t.Fatalf("Expected 2 tool definitions, got %d", len(definitions))
+	}
+	if definitions[0].Name != "get_status" || definitions[0].InputSchema != nil {
+		t.Errorf("Expected name-only get_status definition, got %+v", definitions[0])
+	}
+	if definitions[1].Name != "restart_pod" || definitions[1].Description != "Restart a pod" || definitions[1].InputSchema["type"] != "object" {
+		t.Errorf("Unexpected restart_pod definition: %+v", definitions[1])
+	}
+}
+
 func TestMCPClient_CallTool_GetStatus(t *testing.T) {
 	// Start mock server
 	mockServer := NewMockServer(":9002")
@@ -139,6 +168,25 @@ func TestMCPClient_ConnectionError(t *testing.T) {
 	t.Logf("Successfully caught connection error: %v", err)
 }
 
+func TestMCPClient_CallToolContext_DeadlineExceeded(t *testing.T) {
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer slowServer.Close()
+
+	client := NewMCPClient(slowServer.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.CallToolContext(ctx, "get_status", map[string]interface{}{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline error, got %v", err)
+	}
+}
+
 func TestToolRequest_Marshalling(t *testing.T) {
 	req := ToolRequest{
 		Tool: "test_tool",
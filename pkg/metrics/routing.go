@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -15,6 +17,9 @@ type RoutingMetrics struct {
 
 	// EstimatedCostGauge tracks estimated API costs
 	EstimatedCostGauge prometheus.GaugeVec
+
+	// TimeToFirstTokenHistogram tracks how long streamed calls take to produce their first token
+	TimeToFirstTokenHistogram prometheus.HistogramVec
 }
 
 // NewRoutingMetrics initializes routing metrics
@@ -41,6 +46,14 @@ func NewRoutingMetrics() *RoutingMetrics {
 			},
 			[]string{"provider"},
 		),
+		TimeToFirstTokenHistogram: *promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "agentic_model_time_to_first_token_seconds",
+				Help:    "Time from sending a streamed model call to receiving its first token, by provider and model",
+				Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60},
+			},
+			[]string{"provider", "model"},
+		),
 	}
 }
 
@@ -55,6 +68,11 @@ func (m *RoutingMetrics) RecordTokenUsage(provider, model string, inputTokens, o
 	m.TokenUsageCounter.WithLabelValues(provider, model, "output").Add(float64(outputTokens))
 }
 
+// RecordTimeToFirstToken records the time to first token of a streamed call
+func (m *RoutingMetrics) RecordTimeToFirstToken(provider, model string, timeToFirstToken time.Duration) {
+	m.TimeToFirstTokenHistogram.WithLabelValues(provider, model).Observe(timeToFirstToken.Seconds())
+}
+
 // UpdateEstimatedCost updates the estimated cost gauge
 // This should be called periodically (e.g., every minute) with cumulative cost
 func (m *RoutingMetrics) UpdateEstimatedCost(provider string, costUSD float64) {
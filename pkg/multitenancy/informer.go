@@ -0,0 +1,125 @@
+package multitenancy
+
+import (
+	"fmt"
+	"time"
+
+	toolscache "k8s.io/client-go/tools/cache"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// TenantInformer is the part of an informer TenantSync registers with.
+type TenantInformer interface {
+	AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error)
+}
+
+// TenantSync keeps a Resolver, QuotaManager and SLAMonitor in line with the Tenant
+// resources in the cluster: added and updated Tenants are applied, and Tenants being
+// deleted are deactivated. Any of the three may be nil.
+type TenantSync struct {
+	resolver *Resolver
+	quotas   *QuotaManager
+	sla      *SLAMonitor
+}
+
+// NewTenantSync creates a TenantSync feeding the given components.
+func NewTenantSync(resolver *Resolver, quotas *QuotaManager, sla *SLAMonitor) *TenantSync {
+	return &TenantSync{resolver: resolver, quotas: quotas, sla: sla}
+}
+
+// Register subscribes to Tenant events of informer.
+func (s *TenantSync) Register(informer TenantInformer) error {
+	_, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if tenant, ok := obj.(*agenticv1alpha1.Tenant); ok {
+				s.Apply(tenant)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if tenant, ok := obj.(*agenticv1alpha1.Tenant); ok {
+				s.Apply(tenant)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if unknown, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = unknown.Obj
+			}
+			if tenant, ok := obj.(*agenticv1alpha1.Tenant); ok {
+				s.Remove(tenant)
+			}
+		},
+	})
+	return err
+}
+
+// Apply registers a Tenant or updates its registration. A Tenant being deleted is
+// deactivated.
+func (s *TenantSync) Apply(tenant *agenticv1alpha1.Tenant) {
+	if !tenant.DeletionTimestamp.IsZero() {
+		s.Remove(tenant)
+		return
+	}
+	if tenant.Spec.Namespace == "" {
+		return
+	}
+	if s.resolver != nil {
+		_ = s.resolver.UpsertTenant(TenantContextFromTenant(tenant))
+	}
+	// Each component gets its own copy, so none sees another's updates half-applied
+	if s.quotas != nil {
+		s.quotas.UpsertTenant(TenantContextFromTenant(tenant))
+		// Token usage recorded before a restart is recovered from the Tenant status
+		if start := tenant.Status.TokensMonthStart; start != nil {
+			s.quotas.ObserveTokenUsage(tenant.Name, tenant.Status.TokensUsedThisMonth, start.Time)
+		}
+	}
+	if s.sla != nil {
+		s.sla.UpsertTenant(TenantContextFromTenant(tenant))
+	}
+}
+
+// Remove deactivates a Tenant and stops tracking its quota and SLA.
+func (s *TenantSync) Remove(tenant *agenticv1alpha1.Tenant) {
+	if s.resolver != nil {
+		_ = s.resolver.DeactivateTenant(tenant.Name)
+	}
+	if s.quotas != nil {
+		s.quotas.RemoveTenant(tenant.Name)
+	}
+	if s.sla != nil {
+		s.sla.RemoveTenant(tenant.Name)
+	}
+}
+
+// TenantContextFromTenant converts a Tenant resource into the TenantContext the
+// multi-tenancy components work with.
+func TenantContextFromTenant(tenant *agenticv1alpha1.Tenant) *TenantContext {
+	// An invalid time zone is reported on the Tenant; its windows use the default meanwhile
+	loc, _ := TenantQuotaLocation(tenant.Spec.Quotas)
+	return &TenantContext{
+		Name:             tenant.Name,
+		Namespace:        tenant.Spec.Namespace,
+		ResourceQuotaCPU: tenant.Spec.Quotas.CPULimit,
+		ResourceQuotaRAM: tenant.Spec.Quotas.MemoryLimit,
+		SLATargetPercent: tenant.Spec.SLATarget,
+		MaxMonthlyTokens: tenant.Spec.Quotas.MaxMonthlyTokens,
+		MaxConcurrent:    tenant.Spec.Quotas.MaxConcurrent,
+		QuotaLocation:    loc,
+		CreatedAt:        tenant.CreationTimestamp.Time,
+		UpdatedAt:        time.Now(),
+		IsActive:         true,
+	}
+}
+
+// TenantQuotaLocation parses quotas.timezone. It returns nil when no time zone is set.
+func TenantQuotaLocation(quotas agenticv1alpha1.TenantQuotas) (*time.Location, error) {
+	if quotas.Timezone == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(quotas.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quotas.timezone %q: %w", quotas.Timezone, err)
+	}
+	return loc, nil
+}
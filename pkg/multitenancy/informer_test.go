@@ -0,0 +1,128 @@
+package multitenancy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	agenticv1alpha1 "github.com/shreyansh/agentic-operator/api/v1alpha1"
+)
+
+// fakeInformer hands its registered handler to the test
+type fakeInformer struct {
+	handler toolscache.ResourceEventHandler
+}
+
+func (f *fakeInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	f.handler = handler
+	return nil, nil
+}
+
+func newTenantCR(name, namespace string, slaTarget float64) *agenticv1alpha1.Tenant {
+	return &agenticv1alpha1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agentic-system"},
+		Spec: agenticv1alpha1.TenantSpec{
+			Namespace: namespace,
+			SLATarget: slaTarget,
+			Quotas:    agenticv1alpha1.TenantQuotas{CPULimit: "4", MemoryLimit: "8Gi"},
+		},
+	}
+}
+
+func TestTenantSyncAppliesInformerEvents(t *testing.T) {
+	resolver := NewResolver()
+	quotas := NewQuotaManager(nil)
+	sla := NewSLAMonitor(nil)
+	informer := &fakeInformer{}
+	if err := NewTenantSync(resolver, quotas, sla).Register(informer); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	ctx := context.Background()
+
+	// Add
+	tenant := newTenantCR("acme", "team-acme", 99.0)
+	informer.handler.OnAdd(tenant, false)
+	extracted, err := resolver.ExtractFromNamespace(ctx, "team-acme")
+	if err != nil {
+		t.Fatalf("expected tenant resolved after add: %v", err)
+	}
+	if extracted.ResourceQuotaCPU != "4" || extracted.ResourceQuotaRAM != "8Gi" {
+		t.Errorf("expected quotas copied, got %+v", extracted)
+	}
+	if _, err := quotas.GetStatus("acme"); err != nil {
+		t.Errorf("expected quota tracking after add: %v", err)
+	}
+
+	// Token limits apply and usage in the Tenant status is recovered
+	withTokens := tenant.DeepCopy()
+	withTokens.Spec.Quotas.MaxMonthlyTokens = 5000
+	withTokens.Spec.Quotas.MaxConcurrent = 2
+	withTokens.Status.TokensUsedThisMonth = 1200
+	withTokens.Status.TokensMonthStart = &metav1.Time{Time: time.Now()}
+	informer.handler.OnUpdate(tenant, withTokens)
+	quotaStatus, err := quotas.GetStatus("acme")
+	if err != nil || quotaStatus.MaxMonthlyTokens != 5000 || quotaStatus.TokensThisMonth != 1200 {
+		t.Errorf("expected token quota and usage applied, got %+v (%v)", quotaStatus, err)
+	}
+	if extracted, _ := resolver.ExtractFromNamespace(ctx, "team-acme"); extracted == nil || extracted.MaxConcurrent != 2 {
+		t.Errorf("expected maxConcurrent applied, got %+v", extracted)
+	}
+	_ = sla.RecordFailure("acme")
+
+	// Update keeps the SLA history and applies the new target
+	updated := tenant.DeepCopy()
+	updated.Spec.SLATarget = 0
+	informer.handler.OnUpdate(tenant, updated)
+	status, err := sla.GetStatus("acme")
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if status.FailureCount != 1 || status.SLATarget != 0 {
+		t.Errorf("expected 1 failure and target 0, got %+v", status)
+	}
+
+	// A Tenant being deleted is deactivated
+	terminating := updated.DeepCopy()
+	now := metav1.Now()
+	terminating.DeletionTimestamp = &now
+	informer.handler.OnUpdate(updated, terminating)
+	if _, err := resolver.ExtractFromNamespace(ctx, "team-acme"); err == nil {
+		t.Error("expected terminating tenant not resolved")
+	}
+	if _, err := quotas.GetStatus("acme"); err != ErrTenantNotFound {
+		t.Errorf("expected quota tracking stopped, got %v", err)
+	}
+
+	// Delete, including a tombstone of a missed deletion
+	informer.handler.OnAdd(tenant, false)
+	informer.handler.OnDelete(toolscache.DeletedFinalStateUnknown{Key: "agentic-system/acme", Obj: tenant})
+	if _, err := resolver.GetTenant("acme"); err == nil {
+		t.Error("expected deleted tenant inactive")
+	}
+	if _, err := sla.GetStatus("acme"); err != ErrTenantNotFound {
+		t.Errorf("expected SLA tracking stopped, got %v", err)
+	}
+}
+
+func TestTenantContextFromTenantQuotaLocation(t *testing.T) {
+	tenant := newTenantCR("acme", "team-acme", 99)
+	if tc := TenantContextFromTenant(tenant); tc.QuotaLocation != nil {
+		t.Fatalf("expected the default time zone without quotas.timezone, got %v", tc.QuotaLocation)
+	}
+
+	tenant.Spec.Quotas.Timezone = "Asia/Tokyo"
+	if tc := TenantContextFromTenant(tenant); tc.QuotaLocation == nil || tc.QuotaLocation.String() != "Asia/Tokyo" {
+		t.Fatalf("expected Asia/Tokyo, got %v", tc.QuotaLocation)
+	}
+
+	tenant.Spec.Quotas.Timezone = "Mars/Olympus"
+	if _, err := TenantQuotaLocation(tenant.Spec.Quotas); err == nil {
+		t.Fatal("expected an invalid time zone rejected")
+	}
+	if tc := TenantContextFromTenant(tenant); tc.QuotaLocation != nil {
+		t.Fatalf("expected the default time zone for an invalid one, got %v", tc.QuotaLocation)
+	}
+}
@@ -4,49 +4,95 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"sync"
 	"time"
 )
 
 // ErrTenantNotFound is returned when a tenant cannot be identified.
 var ErrTenantNotFound = errors.New("tenant not found")
 
+// CustomerLabel is set on a tenant namespace to the name of the Tenant that owns it.
+const CustomerLabel = "agentic-customer"
+
+// NamespaceLabels returns the labels of a namespace.
+type NamespaceLabels func(ctx context.Context, namespace string) (map[string]string, error)
+
+// ResolverOption configures a Resolver.
+type ResolverOption func(*Resolver)
+
+// WithNamespaceLabels makes the resolver identify tenants by the CustomerLabel of their
+// namespace.
+func WithNamespaceLabels(lookup NamespaceLabels) ResolverOption {
+	return func(r *Resolver) {
+		r.namespaceLabels = lookup
+	}
+}
+
 // Resolver extracts tenant context from various sources.
 type Resolver struct {
-	tenants map[string]*TenantContext
+	mu              sync.RWMutex
+	tenants         map[string]*TenantContext
+	namespaceLabels NamespaceLabels
 }
 
 // NewResolver creates a new tenant resolver.
-func NewResolver() *Resolver {
-	return &Resolver{
+func NewResolver(opts ...ResolverOption) *Resolver {
+	r := &Resolver{
 		tenants: make(map[string]*TenantContext),
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // RegisterTenant registers a tenant for isolation.
 func (r *Resolver) RegisterTenant(tenant *TenantContext) error {
+	if tenant != nil && tenant.License == nil {
+		return errors.New("license required")
+	}
+	return r.UpsertTenant(tenant)
+}
+
+// UpsertTenant adds a tenant or replaces the registered one of the same name. Unlike
+// RegisterTenant it does not require a license.
+func (r *Resolver) UpsertTenant(tenant *TenantContext) error {
 	if tenant == nil || tenant.Name == "" {
 		return errors.New("tenant name required")
 	}
 	if tenant.Namespace == "" {
 		return errors.New("namespace required")
 	}
-	if tenant.License == nil {
-		return errors.New("license required")
-	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.tenants[tenant.Name] = tenant
 	return nil
 }
 
-// ExtractFromNamespace returns the tenant for a given namespace.
-// Namespaces follow the pattern: agentic-customer-<name>
+// ExtractFromNamespace returns the tenant for a given namespace. The tenant is named by the
+// namespace's CustomerLabel when namespace labels are available, and otherwise found by the
+// namespace it was registered with.
 func (r *Resolver) ExtractFromNamespace(ctx context.Context, namespace string) (*TenantContext, error) {
-	// Pattern: agentic-customer-<name>
-	if !strings.HasPrefix(namespace, "agentic-customer-") {
-		return nil, ErrTenantNotFound
+	tenantName := ""
+	if r.namespaceLabels != nil {
+		labels, err := r.namespaceLabels(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read labels of namespace %s: %w", namespace, err)
+		}
+		tenantName = labels[CustomerLabel]
 	}
 
-	tenantName := strings.TrimPrefix(namespace, "agentic-customer-")
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if tenantName == "" {
+		for name, tenant := range r.tenants {
+			if tenant.Namespace == namespace {
+				tenantName = name
+				break
+			}
+		}
+	}
 	if tenantName == "" {
 		return nil, ErrTenantNotFound
 	}
@@ -55,6 +101,10 @@ func (r *Resolver) ExtractFromNamespace(ctx context.Context, namespace string) (
 	if !ok {
 		return nil, fmt.Errorf("tenant not registered: %s", tenantName)
 	}
+	// A label naming a tenant that was provisioned elsewhere does not grant its identity
+	if tenant.Namespace != namespace {
+		return nil, fmt.Errorf("%w: namespace %s is not the namespace of tenant %s", ErrTenantNotFound, namespace, tenantName)
+	}
 
 	if !tenant.IsActive {
 		return nil, fmt.Errorf("tenant inactive: %s", tenantName)
@@ -65,6 +115,13 @@ func (r *Resolver) ExtractFromNamespace(ctx context.Context, namespace string) (
 
 // GetTenant returns a tenant by name.
 func (r *Resolver) GetTenant(name string) (*TenantContext, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.activeTenant(name)
+}
+
+// activeTenant returns an active tenant by name (must hold lock).
+func (r *Resolver) activeTenant(name string) (*TenantContext, error) {
 	tenant, ok := r.tenants[name]
 	if !ok {
 		return nil, ErrTenantNotFound
@@ -92,6 +149,8 @@ func (r *Resolver) ValidateTenant(tenantName string) error {
 
 // ListTenants returns all active tenants.
 func (r *Resolver) ListTenants() []*TenantContext {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	out := make([]*TenantContext, 0, len(r.tenants))
 	for _, tenant := range r.tenants {
 		if tenant.IsActive {
@@ -103,7 +162,9 @@ func (r *Resolver) ListTenants() []*TenantContext {
 
 // DeactivateTenant marks a tenant as inactive (soft delete).
 func (r *Resolver) DeactivateTenant(name string) error {
-	tenant, err := r.GetTenant(name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tenant, err := r.activeTenant(name)
 	if err != nil {
 		return err
 	}
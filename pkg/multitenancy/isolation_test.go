@@ -2,6 +2,7 @@ package multitenancy
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -85,3 +86,47 @@ func TestDeactivateTenant(t *testing.T) {
 		t.Error("expected error for inactive tenant")
 	}
 }
+
+func TestResolverExtractFromNamespaceLabel(t *testing.T) {
+	labels := map[string]map[string]string{
+		"team-acme":  {CustomerLabel: "acme"},
+		"team-other": {CustomerLabel: "acme"},
+	}
+	r := NewResolver(WithNamespaceLabels(func(_ context.Context, namespace string) (map[string]string, error) {
+		return labels[namespace], nil
+	}))
+	_ = r.UpsertTenant(&TenantContext{Name: "acme", Namespace: "team-acme", IsActive: true})
+	ctx := context.Background()
+
+	extracted, err := r.ExtractFromNamespace(ctx, "team-acme")
+	if err != nil {
+		t.Fatalf("ExtractFromNamespace failed: %v", err)
+	}
+	if extracted.Name != "acme" {
+		t.Errorf("expected acme, got %s", extracted.Name)
+	}
+	// A namespace labelled for a tenant provisioned elsewhere does not resolve to it
+	if _, err := r.ExtractFromNamespace(ctx, "team-other"); !errors.Is(err, ErrTenantNotFound) {
+		t.Errorf("expected ErrTenantNotFound for foreign namespace, got %v", err)
+	}
+	if _, err := r.ExtractFromNamespace(ctx, "default"); !errors.Is(err, ErrTenantNotFound) {
+		t.Errorf("expected ErrTenantNotFound for unlabelled namespace, got %v", err)
+	}
+}
+
+func TestResolverUpsertTenantWithoutLicense(t *testing.T) {
+	r := NewResolver()
+	if err := r.UpsertTenant(&TenantContext{Name: "acme", Namespace: "team-acme", IsActive: true}); err != nil {
+		t.Fatalf("UpsertTenant failed: %v", err)
+	}
+	if err := r.RegisterTenant(&TenantContext{Name: "other", Namespace: "team-other"}); err == nil {
+		t.Error("expected RegisterTenant to require a license")
+	}
+	_ = r.DeactivateTenant("acme")
+	if err := r.UpsertTenant(&TenantContext{Name: "acme", Namespace: "team-acme", IsActive: true}); err != nil {
+		t.Fatalf("UpsertTenant failed: %v", err)
+	}
+	if _, err := r.GetTenant("acme"); err != nil {
+		t.Errorf("expected re-registered tenant active, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package multitenancy
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -12,46 +13,144 @@ var ErrQuotaExceeded = errors.New("quota exceeded")
 // ErrBudgetExceeded is returned when cost budget is exhausted.
 var ErrBudgetExceeded = errors.New("cost budget exceeded")
 
-// QuotaManager tracks per-tenant quotas and enforces limits.
+// ErrTokenBudgetExceeded is returned when a call would exceed the tenant's monthly token budget.
+var ErrTokenBudgetExceeded = errors.New("monthly token budget exceeded")
+
+// QuotaOption configures a QuotaManager.
+type QuotaOption func(*QuotaManager)
+
+// WithQuotaLocation sets the time zone whose midnight and first of the month start the
+// daily and monthly quota windows of tenants without their own QuotaLocation. The
+// default is UTC.
+func WithQuotaLocation(loc *time.Location) QuotaOption {
+	return func(qm *QuotaManager) {
+		if loc != nil {
+			qm.location = loc
+		}
+	}
+}
+
+// QuotaManager tracks per-tenant quotas and enforces limits. Workloads per day reset at
+// the start of each daily window; cost and tokens reset at the start of each monthly
+// window.
 type QuotaManager struct {
-	mu      sync.RWMutex
-	tenants map[string]*quotaTracker
+	mu       sync.RWMutex
+	tenants  map[string]*quotaTracker
+	location *time.Location
+
+	// restored holds checkpointed usage of tenants that are not tracked yet
+	restored map[string]QuotaState
+}
+
+// Reservation holds quota for an admitted workload until the workload finishes.
+type Reservation struct {
+	// Workload is the namespace/name of the workload holding the reservation
+	Workload   string    `json:"workload"`
+	CostUSD    float64   `json:"costUSD"`
+	ReservedAt time.Time `json:"reservedAt"`
 }
 
 type quotaTracker struct {
 	tenant        *TenantContext
 	workloadsUsed int
 	costUsed      float64
-	lastResetDate time.Time
+	tokensUsed    int64
+
+	// lastResetDate and lastMonthlyReset are when the daily and monthly usage last reset
+	lastResetDate    time.Time
+	lastMonthlyReset time.Time
+
+	// reservations are keyed by workload UID; their cost counts against the budget until
+	// it is committed or released
+	reservations map[string]Reservation
+	costReserved float64
+
+	// synced is the usage last loaded from or saved to the StateStore; what changed since
+	// is merged into the stored state on the next restore
+	synced QuotaState
+}
+
+// newQuotaTracker starts tracking a tenant from now.
+func newQuotaTracker(tenant *TenantContext) *quotaTracker {
+	now := time.Now()
+	return &quotaTracker{
+		tenant:           tenant,
+		lastResetDate:    now,
+		lastMonthlyReset: now,
+		reservations:     map[string]Reservation{},
+	}
 }
 
 // NewQuotaManager creates a quota manager for the given tenants.
-func NewQuotaManager(tenants []*TenantContext) *QuotaManager {
+func NewQuotaManager(tenants []*TenantContext, opts ...QuotaOption) *QuotaManager {
 	qm := &QuotaManager{
-		tenants: make(map[string]*quotaTracker),
+		tenants:  make(map[string]*quotaTracker),
+		location: time.UTC,
+	}
+	for _, opt := range opts {
+		opt(qm)
 	}
 	for _, tenant := range tenants {
-		qm.tenants[tenant.Name] = &quotaTracker{
-			tenant:        tenant,
-			lastResetDate: time.Now(),
-		}
+		qm.tenants[tenant.Name] = newQuotaTracker(tenant)
 	}
 	return qm
 }
 
+// dayStart returns the start of the day of t in loc.
+func dayStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// monthStart returns the start of the calendar month of t in loc.
+func monthStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+}
+
+// QuotaLocation returns the time zone of a tenant's quota windows: its own QuotaLocation,
+// or the manager's default.
+func (qm *QuotaManager) QuotaLocation(tenant *TenantContext) *time.Location {
+	if tenant.QuotaLocation != nil {
+		return tenant.QuotaLocation
+	}
+	return qm.location
+}
+
+// roll resets the usage of windows that ended before now (must hold write lock).
+func (qm *QuotaManager) roll(tracker *quotaTracker, now time.Time) {
+	loc := qm.QuotaLocation(tracker.tenant)
+	if tracker.lastResetDate.Before(dayStart(now, loc)) {
+		tracker.workloadsUsed = 0
+		tracker.synced.WorkloadsUsed = 0
+		tracker.lastResetDate = now
+	}
+	if tracker.lastMonthlyReset.Before(monthStart(now, loc)) {
+		tracker.costUsed = 0
+		tracker.tokensUsed = 0
+		tracker.synced.CostUsed = 0
+		tracker.synced.TokensUsed = 0
+		tracker.lastMonthlyReset = now
+	}
+}
+
 // GetStatus returns the current quota status for a tenant.
 func (qm *QuotaManager) GetStatus(tenantName string) (*QuotaStatus, error) {
-	qm.mu.RLock()
-	defer qm.mu.RUnlock()
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
 
 	tracker, ok := qm.tenants[tenantName]
 	if !ok {
 		return nil, ErrTenantNotFound
 	}
+	qm.roll(tracker, time.Now())
 
 	tenant := tracker.tenant
+	workloadsExceeded := tenant.QuotaPerDay > 0 && tracker.workloadsUsed >= tenant.QuotaPerDay
+	budgetExceeded := tenant.CostBudgetUSD > 0 && tracker.costUsed+tracker.costReserved >= tenant.CostBudgetUSD
+	tokensExceeded := tenant.MaxMonthlyTokens > 0 && tracker.tokensUsed >= tenant.MaxMonthlyTokens
 	workloadsRemaining := tenant.QuotaPerDay - tracker.workloadsUsed
-	costRemaining := tenant.CostBudgetUSD - tracker.costUsed
+	costRemaining := tenant.CostBudgetUSD - tracker.costUsed - tracker.costReserved
 
 	percentUsed := float64(0)
 	if tenant.QuotaPerDay > 0 {
@@ -64,56 +163,224 @@ func (qm *QuotaManager) GetStatus(tenantName string) (*QuotaStatus, error) {
 		WorkloadsUsed:      tracker.workloadsUsed,
 		WorkloadsRemaining: workloadsRemaining,
 		CostThisMonth:      tracker.costUsed,
+		CostReserved:       tracker.costReserved,
 		CostRemaining:      costRemaining,
+		Reservations:       len(tracker.reservations),
+		TokensThisMonth:    tracker.tokensUsed,
+		MaxMonthlyTokens:   tenant.MaxMonthlyTokens,
 		PercentageUsed:     percentUsed,
 		LastReset:          tracker.lastResetDate,
-		IsExceeded:         tracker.workloadsUsed >= tenant.QuotaPerDay || tracker.costUsed >= tenant.CostBudgetUSD,
+		IsExceeded:         workloadsExceeded || budgetExceeded || tokensExceeded,
 	}, nil
 }
 
-// CheckAndConsume checks if quota is available, and if so, consumes it.
+// CheckAndConsume checks if quota is available, and if so, consumes it. A zero
+// QuotaPerDay or CostBudgetUSD leaves that limit unenforced. Prefer Reserve, which
+// charges a workload once however often it is checked.
 func (qm *QuotaManager) CheckAndConsume(tenantName string, costUSD float64) error {
 	qm.mu.Lock()
 	defer qm.mu.Unlock()
 
-	qm.maybeReset(tenantName) // Reset daily quota if needed
-
 	tracker, ok := qm.tenants[tenantName]
 	if !ok {
 		return ErrTenantNotFound
 	}
+	qm.roll(tracker, time.Now())
 
-	tenant := tracker.tenant
+	if err := tracker.admit(costUSD); err != nil {
+		return err
+	}
+	tracker.workloadsUsed++
+	tracker.costUsed += costUSD
+	return nil
+}
 
-	// Check workload quota
-	if tracker.workloadsUsed >= tenant.QuotaPerDay {
+// admit checks whether one more workload costing costUSD fits the tenant's daily
+// workload quota and cost budget (must hold lock).
+func (t *quotaTracker) admit(costUSD float64) error {
+	if t.tenant.QuotaPerDay > 0 && t.workloadsUsed >= t.tenant.QuotaPerDay {
 		return ErrQuotaExceeded
 	}
-
-	// Check cost budget
-	if tracker.costUsed+costUSD > tenant.CostBudgetUSD {
+	if t.tenant.CostBudgetUSD > 0 && t.costUsed+t.costReserved+costUSD > t.tenant.CostBudgetUSD {
 		return ErrBudgetExceeded
 	}
+	return nil
+}
+
+// Reserve admits the workload with the given UID: it counts the workload against the daily
+// workload quota and holds estimateUSD of the cost budget until Commit or Release.
+// Reserving again for a UID that holds a reservation is a no-op, so a workload is charged
+// once however often it is reconciled.
+func (qm *QuotaManager) Reserve(tenantName, uid, workload string, estimateUSD float64) error {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	tracker, ok := qm.tenants[tenantName]
+	if !ok {
+		return ErrTenantNotFound
+	}
+	if _, held := tracker.reservations[uid]; held {
+		return nil
+	}
+	qm.roll(tracker, time.Now())
 
-	// Consume quota
+	if err := tracker.admit(estimateUSD); err != nil {
+		return err
+	}
 	tracker.workloadsUsed++
-	tracker.costUsed += costUSD
+	tracker.costReserved += estimateUSD
+	tracker.reservations[uid] = Reservation{Workload: workload, CostUSD: estimateUSD, ReservedAt: time.Now()}
+	return nil
+}
 
+// Hold reserves estimateUSD of the cost budget for an admitted workload that holds no
+// reservation, such as one whose reservation was settled or lost while it was retried.
+// The workload was admitted before, so it is neither checked against the quota nor counted
+// against the daily workload quota again. Holding for a UID that holds a reservation is a
+// no-op.
+func (qm *QuotaManager) Hold(tenantName, uid, workload string, estimateUSD float64) error {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	tracker, ok := qm.tenants[tenantName]
+	if !ok {
+		return ErrTenantNotFound
+	}
+	if _, held := tracker.reservations[uid]; held {
+		return nil
+	}
+	tracker.costReserved += estimateUSD
+	tracker.reservations[uid] = Reservation{Workload: workload, CostUSD: estimateUSD, ReservedAt: time.Now()}
 	return nil
 }
 
-// maybeReset resets daily quota if the day has changed (must hold lock).
-func (qm *QuotaManager) maybeReset(tenantName string) {
-	tracker := qm.tenants[tenantName]
+// Commit settles the reservation of a finished workload: the reserved cost is returned
+// and actualUSD is charged to the current monthly window instead. It reports whether the
+// UID held a reservation; settling again is a no-op.
+func (qm *QuotaManager) Commit(tenantName, uid string, actualUSD float64) (bool, error) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	tracker, ok := qm.tenants[tenantName]
+	if !ok {
+		return false, ErrTenantNotFound
+	}
+	if !tracker.unreserve(uid) {
+		return false, nil
+	}
+	qm.roll(tracker, time.Now())
+	tracker.costUsed += actualUSD
+	return true, nil
+}
+
+// Release returns the reserved cost of a workload that failed or was deleted. The
+// workload still counts against the daily workload quota. It reports whether the UID
+// held a reservation.
+func (qm *QuotaManager) Release(tenantName, uid string) (bool, error) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	tracker, ok := qm.tenants[tenantName]
+	if !ok {
+		return false, ErrTenantNotFound
+	}
+	return tracker.unreserve(uid), nil
+}
+
+// ReleaseWorkload releases the reservations held by the workload namespace/name, for a
+// workload that was deleted before its UID could be read. It returns how many were
+// released.
+func (qm *QuotaManager) ReleaseWorkload(tenantName, workload string) (int, error) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	tracker, ok := qm.tenants[tenantName]
+	if !ok {
+		return 0, ErrTenantNotFound
+	}
+	released := 0
+	for uid, reservation := range tracker.reservations {
+		if reservation.Workload == workload && tracker.unreserve(uid) {
+			released++
+		}
+	}
+	return released, nil
+}
+
+// unreserve drops a reservation and returns its cost to the budget (must hold lock).
+func (t *quotaTracker) unreserve(uid string) bool {
+	reservation, held := t.reservations[uid]
+	if !held {
+		return false
+	}
+	delete(t.reservations, uid)
+	t.costReserved -= reservation.CostUSD
+	if len(t.reservations) == 0 {
+		// Avoid drift from repeated floating-point additions and subtractions
+		t.costReserved = 0
+	}
+	return true
+}
+
+// CheckTokens returns ErrTokenBudgetExceeded if using tokens more this month would
+// exceed the tenant's MaxMonthlyTokens. A zero MaxMonthlyTokens leaves tokens unlimited.
+func (qm *QuotaManager) CheckTokens(tenantName string, tokens int64) error {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	tracker, ok := qm.tenants[tenantName]
+	if !ok {
+		return ErrTenantNotFound
+	}
+	limit := tracker.tenant.MaxMonthlyTokens
+	if limit <= 0 {
+		return nil
+	}
+	qm.roll(tracker, time.Now())
+	if tracker.tokensUsed+tokens > limit {
+		return fmt.Errorf("%w: tenant %s used %d of %d tokens this month, the call needs up to %d",
+			ErrTokenBudgetExceeded, tenantName, tracker.tokensUsed, limit, tokens)
+	}
+	return nil
+}
+
+// RecordTokens adds tokens used by a completed call to the tenant's monthly usage.
+func (qm *QuotaManager) RecordTokens(tenantName string, tokens int64) error {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	tracker, ok := qm.tenants[tenantName]
+	if !ok {
+		return ErrTenantNotFound
+	}
+	qm.roll(tracker, time.Now())
+	tracker.tokensUsed += tokens
+	return nil
+}
+
+// ObserveTokenUsage raises the tenant's monthly usage to tokens when usage recorded
+// elsewhere (such as the Tenant status) since windowStart is higher than the tracked
+// usage. Usage of an earlier monthly window and unknown tenants are ignored.
+func (qm *QuotaManager) ObserveTokenUsage(tenantName string, tokens int64, windowStart time.Time) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	tracker, ok := qm.tenants[tenantName]
+	if !ok {
+		return
+	}
 	now := time.Now()
-	if now.YearDay() != tracker.lastResetDate.YearDay() {
-		// New day — reset workload quota but keep cost running total
-		tracker.workloadsUsed = 0
-		tracker.lastResetDate = now
+	qm.roll(tracker, now)
+	if windowStart.Before(monthStart(now, qm.QuotaLocation(tracker.tenant))) || tokens <= tracker.tokensUsed {
+		return
 	}
+	// Observed usage was recorded elsewhere, so it is not a change to merge on restore
+	tracker.synced.TokensUsed += tokens - tracker.tokensUsed
+	tracker.tokensUsed = tokens
 }
 
-// Reset manually resets a tenant's quota (admin operation).
+// Reset manually resets a tenant's quota (admin operation). Reservations of running
+// workloads are kept.
 func (qm *QuotaManager) Reset(tenantName string) error {
 	qm.mu.Lock()
 	defer qm.mu.Unlock()
@@ -123,9 +390,12 @@ func (qm *QuotaManager) Reset(tenantName string) error {
 		return ErrTenantNotFound
 	}
 
+	now := time.Now()
 	tracker.workloadsUsed = 0
 	tracker.costUsed = 0
-	tracker.lastResetDate = time.Now()
+	tracker.tokensUsed = 0
+	tracker.lastResetDate = now
+	tracker.lastMonthlyReset = now
 	return nil
 }
 
@@ -133,8 +403,34 @@ func (qm *QuotaManager) Reset(tenantName string) error {
 func (qm *QuotaManager) AddTenant(tenant *TenantContext) {
 	qm.mu.Lock()
 	defer qm.mu.Unlock()
-	qm.tenants[tenant.Name] = &quotaTracker{
-		tenant:        tenant,
-		lastResetDate: time.Now(),
+	qm.track(tenant)
+}
+
+// UpsertTenant adds a tenant to quota tracking, or updates the limits of a tracked tenant
+// while keeping its usage.
+func (qm *QuotaManager) UpsertTenant(tenant *TenantContext) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+	if tracker, ok := qm.tenants[tenant.Name]; ok {
+		tracker.tenant = tenant
+		return
+	}
+	qm.track(tenant)
+}
+
+// track starts tracking a tenant, with its checkpointed usage if any (must hold lock).
+func (qm *QuotaManager) track(tenant *TenantContext) {
+	tracker := newQuotaTracker(tenant)
+	if state, ok := qm.restored[tenant.Name]; ok {
+		qm.restoreTracker(tracker, state)
+		delete(qm.restored, tenant.Name)
 	}
+	qm.tenants[tenant.Name] = tracker
+}
+
+// RemoveTenant stops tracking a tenant's quota.
+func (qm *QuotaManager) RemoveTenant(tenantName string) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+	delete(qm.tenants, tenantName)
 }
@@ -1,6 +1,7 @@
 package multitenancy
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -140,3 +141,158 @@ func TestQuotaManagerDailyReset(t *testing.T) {
 		t.Errorf("expected 1 used after daily reset, got %d", status.WorkloadsUsed)
 	}
 }
+
+func TestQuotaManagerZeroLimitsAreUnlimited(t *testing.T) {
+	qm := NewQuotaManager([]*TenantContext{{Name: "test", Namespace: "team-test", IsActive: true}})
+	for i := 0; i < 3; i++ {
+		if err := qm.CheckAndConsume("test", 10.0); err != nil {
+			t.Fatalf("consume %d: expected no limit, got %v", i, err)
+		}
+	}
+	status, _ := qm.GetStatus("test")
+	if status.IsExceeded {
+		t.Error("expected quota without limits never exceeded")
+	}
+}
+
+func TestQuotaManagerUpsertKeepsUsage(t *testing.T) {
+	qm := NewQuotaManager(nil)
+	if err := qm.CheckAndConsume("test", 1.0); err != ErrTenantNotFound {
+		t.Fatalf("expected ErrTenantNotFound, got %v", err)
+	}
+	qm.UpsertTenant(&TenantContext{Name: "test", QuotaPerDay: 2, IsActive: true})
+	_ = qm.CheckAndConsume("test", 1.0)
+	qm.UpsertTenant(&TenantContext{Name: "test", QuotaPerDay: 5, IsActive: true})
+
+	status, _ := qm.GetStatus("test")
+	if status.WorkloadsUsed != 1 || status.WorkloadsPerDay != 5 {
+		t.Errorf("expected 1 of 5 used, got %d of %d", status.WorkloadsUsed, status.WorkloadsPerDay)
+	}
+	qm.RemoveTenant("test")
+	if _, err := qm.GetStatus("test"); err != ErrTenantNotFound {
+		t.Errorf("expected removed tenant untracked, got %v", err)
+	}
+}
+
+func TestQuotaManagerMonthlyTokens(t *testing.T) {
+	tenant := &TenantContext{Name: "test", Namespace: "team-test", MaxMonthlyTokens: 1000, IsActive: true}
+	qm := NewQuotaManager([]*TenantContext{tenant})
+
+	if err := qm.CheckTokens("test", 600); err != nil {
+		t.Fatalf("expected call within budget, got %v", err)
+	}
+	if err := qm.RecordTokens("test", 600); err != nil {
+		t.Fatalf("RecordTokens failed: %v", err)
+	}
+	if err := qm.CheckTokens("test", 500); !errors.Is(err, ErrTokenBudgetExceeded) {
+		t.Fatalf("expected ErrTokenBudgetExceeded, got %v", err)
+	}
+	status, _ := qm.GetStatus("test")
+	if status.TokensThisMonth != 600 || status.MaxMonthlyTokens != 1000 || status.IsExceeded {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	// Usage reported by the Tenant status only ever raises the tracked usage
+	qm.ObserveTokenUsage("test", 5000, time.Now().AddDate(0, -2, 0))
+	qm.ObserveTokenUsage("test", 100, time.Now())
+	qm.ObserveTokenUsage("test", 1000, time.Now())
+	status, _ = qm.GetStatus("test")
+	if status.TokensThisMonth != 1000 || !status.IsExceeded {
+		t.Errorf("expected observed usage to exhaust the budget, got %+v", status)
+	}
+
+	// A new month starts from zero
+	qm.tenants["test"].lastMonthlyReset = time.Now().AddDate(0, -1, 0)
+	if err := qm.CheckTokens("test", 900); err != nil {
+		t.Errorf("expected usage reset in a new month, got %v", err)
+	}
+}
+
+func TestQuotaManagerUnlimitedTokens(t *testing.T) {
+	qm := NewQuotaManager([]*TenantContext{{Name: "test", Namespace: "team-test", IsActive: true}})
+	if err := qm.CheckTokens("test", 1<<40); err != nil {
+		t.Errorf("expected a zero MaxMonthlyTokens to be unlimited, got %v", err)
+	}
+	if err := qm.CheckTokens("unknown", 1); err != ErrTenantNotFound {
+		t.Errorf("expected ErrTenantNotFound, got %v", err)
+	}
+}
+
+func TestQuotaManagerReservations(t *testing.T) {
+	tenant := &TenantContext{Name: "test", Namespace: "team-test", QuotaPerDay: 5, CostBudgetUSD: 25, IsActive: true}
+	qm := NewQuotaManager([]*TenantContext{tenant})
+
+	// Reserving repeatedly for the same workload charges it once
+	for i := 0; i < 3; i++ {
+		if err := qm.Reserve("test", "uid-a", "team-test/a", 10); err != nil {
+			t.Fatalf("Reserve %d failed: %v", i, err)
+		}
+	}
+	status, _ := qm.GetStatus("test")
+	if status.WorkloadsUsed != 1 || status.CostReserved != 10 || status.Reservations != 1 {
+		t.Fatalf("expected one reservation of $10, got %+v", status)
+	}
+
+	// Reservations hold the budget until they settle
+	if err := qm.Reserve("test", "uid-b", "team-test/b", 10); err != nil {
+		t.Fatalf("Reserve b failed: %v", err)
+	}
+	if err := qm.Reserve("test", "uid-c", "team-test/c", 10); err != ErrBudgetExceeded {
+		t.Fatalf("expected ErrBudgetExceeded while $20 is reserved, got %v", err)
+	}
+
+	// Committing charges the actual cost; releasing frees the reservation
+	if settled, err := qm.Commit("test", "uid-a", 1.5); !settled || err != nil {
+		t.Fatalf("Commit failed: settled=%v err=%v", settled, err)
+	}
+	if settled, _ := qm.Commit("test", "uid-a", 1.5); settled {
+		t.Fatal("expected a second Commit to be a no-op")
+	}
+	if released, err := qm.ReleaseWorkload("test", "team-test/b"); released != 1 || err != nil {
+		t.Fatalf("ReleaseWorkload failed: released=%d err=%v", released, err)
+	}
+	status, _ = qm.GetStatus("test")
+	if status.CostThisMonth != 1.5 || status.CostReserved != 0 || status.Reservations != 0 || status.WorkloadsUsed != 2 {
+		t.Fatalf("expected $1.50 charged and nothing reserved, got %+v", status)
+	}
+	if err := qm.Reserve("test", "uid-c", "team-test/c", 10); err != nil {
+		t.Fatalf("expected budget available after settlement, got %v", err)
+	}
+
+	// A workload admitted before holds a reservation again without a quota check
+	if err := qm.Hold("test", "uid-a", "team-test/a", 20); err != nil {
+		t.Fatalf("Hold failed: %v", err)
+	}
+	status, _ = qm.GetStatus("test")
+	if status.CostReserved != 30 || status.Reservations != 2 || status.WorkloadsUsed != 3 {
+		t.Fatalf("expected the held reservation without another workload counted, got %+v", status)
+	}
+}
+
+func TestQuotaManagerWindowsFollowTimeZone(t *testing.T) {
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+	tenant := &TenantContext{Name: "test", Namespace: "team-test", QuotaPerDay: 1, IsActive: true}
+	qm := NewQuotaManager([]*TenantContext{tenant}, WithQuotaLocation(tokyo))
+	_ = qm.CheckAndConsume("test", 0)
+	tracker := qm.tenants["test"]
+
+	// Midnight in the quota time zone starts a new day, whatever the UTC date is
+	midnight := dayStart(time.Now(), tokyo)
+	tracker.lastResetDate = midnight.Add(-time.Minute)
+	if err := qm.CheckAndConsume("test", 0); err != nil {
+		t.Fatalf("expected a new day after midnight UTC+9, got %v", err)
+	}
+	tracker.lastResetDate = midnight.Add(time.Minute)
+	if err := qm.CheckAndConsume("test", 0); err != ErrQuotaExceeded {
+		t.Fatalf("expected the day to continue after midnight UTC+9, got %v", err)
+	}
+
+	// A tenant's own time zone overrides the default
+	tenant.QuotaLocation = time.UTC
+	if loc := qm.QuotaLocation(tenant); loc != time.UTC {
+		t.Fatalf("expected the tenant time zone, got %v", loc)
+	}
+	if got := monthStart(time.Date(2026, 3, 31, 20, 0, 0, 0, time.UTC), tokyo); !got.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, tokyo)) {
+		t.Fatalf("expected April in UTC+9, got %v", got)
+	}
+}
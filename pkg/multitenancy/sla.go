@@ -9,6 +9,9 @@ import (
 type SLAMonitor struct {
 	mu       sync.RWMutex
 	trackers map[string]*slaTracker
+
+	// restored holds checkpointed history of tenants that are not tracked yet
+	restored map[string]SLAState
 }
 
 type slaTracker struct {
@@ -18,6 +21,9 @@ type slaTracker struct {
 	breachCount    int
 	lastBreach     *time.Time
 	breachDetected bool
+
+	// synced is the history last loaded from or saved to the StateStore
+	synced SLAState
 }
 
 // NewSLAMonitor creates an SLA monitor for the given tenants.
@@ -118,9 +124,36 @@ func (sm *SLAMonitor) calcSuccessRate(tracker *slaTracker) float64 {
 func (sm *SLAMonitor) AddTenant(tenant *TenantContext) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
-	sm.trackers[tenant.Name] = &slaTracker{
-		tenant: tenant,
+	sm.track(tenant)
+}
+
+// UpsertTenant adds a tenant to SLA tracking, or updates the SLA target of a tracked
+// tenant while keeping its history.
+func (sm *SLAMonitor) UpsertTenant(tenant *TenantContext) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if tracker, ok := sm.trackers[tenant.Name]; ok {
+		tracker.tenant = tenant
+		return
 	}
+	sm.track(tenant)
+}
+
+// track starts tracking a tenant, with its checkpointed history if any (must hold lock).
+func (sm *SLAMonitor) track(tenant *TenantContext) {
+	tracker := &slaTracker{tenant: tenant}
+	if state, ok := sm.restored[tenant.Name]; ok {
+		tracker.restore(state)
+		delete(sm.restored, tenant.Name)
+	}
+	sm.trackers[tenant.Name] = tracker
+}
+
+// RemoveTenant stops tracking a tenant's SLA.
+func (sm *SLAMonitor) RemoveTenant(tenantName string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	delete(sm.trackers, tenantName)
 }
 
 // GetBreachedTenants returns all tenants currently in breach.
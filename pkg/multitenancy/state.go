@@ -0,0 +1,327 @@
+package multitenancy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ErrStateConflict is returned by a StateStore when the stored state changed since it was
+// last loaded or saved, such as by another replica.
+var ErrStateConflict = errors.New("stored tenant state changed concurrently")
+
+// stateVersion is the version of the State format.
+const stateVersion = 1
+
+// restoreRetryInitial is the first delay before a failed restore is retried; the delay
+// doubles up to the checkpoint interval.
+const restoreRetryInitial = time.Second
+
+// StateStore persists the QuotaManager and SLAMonitor counters so they survive operator
+// restarts and leader changes.
+type StateStore interface {
+	// Load returns the stored state, or an empty State when nothing was stored yet.
+	Load(ctx context.Context) (*State, error)
+	// Save replaces the stored state. It returns ErrStateConflict when the stored state
+	// changed since the last Load or Save.
+	Save(ctx context.Context, state *State) error
+}
+
+// State is a checkpoint of the per-tenant quota and SLA counters.
+type State struct {
+	Version int                   `json:"version"`
+	SavedAt time.Time             `json:"savedAt"`
+	Quotas  map[string]QuotaState `json:"quotas,omitempty"`
+	SLA     map[string]SLAState   `json:"sla,omitempty"`
+}
+
+// QuotaState is the checkpointed usage of one tenant's quota.
+type QuotaState struct {
+	WorkloadsUsed    int                    `json:"workloadsUsed"`
+	CostUsed         float64                `json:"costUsed"`
+	TokensUsed       int64                  `json:"tokensUsed"`
+	LastResetDate    time.Time              `json:"lastResetDate"`
+	LastMonthlyReset time.Time              `json:"lastMonthlyReset"`
+	Reservations     map[string]Reservation `json:"reservations,omitempty"`
+}
+
+// SLAState is the checkpointed SLA history of one tenant.
+type SLAState struct {
+	SuccessCount   int        `json:"successCount"`
+	FailureCount   int        `json:"failureCount"`
+	BreachCount    int        `json:"breachCount"`
+	LastBreach     *time.Time `json:"lastBreach,omitempty"`
+	BreachDetected bool       `json:"breachDetected"`
+}
+
+// Snapshot returns the usage of every tracked tenant.
+func (qm *QuotaManager) Snapshot() map[string]QuotaState {
+	qm.mu.RLock()
+	defer qm.mu.RUnlock()
+	out := make(map[string]QuotaState, len(qm.tenants))
+	for name, tracker := range qm.tenants {
+		out[name] = tracker.state()
+	}
+	return out
+}
+
+// state copies the tracker's usage and reservations.
+func (t *quotaTracker) state() QuotaState {
+	reservations := make(map[string]Reservation, len(t.reservations))
+	for uid, reservation := range t.reservations {
+		reservations[uid] = reservation
+	}
+	return QuotaState{
+		WorkloadsUsed:    t.workloadsUsed,
+		CostUsed:         t.costUsed,
+		TokensUsed:       t.tokensUsed,
+		LastResetDate:    t.lastResetDate,
+		LastMonthlyReset: t.lastMonthlyReset,
+		Reservations:     reservations,
+	}
+}
+
+// Restore merges checkpointed usage into the tracked tenants. Usage recorded and
+// reservations made or settled since a tenant's state was last loaded or saved are kept,
+// so neither admissions before the first restore nor changes since the last checkpoint
+// are lost. Usage of tenants that are not tracked yet is applied when they are added.
+func (qm *QuotaManager) Restore(states map[string]QuotaState) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+	qm.restored = make(map[string]QuotaState, len(states))
+	for name, state := range states {
+		if tracker, ok := qm.tenants[name]; ok {
+			qm.restoreTracker(tracker, state)
+		} else {
+			qm.restored[name] = state
+		}
+	}
+}
+
+// restoreTracker merges checkpointed usage and reservations into the tracker: the stored
+// state, with the windows that ended since the checkpoint reset, plus what changed in the
+// tracker since it was last synced. Token usage observed since, such as from the Tenant
+// status, is kept when it is higher (must hold lock).
+func (qm *QuotaManager) restoreTracker(t *quotaTracker, state QuotaState) {
+	now := time.Now()
+	qm.roll(t, now)
+	stored := &quotaTracker{
+		tenant:           t.tenant,
+		workloadsUsed:    state.WorkloadsUsed,
+		costUsed:         state.CostUsed,
+		tokensUsed:       state.TokensUsed,
+		lastResetDate:    state.LastResetDate,
+		lastMonthlyReset: state.LastMonthlyReset,
+	}
+	qm.roll(stored, now)
+
+	t.workloadsUsed = max(0, stored.workloadsUsed+t.workloadsUsed-t.synced.WorkloadsUsed)
+	t.costUsed = max(0, stored.costUsed+t.costUsed-t.synced.CostUsed)
+	t.tokensUsed = max(stored.tokensUsed+t.tokensUsed-t.synced.TokensUsed, t.tokensUsed)
+
+	// Stored reservations settled here since are dropped; reservations made here are kept
+	reservations := make(map[string]Reservation, len(state.Reservations)+len(t.reservations))
+	for uid, reservation := range state.Reservations {
+		_, synced := t.synced.Reservations[uid]
+		if _, live := t.reservations[uid]; synced && !live {
+			continue
+		}
+		reservations[uid] = reservation
+	}
+	for uid, reservation := range t.reservations {
+		if _, synced := t.synced.Reservations[uid]; !synced {
+			reservations[uid] = reservation
+		}
+	}
+	t.reservations = reservations
+	t.costReserved = 0
+	for _, reservation := range reservations {
+		t.costReserved += reservation.CostUSD
+	}
+	t.synced = t.state()
+}
+
+// markSynced records states as saved, so the next restore only merges what changed since.
+func (qm *QuotaManager) markSynced(states map[string]QuotaState) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+	for name, state := range states {
+		if tracker, ok := qm.tenants[name]; ok {
+			tracker.synced = state
+		}
+	}
+}
+
+// Snapshot returns the SLA history of every tracked tenant.
+func (sm *SLAMonitor) Snapshot() map[string]SLAState {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	out := make(map[string]SLAState, len(sm.trackers))
+	for name, tracker := range sm.trackers {
+		out[name] = tracker.state()
+	}
+	return out
+}
+
+// Restore merges the checkpointed SLA history into the tracked tenants, keeping the
+// results recorded since a tenant's history was last loaded or saved. History of tenants
+// that are not tracked yet is applied when they are added.
+func (sm *SLAMonitor) Restore(states map[string]SLAState) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.restored = make(map[string]SLAState, len(states))
+	for name, state := range states {
+		if tracker, ok := sm.trackers[name]; ok {
+			tracker.restore(state)
+		} else {
+			sm.restored[name] = state
+		}
+	}
+}
+
+// markSynced records states as saved, so the next restore only merges what changed since.
+func (sm *SLAMonitor) markSynced(states map[string]SLAState) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	for name, state := range states {
+		if tracker, ok := sm.trackers[name]; ok {
+			tracker.synced = state
+		}
+	}
+}
+
+func (t *slaTracker) state() SLAState {
+	return SLAState{
+		SuccessCount:   t.successCount,
+		FailureCount:   t.failureCount,
+		BreachCount:    t.breachCount,
+		LastBreach:     t.lastBreach,
+		BreachDetected: t.breachDetected,
+	}
+}
+
+func (t *slaTracker) restore(state SLAState) {
+	changed := t.state() != t.synced
+	t.successCount = state.SuccessCount + t.successCount - t.synced.SuccessCount
+	t.failureCount = state.FailureCount + t.failureCount - t.synced.FailureCount
+	t.breachCount = state.BreachCount + t.breachCount - t.synced.BreachCount
+	if t.lastBreach == nil || (state.LastBreach != nil && state.LastBreach.After(*t.lastBreach)) {
+		t.lastBreach = state.LastBreach
+	}
+	// The breach flag follows the latest result: a local one if any was recorded since
+	if !changed {
+		t.breachDetected = state.BreachDetected
+	}
+	t.synced = t.state()
+}
+
+// Checkpointer restores the QuotaManager and SLAMonitor from a StateStore when it starts,
+// then saves their counters every interval and once more when it stops. It only runs on
+// the elected leader, the only replica whose reconcilers change the counters.
+type Checkpointer struct {
+	store    StateStore
+	quotas   *QuotaManager
+	sla      *SLAMonitor
+	interval time.Duration
+}
+
+// NewCheckpointer creates a Checkpointer persisting quotas and sla, either of which may be
+// nil, to store.
+func NewCheckpointer(store StateStore, quotas *QuotaManager, sla *SLAMonitor, interval time.Duration) *Checkpointer {
+	return &Checkpointer{store: store, quotas: quotas, sla: sla, interval: interval}
+}
+
+// NeedLeaderElection makes the manager run the Checkpointer on the leader only.
+func (c *Checkpointer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start restores the stored state, retrying with a backoff of up to interval until it
+// succeeds, and then checkpoints until ctx is done. Nothing is saved before the restore
+// succeeded, so a stored checkpoint is never replaced by empty counters; usage recorded
+// meanwhile is merged into the restored state.
+func (c *Checkpointer) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("tenant-state")
+
+	retry := min(restoreRetryInitial, c.interval)
+	for {
+		err := c.Restore(ctx)
+		if err == nil {
+			break
+		}
+		log.Error(err, "failed to restore tenant state, retrying", "after", retry)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retry):
+		}
+		retry = min(2*retry, c.interval)
+	}
+	log.Info("Restored tenant quota and SLA state")
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// The final checkpoint must finish before another replica takes over
+			saveCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if err := c.Checkpoint(saveCtx); err != nil {
+				log.Error(err, "failed to save tenant state on shutdown")
+			}
+			return nil
+		case <-ticker.C:
+			err := c.Checkpoint(ctx)
+			if errors.Is(err, ErrStateConflict) {
+				// Another writer got in first: merge our changes into what it stored
+				log.Info("Stored tenant state changed concurrently, merging it")
+				if err = c.Restore(ctx); err == nil {
+					err = c.Checkpoint(ctx)
+				}
+			}
+			if err != nil {
+				log.Error(err, "failed to checkpoint tenant state")
+			}
+		}
+	}
+}
+
+// Restore loads the stored state into the QuotaManager and SLAMonitor.
+func (c *Checkpointer) Restore(ctx context.Context) error {
+	state, err := c.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if c.quotas != nil {
+		c.quotas.Restore(state.Quotas)
+	}
+	if c.sla != nil {
+		c.sla.Restore(state.SLA)
+	}
+	return nil
+}
+
+// Checkpoint saves the current counters of the QuotaManager and SLAMonitor.
+func (c *Checkpointer) Checkpoint(ctx context.Context) error {
+	state := &State{Version: stateVersion, SavedAt: time.Now().UTC()}
+	if c.quotas != nil {
+		state.Quotas = c.quotas.Snapshot()
+	}
+	if c.sla != nil {
+		state.SLA = c.sla.Snapshot()
+	}
+	if err := c.store.Save(ctx, state); err != nil {
+		return err
+	}
+	if c.quotas != nil {
+		c.quotas.markSynced(state.Quotas)
+	}
+	if c.sla != nil {
+		c.sla.markSynced(state.SLA)
+	}
+	return nil
+}
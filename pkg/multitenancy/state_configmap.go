@@ -0,0 +1,95 @@
+package multitenancy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+
+// StateConfigMapKey is the ConfigMap data key holding the JSON-encoded State.
+const StateConfigMapKey = "state.json"
+
+// ConfigMapStateStore stores the tenant State in a ConfigMap. Every Save carries the
+// resourceVersion of the last Load or Save, so a writer that missed a newer checkpoint,
+// such as a replica that lost leadership, gets ErrStateConflict instead of overwriting it.
+type ConfigMapStateStore struct {
+	client client.Client
+	key    types.NamespacedName
+
+	mu              sync.Mutex
+	resourceVersion string
+}
+
+// NewConfigMapStateStore creates a store keeping the State in the ConfigMap name in
+// namespace. Use a client that reads from the API server rather than a cache, so Load sees
+// the latest checkpoint.
+func NewConfigMapStateStore(c client.Client, namespace, name string) *ConfigMapStateStore {
+	return &ConfigMapStateStore{client: c, key: types.NamespacedName{Namespace: namespace, Name: name}}
+}
+
+// Load reads the State from the ConfigMap; a missing ConfigMap is an empty State.
+func (s *ConfigMapStateStore) Load(ctx context.Context) (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cm := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, s.key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			s.resourceVersion = ""
+			return &State{Version: stateVersion}, nil
+		}
+		return nil, fmt.Errorf("failed to read tenant state ConfigMap %s: %w", s.key, err)
+	}
+	s.resourceVersion = cm.ResourceVersion
+
+	state := &State{Version: stateVersion}
+	if data := cm.Data[StateConfigMapKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), state); err != nil {
+			return nil, fmt.Errorf("failed to decode tenant state ConfigMap %s: %w", s.key, err)
+		}
+	}
+	return state, nil
+}
+
+// Save writes the State to the ConfigMap, creating it on the first Save.
+func (s *ConfigMapStateStore) Save(ctx context.Context, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode tenant state: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            s.key.Name,
+			Namespace:       s.key.Namespace,
+			ResourceVersion: s.resourceVersion,
+			Labels:          map[string]string{"app.kubernetes.io/managed-by": "agentic-operator"},
+		},
+		Data: map[string]string{StateConfigMapKey: string(data)},
+	}
+	if s.resourceVersion == "" {
+		err = s.client.Create(ctx, cm)
+	} else {
+		err = s.client.Update(ctx, cm)
+	}
+	if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("%w: ConfigMap %s", ErrStateConflict, s.key)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save tenant state ConfigMap %s: %w", s.key, err)
+	}
+	s.resourceVersion = cm.ResourceVersion
+	return nil
+}
@@ -0,0 +1,239 @@
+package multitenancy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// memoryStateStore keeps the State in memory and counts saves
+type memoryStateStore struct {
+	mu    sync.Mutex
+	state *State
+	saves int
+}
+
+func (m *memoryStateStore) Load(_ context.Context) (*State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == nil {
+		return &State{Version: stateVersion}, nil
+	}
+	return m.state, nil
+}
+
+func (m *memoryStateStore) Save(_ context.Context, state *State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = state
+	m.saves++
+	return nil
+}
+
+func newStateTestTenant() *TenantContext {
+	return &TenantContext{Name: "acme", Namespace: "team-acme", QuotaPerDay: 10, MaxMonthlyTokens: 1000, SLATargetPercent: 90, IsActive: true}
+}
+
+func TestCheckpointerRestoresCounters(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStateStore{}
+
+	quotas := NewQuotaManager([]*TenantContext{newStateTestTenant()})
+	sla := NewSLAMonitor([]*TenantContext{newStateTestTenant()})
+	_ = quotas.CheckAndConsume("acme", 2.5)
+	_ = quotas.Reserve("acme", "uid-1", "team-acme/report", 4)
+	_ = quotas.RecordTokens("acme", 300)
+	_ = sla.RecordSuccess("acme")
+	_ = sla.RecordFailure("acme")
+	if err := NewCheckpointer(store, quotas, sla, time.Minute).Checkpoint(ctx); err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+
+	// A new leader restores before the informer has added the tenant
+	restoredQuotas := NewQuotaManager(nil)
+	restoredSLA := NewSLAMonitor(nil)
+	if err := NewCheckpointer(store, restoredQuotas, restoredSLA, time.Minute).Restore(ctx); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	restoredQuotas.UpsertTenant(newStateTestTenant())
+	restoredSLA.UpsertTenant(newStateTestTenant())
+
+	quotaStatus, err := restoredQuotas.GetStatus("acme")
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if quotaStatus.WorkloadsUsed != 2 || quotaStatus.CostThisMonth != 2.5 || quotaStatus.TokensThisMonth != 300 ||
+		quotaStatus.Reservations != 1 || quotaStatus.CostReserved != 4 {
+		t.Errorf("expected quota usage restored, got %+v", quotaStatus)
+	}
+	slaStatus, err := restoredSLA.GetStatus("acme")
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if slaStatus.SuccessCount != 1 || slaStatus.FailureCount != 1 || slaStatus.BreachCount != 1 || !slaStatus.IsBreached {
+		t.Errorf("expected SLA history restored, got %+v", slaStatus)
+	}
+}
+
+func TestQuotaRestoreKeepsHigherObservedTokens(t *testing.T) {
+	quotas := NewQuotaManager([]*TenantContext{newStateTestTenant()})
+	quotas.ObserveTokenUsage("acme", 700, time.Now())
+	snapshot := quotas.Snapshot()
+	state := snapshot["acme"]
+	state.TokensUsed = 400
+	quotas.Restore(map[string]QuotaState{"acme": state})
+
+	status, _ := quotas.GetStatus("acme")
+	if status.TokensThisMonth != 700 {
+		t.Errorf("expected observed usage of 700 kept, got %d", status.TokensThisMonth)
+	}
+}
+
+func TestQuotaRestoreKeepsReservationsMadeBeforeIt(t *testing.T) {
+	quotas := NewQuotaManager([]*TenantContext{newStateTestTenant()})
+	// Admitted before the first restore completed
+	_ = quotas.Reserve("acme", "uid-live", "team-acme/live", 3)
+
+	quotas.Restore(map[string]QuotaState{"acme": {
+		WorkloadsUsed:    2,
+		CostUsed:         5,
+		LastResetDate:    time.Now(),
+		LastMonthlyReset: time.Now(),
+		Reservations:     map[string]Reservation{"uid-stored": {Workload: "team-acme/stored", CostUSD: 4}},
+	}})
+
+	status, _ := quotas.GetStatus("acme")
+	if status.WorkloadsUsed != 3 || status.CostThisMonth != 5 || status.Reservations != 2 || status.CostReserved != 7 {
+		t.Errorf("expected stored and live usage merged, got %+v", status)
+	}
+}
+
+func TestCheckpointerConflictKeepsChangesSinceCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStateStore{}
+	quotas := NewQuotaManager([]*TenantContext{newStateTestTenant()})
+	_ = quotas.Reserve("acme", "uid-1", "team-acme/first", 4)
+	checkpointer := NewCheckpointer(store, quotas, nil, time.Minute)
+	if err := checkpointer.Checkpoint(ctx); err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+
+	// Changes here since the checkpoint
+	_, _ = quotas.Commit("acme", "uid-1", 1.5)
+	_ = quotas.RecordTokens("acme", 200)
+	// Changes by another writer since the checkpoint
+	other := store.state.Quotas["acme"]
+	other.TokensUsed = 300
+	other.Reservations = map[string]Reservation{"uid-1": other.Reservations["uid-1"], "uid-2": {Workload: "team-acme/second", CostUSD: 2}}
+	other.WorkloadsUsed = 2
+	store.state = &State{Version: stateVersion, Quotas: map[string]QuotaState{"acme": other}}
+
+	if err := checkpointer.Restore(ctx); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	status, _ := quotas.GetStatus("acme")
+	if status.TokensThisMonth != 500 || status.WorkloadsUsed != 2 || status.CostThisMonth != 1.5 ||
+		status.Reservations != 1 || status.CostReserved != 2 {
+		t.Errorf("expected both writers' changes merged, got %+v", status)
+	}
+}
+
+// flakyStateStore fails the first Load
+type flakyStateStore struct {
+	memoryStateStore
+	failed bool
+}
+
+func (f *flakyStateStore) Load(ctx context.Context) (*State, error) {
+	if !f.failed {
+		f.failed = true
+		return nil, errors.New("apiserver unavailable")
+	}
+	return f.memoryStateStore.Load(ctx)
+}
+
+func TestCheckpointerRetriesRestoreBeforeInterval(t *testing.T) {
+	store := &flakyStateStore{}
+	checkpointer := NewCheckpointer(store, NewQuotaManager([]*TenantContext{newStateTestTenant()}), nil, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := checkpointer.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	// Only the final checkpoint saves, which requires the retried restore to succeed
+	if store.saves != 1 {
+		t.Fatalf("expected the restore retried within the hour interval, got %d saves", store.saves)
+	}
+}
+
+func TestCheckpointerStartSavesUntilStopped(t *testing.T) {
+	store := &memoryStateStore{}
+	quotas := NewQuotaManager([]*TenantContext{newStateTestTenant()})
+	_ = quotas.CheckAndConsume("acme", 1)
+	checkpointer := NewCheckpointer(store, quotas, nil, 10*time.Millisecond)
+	if !checkpointer.NeedLeaderElection() {
+		t.Fatal("expected the checkpointer to run on the leader only")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := checkpointer.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if store.saves < 2 {
+		t.Fatalf("expected periodic and final checkpoints, got %d saves", store.saves)
+	}
+	if store.state.Quotas["acme"].WorkloadsUsed != 1 {
+		t.Errorf("expected usage saved, got %+v", store.state.Quotas)
+	}
+}
+
+func TestConfigMapStateStore(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+	leader := NewConfigMapStateStore(c, "agentic-system", "agentic-tenant-state")
+
+	state, err := leader.Load(ctx)
+	if err != nil || len(state.Quotas) != 0 {
+		t.Fatalf("expected empty state before the first save, got %+v (%v)", state, err)
+	}
+	saved := &State{Version: stateVersion, Quotas: map[string]QuotaState{"acme": {WorkloadsUsed: 3, CostUsed: 1.5}}}
+	if err := leader.Save(ctx, saved); err != nil {
+		t.Fatalf("first Save failed: %v", err)
+	}
+	if err := leader.Save(ctx, saved); err != nil {
+		t.Fatalf("second Save failed: %v", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "agentic-system", Name: "agentic-tenant-state"}, cm); err != nil {
+		t.Fatalf("expected ConfigMap created: %v", err)
+	}
+	if cm.Data[StateConfigMapKey] == "" {
+		t.Fatalf("expected state in %s, got %v", StateConfigMapKey, cm.Data)
+	}
+
+	// A new leader takes over; the old one can no longer write
+	newLeader := NewConfigMapStateStore(c, "agentic-system", "agentic-tenant-state")
+	loaded, err := newLeader.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Quotas["acme"].WorkloadsUsed != 3 || loaded.Quotas["acme"].CostUsed != 1.5 {
+		t.Fatalf("expected saved state loaded, got %+v", loaded.Quotas)
+	}
+	if err := newLeader.Save(ctx, loaded); err != nil {
+		t.Fatalf("new leader Save failed: %v", err)
+	}
+	if err := leader.Save(ctx, saved); !errors.Is(err, ErrStateConflict) {
+		t.Fatalf("expected ErrStateConflict for the old leader, got %v", err)
+	}
+}
@@ -17,6 +17,12 @@ type TenantContext struct {
 	ResourceQuotaRAM string // e.g., "20Gi"
 	CostBudgetUSD    float64
 	SLATargetPercent float64 // e.g., 99.0 for 99%, 99.9 for 99.9%
+	MaxMonthlyTokens int64   // Max LLM tokens per calendar month (0 = unlimited)
+	MaxConcurrent    int     // Max Running workloads (0 = unlimited)
+
+	// QuotaLocation is the time zone of the daily and monthly quota windows (nil = the
+	// QuotaManager's default)
+	QuotaLocation *time.Location
 
 	// Metadata
 	CreatedAt time.Time
@@ -53,7 +59,11 @@ type QuotaStatus struct {
 	WorkloadsUsed      int
 	WorkloadsRemaining int
 	CostThisMonth      float64
+	CostReserved       float64 // Held by reservations of running workloads
 	CostRemaining      float64
+	Reservations       int
+	TokensThisMonth    int64
+	MaxMonthlyTokens   int64
 	PercentageUsed     float64 // 0-100
 	LastReset          time.Time
 	IsExceeded         bool
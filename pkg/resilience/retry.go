@@ -2,6 +2,7 @@ package resilience
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -37,8 +38,32 @@ type RetryResult struct {
 	Duration time.Duration
 }
 
+// permanentError marks an error that retrying cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so WithRetry returns it without retrying. errors.Is and errors.As
+// still see the wrapped error.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent.
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
 // WithRetry executes fn with exponential backoff.
 // Returns (result, RetryResult) where result is nil on permanent failure.
+// Errors wrapped with Permanent are returned immediately.
 func WithRetry[T any](ctx context.Context, cfg RetryConfig, operationName string, fn func(ctx context.Context) (T, error)) (T, RetryResult) {
 	log := logf.FromContext(ctx)
 	start := time.Now()
@@ -52,6 +77,15 @@ func WithRetry[T any](ctx context.Context, cfg RetryConfig, operationName string
 
 		rr := RetryResult{Attempts: attempt + 1, LastErr: err, Duration: time.Since(start)}
 
+		if IsPermanent(err) {
+			log.Info("operation failed permanently, not retrying",
+				"operation", operationName,
+				"attempts", rr.Attempts,
+				"error", err.Error(),
+			)
+			return zero, rr
+		}
+
 		if attempt == cfg.MaxRetries {
 			log.Error(err, "operation failed after all retries",
 				"operation", operationName,
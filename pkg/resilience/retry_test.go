@@ -95,3 +95,22 @@ func TestBackoffDuration_MaxCap(t *testing.T) {
 		t.Errorf("expected max backoff 5s, got %v", d)
 	}
 }
+
+func TestWithRetry_PermanentErrorStopsRetries(t *testing.T) {
+	cfg := RetryConfig{MaxRetries: 3, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 100 * time.Millisecond, BackoffFactor: 2.0}
+	errQuota := errors.New("quota exceeded")
+	calls := 0
+	_, rr := WithRetry(context.Background(), cfg, "test-op", func(_ context.Context) (int, error) {
+		calls++
+		return 0, Permanent(errQuota)
+	})
+	if calls != 1 || rr.Attempts != 1 {
+		t.Errorf("expected a single attempt, got %d calls and %d attempts", calls, rr.Attempts)
+	}
+	if !errors.Is(rr.LastErr, errQuota) {
+		t.Errorf("expected the wrapped error to be preserved, got %v", rr.LastErr)
+	}
+	if Permanent(nil) != nil {
+		t.Errorf("expected Permanent(nil) to be nil")
+	}
+}